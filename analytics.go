@@ -3,17 +3,126 @@ package hookbase
 import (
 	"context"
 	"net/url"
+	"time"
 )
 
 // DashboardData represents analytics dashboard summary data.
 type DashboardData struct {
-	EventsReceived      int                      `json:"eventsReceived"`
-	DeliveriesCompleted int                      `json:"deliveriesCompleted"`
-	DeliverySuccessRate float64                  `json:"deliverySuccessRate"`
-	ActiveSources       int                      `json:"activeSources"`
-	ActiveDestinations  int                      `json:"activeDestinations"`
-	ActiveRoutes        int                      `json:"activeRoutes"`
-	Timeline            []map[string]interface{} `json:"timeline"`
+	EventsReceived      int             `json:"eventsReceived"`
+	DeliveriesCompleted int             `json:"deliveriesCompleted"`
+	DeliverySuccessRate float64         `json:"deliverySuccessRate"`
+	ActiveSources       int             `json:"activeSources"`
+	ActiveDestinations  int             `json:"activeDestinations"`
+	ActiveRoutes        int             `json:"activeRoutes"`
+	Timeline            []TimelinePoint `json:"timeline"`
+}
+
+// TimelinePoint is a single bucket of the dashboard timeline. Counts missing from the
+// response are zero.
+type TimelinePoint struct {
+	Timestamp           Time    `json:"timestamp"`
+	EventsReceived      int     `json:"eventsReceived"`
+	DeliveriesSucceeded int     `json:"deliveriesSucceeded"`
+	DeliveriesFailed    int     `json:"deliveriesFailed"`
+	AvgLatencyMs        float64 `json:"avgLatencyMs"`
+}
+
+// Timeline granularities.
+const (
+	GranularityHour = "hour"
+	GranularityDay  = "day"
+)
+
+// DashboardParams are the parameters for the analytics dashboard. Use either Range
+// (such as "24h" or "7d") or From/To.
+type DashboardParams struct {
+	Range         *string
+	From          time.Time
+	To            time.Time
+	Granularity   *string // GranularityHour or GranularityDay
+	SourceID      *string
+	DestinationID *string
+}
+
+func (p *DashboardParams) toQuery() url.Values {
+	if p == nil {
+		return nil
+	}
+	q := url.Values{}
+	if p.Range != nil {
+		q.Set("range", *p.Range)
+	}
+	if !p.From.IsZero() {
+		q.Set("from", p.From.UTC().Format(time.RFC3339))
+	}
+	if !p.To.IsZero() {
+		q.Set("to", p.To.UTC().Format(time.RFC3339))
+	}
+	if p.Granularity != nil {
+		q.Set("granularity", *p.Granularity)
+	}
+	if p.SourceID != nil {
+		q.Set("sourceId", *p.SourceID)
+	}
+	if p.DestinationID != nil {
+		q.Set("destinationId", *p.DestinationID)
+	}
+	return q
+}
+
+// OutboundAnalyticsParams filter outbound analytics queries. All fields are optional.
+type OutboundAnalyticsParams struct {
+	ApplicationID *string
+	EndpointID    *string
+	EventType     *string
+	From          time.Time
+	To            time.Time
+	Granularity   *string // GranularityHour or GranularityDay
+}
+
+func (p *OutboundAnalyticsParams) toQuery() url.Values {
+	if p == nil {
+		return nil
+	}
+	q := url.Values{}
+	if p.ApplicationID != nil {
+		q.Set("applicationId", *p.ApplicationID)
+	}
+	if p.EndpointID != nil {
+		q.Set("endpointId", *p.EndpointID)
+	}
+	if p.EventType != nil {
+		q.Set("eventType", *p.EventType)
+	}
+	if !p.From.IsZero() {
+		q.Set("from", p.From.UTC().Format(time.RFC3339))
+	}
+	if !p.To.IsZero() {
+		q.Set("to", p.To.UTC().Format(time.RFC3339))
+	}
+	if p.Granularity != nil {
+		q.Set("granularity", *p.Granularity)
+	}
+	return q
+}
+
+// OutboundTimeseriesPoint is a single bucket of outbound delivery analytics.
+type OutboundTimeseriesPoint struct {
+	Timestamp    Time    `json:"timestamp"`
+	Attempted    int     `json:"attempted"`
+	Delivered    int     `json:"delivered"`
+	Failed       int     `json:"failed"`
+	AvgLatencyMs float64 `json:"avgLatencyMs"`
+	P95LatencyMs float64 `json:"p95LatencyMs"`
+}
+
+// OutboundErrorBreakdown counts failed outbound deliveries by HTTP status class
+// (such as "4xx" and "5xx") and by error category (such as "timeout" or
+// "connection_refused").
+type OutboundErrorBreakdown struct {
+	Total         int            `json:"total"`
+	ByStatusClass map[string]int `json:"byStatusClass"`
+	ByCategory    map[string]int `json:"byCategory"`
 }
 
 // AnalyticsResource provides access to analytics-related API endpoints.
@@ -21,16 +130,46 @@ type AnalyticsResource struct {
 	t *transport
 }
 
-// Dashboard returns the analytics dashboard summary.
-func (r *AnalyticsResource) Dashboard(ctx context.Context, rangeStr string, opts ...RequestOption) (*DashboardData, error) {
-	q := url.Values{}
+// Dashboard returns the analytics dashboard summary. params may be nil.
+func (r *AnalyticsResource) Dashboard(ctx context.Context, params *DashboardParams, opts ...RequestOption) (*DashboardData, error) {
+	var resp struct {
+		Data DashboardData `json:"data"`
+	}
+	if err := r.t.do(ctx, "GET", "/api/analytics/dashboard", params.toQuery(), nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// DashboardRange returns the analytics dashboard summary for a preset range such as "7d".
+//
+// Deprecated: Use Dashboard with DashboardParams.Range.
+func (r *AnalyticsResource) DashboardRange(ctx context.Context, rangeStr string, opts ...RequestOption) (*DashboardData, error) {
+	params := &DashboardParams{}
 	if rangeStr != "" {
-		q.Set("range", rangeStr)
+		params.Range = &rangeStr
 	}
+	return r.Dashboard(ctx, params, opts...)
+}
+
+// OutboundTimeseries returns outbound delivery counts and latencies over time. params may be nil.
+func (r *AnalyticsResource) OutboundTimeseries(ctx context.Context, params *OutboundAnalyticsParams, opts ...RequestOption) ([]OutboundTimeseriesPoint, error) {
 	var resp struct {
-		Data DashboardData `json:"data"`
+		Data []OutboundTimeseriesPoint `json:"data"`
+	}
+	if err := r.t.do(ctx, "GET", "/api/analytics/outbound/timeseries", params.toQuery(), nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// ErrorBreakdown returns failed outbound delivery counts grouped by status class and
+// error category. params may be nil; Granularity is ignored.
+func (r *AnalyticsResource) ErrorBreakdown(ctx context.Context, params *OutboundAnalyticsParams, opts ...RequestOption) (*OutboundErrorBreakdown, error) {
+	var resp struct {
+		Data OutboundErrorBreakdown `json:"data"`
 	}
-	if err := r.t.do(ctx, "GET", "/api/analytics/dashboard", q, nil, &resp, opts...); err != nil {
+	if err := r.t.do(ctx, "GET", "/api/analytics/outbound/errors", params.toQuery(), nil, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp.Data, nil
@@ -3,8 +3,100 @@ package hookbase
 import (
 	"context"
 	"net/url"
+	"strings"
 )
 
+// API key scopes.
+const (
+	ScopeSourcesRead        = "sources:read"
+	ScopeSourcesWrite       = "sources:write"
+	ScopeDestinationsRead   = "destinations:read"
+	ScopeDestinationsWrite  = "destinations:write"
+	ScopeRoutesRead         = "routes:read"
+	ScopeRoutesWrite        = "routes:write"
+	ScopeEventsRead         = "events:read"
+	ScopeDeliveriesRead     = "deliveries:read"
+	ScopeDeliveriesWrite    = "deliveries:write"
+	ScopeTransformsRead     = "transforms:read"
+	ScopeTransformsWrite    = "transforms:write"
+	ScopeFiltersRead        = "filters:read"
+	ScopeFiltersWrite       = "filters:write"
+	ScopeSchemasRead        = "schemas:read"
+	ScopeSchemasWrite       = "schemas:write"
+	ScopeCronRead           = "cron:read"
+	ScopeCronWrite          = "cron:write"
+	ScopeTunnelsRead        = "tunnels:read"
+	ScopeTunnelsWrite       = "tunnels:write"
+	ScopeAnalyticsRead      = "analytics:read"
+	ScopeApplicationsRead   = "applications:read"
+	ScopeApplicationsWrite  = "applications:write"
+	ScopeEndpointsRead      = "endpoints:read"
+	ScopeEndpointsWrite     = "endpoints:write"
+	ScopeMessagesRead       = "messages:read"
+	ScopeMessagesSend       = "messages:send"
+	ScopeEventTypesRead     = "event-types:read"
+	ScopeEventTypesWrite    = "event-types:write"
+	ScopeSubscriptionsRead  = "subscriptions:read"
+	ScopeSubscriptionsWrite = "subscriptions:write"
+	ScopePortalWrite        = "portal:write"
+	ScopeDLQRead            = "dlq:read"
+	ScopeDLQWrite           = "dlq:write"
+	ScopeAPIKeysRead        = "api-keys:read"
+	ScopeAPIKeysWrite       = "api-keys:write"
+)
+
+var knownScopes = map[string]bool{
+	ScopeSourcesRead:        true,
+	ScopeSourcesWrite:       true,
+	ScopeDestinationsRead:   true,
+	ScopeDestinationsWrite:  true,
+	ScopeRoutesRead:         true,
+	ScopeRoutesWrite:        true,
+	ScopeEventsRead:         true,
+	ScopeDeliveriesRead:     true,
+	ScopeDeliveriesWrite:    true,
+	ScopeTransformsRead:     true,
+	ScopeTransformsWrite:    true,
+	ScopeFiltersRead:        true,
+	ScopeFiltersWrite:       true,
+	ScopeSchemasRead:        true,
+	ScopeSchemasWrite:       true,
+	ScopeCronRead:           true,
+	ScopeCronWrite:          true,
+	ScopeTunnelsRead:        true,
+	ScopeTunnelsWrite:       true,
+	ScopeAnalyticsRead:      true,
+	ScopeApplicationsRead:   true,
+	ScopeApplicationsWrite:  true,
+	ScopeEndpointsRead:      true,
+	ScopeEndpointsWrite:     true,
+	ScopeMessagesRead:       true,
+	ScopeMessagesSend:       true,
+	ScopeEventTypesRead:     true,
+	ScopeEventTypesWrite:    true,
+	ScopeSubscriptionsRead:  true,
+	ScopeSubscriptionsWrite: true,
+	ScopePortalWrite:        true,
+	ScopeDLQRead:            true,
+	ScopeDLQWrite:           true,
+	ScopeAPIKeysRead:        true,
+	ScopeAPIKeysWrite:       true,
+}
+
+// ValidateScopes returns a *ValidationError if any of the scopes is not a known scope.
+func ValidateScopes(scopes []string) error {
+	var unknown []string
+	for _, s := range scopes {
+		if !knownScopes[s] {
+			unknown = append(unknown, s)
+		}
+	}
+	if len(unknown) > 0 {
+		return newClientValidationError("scopes", "contains unknown scopes: "+strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
 // APIKey represents an API key.
 type APIKey struct {
 	ID             string   `json:"id"`
@@ -19,17 +111,19 @@ type APIKey struct {
 	UpdatedAt      string   `json:"updatedAt"`
 }
 
-// APIKeyWithSecret includes the full API key (only returned on creation).
+// APIKeyWithSecret includes the full API key (only returned on creation and rotation).
 type APIKeyWithSecret struct {
 	APIKey
 	Key string `json:"key"`
+	// PreviousKeyExpiresAt is set after a rotation; the old key keeps working until then.
+	PreviousKeyExpiresAt *string `json:"previousKeyExpiresAt,omitempty"`
 }
 
 // CreateAPIKeyParams are the parameters for creating an API key.
 type CreateAPIKeyParams struct {
-	Name         string   `json:"name"`
-	Scopes       []string `json:"scopes,omitempty"`
-	ExpiresInDays *int    `json:"expiresInDays,omitempty"`
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes,omitempty"`
+	ExpiresInDays *int     `json:"expiresInDays,omitempty"`
 }
 
 // UpdateAPIKeyParams are the parameters for updating an API key.
@@ -39,17 +133,143 @@ type UpdateAPIKeyParams struct {
 	IsDisabled *bool    `json:"isDisabled,omitempty"`
 }
 
+// ListAPIKeysParams are the parameters for listing API keys.
+type ListAPIKeysParams struct {
+	IsDisabled         *bool   `json:"isDisabled,omitempty"`
+	ExpiringWithinDays *int    `json:"expiringWithinDays,omitempty"`
+	Search             *string `json:"search,omitempty"`
+}
+
+func (p *ListAPIKeysParams) toQuery() url.Values {
+	if p == nil {
+		return nil
+	}
+	q := url.Values{}
+	if p.IsDisabled != nil {
+		q.Set("isDisabled", btoa(*p.IsDisabled))
+	}
+	if p.ExpiringWithinDays != nil {
+		q.Set("expiringWithinDays", itoa(*p.ExpiringWithinDays))
+	}
+	if p.Search != nil {
+		q.Set("search", *p.Search)
+	}
+	return q
+}
+
+// APIKeyUsageParams are the parameters for retrieving API key usage.
+type APIKeyUsageParams struct {
+	StartDate *string `json:"startDate,omitempty"`
+	EndDate   *string `json:"endDate,omitempty"`
+}
+
+func (p *APIKeyUsageParams) toQuery() url.Values {
+	if p == nil {
+		return nil
+	}
+	q := url.Values{}
+	if p.StartDate != nil {
+		q.Set("startDate", *p.StartDate)
+	}
+	if p.EndDate != nil {
+		q.Set("endDate", *p.EndDate)
+	}
+	return q
+}
+
+// APIKeyUsage summarizes how an API key has been used.
+type APIKeyUsage struct {
+	KeyID         string                `json:"keyId"`
+	TotalRequests int                   `json:"totalRequests"`
+	LastUsedAt    *string               `json:"lastUsedAt"`
+	LastUsedIP    *string               `json:"lastUsedIp"`
+	Daily         []APIKeyDailyUsage    `json:"daily"`
+	TopEndpoints  []APIKeyEndpointUsage `json:"topEndpoints"`
+}
+
+// APIKeyDailyUsage contains request counts for a single day.
+type APIKeyDailyUsage struct {
+	Date     string `json:"date"`
+	Requests int    `json:"requests"`
+	Errors   int    `json:"errors"`
+}
+
+// APIKeyEndpointUsage contains request counts for a single API endpoint.
+type APIKeyEndpointUsage struct {
+	Method   string `json:"method"`
+	Path     string `json:"path"`
+	Requests int    `json:"requests"`
+}
+
+// APIKeyAuditEvent is a record of a single request made with an API key.
+type APIKeyAuditEvent struct {
+	ID         string  `json:"id"`
+	Method     string  `json:"method"`
+	Path       string  `json:"path"`
+	StatusCode int     `json:"statusCode"`
+	IPAddress  *string `json:"ipAddress"`
+	UserAgent  *string `json:"userAgent"`
+	RequestID  *string `json:"requestId"`
+	CreatedAt  string  `json:"createdAt"`
+}
+
+// ListAPIKeyAuditEventsParams are the parameters for listing API key audit events.
+type ListAPIKeyAuditEventsParams struct {
+	Limit     *int    `json:"limit,omitempty"`
+	Cursor    *string `json:"cursor,omitempty"`
+	StartDate *string `json:"startDate,omitempty"`
+	EndDate   *string `json:"endDate,omitempty"`
+}
+
+func (p *ListAPIKeyAuditEventsParams) toQuery() url.Values {
+	if p == nil {
+		return nil
+	}
+	q := url.Values{}
+	if p.Limit != nil {
+		q.Set("limit", itoa(*p.Limit))
+	}
+	if p.Cursor != nil {
+		q.Set("cursor", *p.Cursor)
+	}
+	if p.StartDate != nil {
+		q.Set("startDate", *p.StartDate)
+	}
+	if p.EndDate != nil {
+		q.Set("endDate", *p.EndDate)
+	}
+	return q
+}
+
+// APIKeyScope describes a scope that can be granted to an API key.
+type APIKeyScope struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Category    string `json:"category"`
+}
+
 // APIKeysResource provides access to API key-related endpoints.
 type APIKeysResource struct {
 	t *transport
 }
 
-// List returns all API keys.
-func (r *APIKeysResource) List(ctx context.Context, opts ...RequestOption) ([]APIKey, error) {
+// List returns API keys matching params. params may be nil to list all keys.
+func (r *APIKeysResource) List(ctx context.Context, params *ListAPIKeysParams, opts ...RequestOption) ([]APIKey, error) {
 	var resp struct {
 		Data []APIKey `json:"data"`
 	}
-	if err := r.t.do(ctx, "GET", "/api/api-keys", nil, nil, &resp, opts...); err != nil {
+	if err := r.t.do(ctx, "GET", "/api/api-keys", params.toQuery(), nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// ListAvailableScopes returns every scope that can be granted to an API key.
+func (r *APIKeysResource) ListAvailableScopes(ctx context.Context, opts ...RequestOption) ([]APIKeyScope, error) {
+	var resp struct {
+		Data []APIKeyScope `json:"data"`
+	}
+	if err := r.t.do(ctx, "GET", "/api/api-keys/scopes", nil, nil, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return resp.Data, nil
@@ -68,6 +288,14 @@ func (r *APIKeysResource) Get(ctx context.Context, id string, opts ...RequestOpt
 
 // Create creates a new API key. The full key is only returned in this response.
 func (r *APIKeysResource) Create(ctx context.Context, params *CreateAPIKeyParams, opts ...RequestOption) (*APIKeyWithSecret, error) {
+	if params == nil {
+		return nil, newClientValidationError("name", "is required")
+	}
+	if r.t.clientValidation {
+		if err := ValidateScopes(params.Scopes); err != nil {
+			return nil, err
+		}
+	}
 	var resp struct {
 		Data APIKeyWithSecret `json:"data"`
 	}
@@ -77,8 +305,16 @@ func (r *APIKeysResource) Create(ctx context.Context, params *CreateAPIKeyParams
 	return &resp.Data, nil
 }
 
-// Update updates an API key.
+// Update updates an API key. A nil params changes nothing.
 func (r *APIKeysResource) Update(ctx context.Context, id string, params *UpdateAPIKeyParams, opts ...RequestOption) (*APIKey, error) {
+	if params == nil {
+		params = &UpdateAPIKeyParams{}
+	}
+	if r.t.clientValidation {
+		if err := ValidateScopes(params.Scopes); err != nil {
+			return nil, err
+		}
+	}
 	var resp struct {
 		Data APIKey `json:"data"`
 	}
@@ -92,3 +328,74 @@ func (r *APIKeysResource) Update(ctx context.Context, id string, params *UpdateA
 func (r *APIKeysResource) Delete(ctx context.Context, id string, opts ...RequestOption) error {
 	return r.t.do(ctx, "DELETE", "/api/api-keys/"+url.PathEscape(id), nil, nil, nil, opts...)
 }
+
+// Rotate issues a new secret for an API key. The previous key keeps working until
+// PreviousKeyExpiresAt. The new key is only returned in this response.
+func (r *APIKeysResource) Rotate(ctx context.Context, id string, opts ...RequestOption) (*APIKeyWithSecret, error) {
+	var resp struct {
+		Data APIKeyWithSecret `json:"data"`
+	}
+	if err := r.t.do(ctx, "POST", "/api/api-keys/"+url.PathEscape(id)+"/rotate", nil, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// RotateKey issues a new secret for an API key, keeping its ID, name, and scopes.
+// It is equivalent to Rotate.
+func (r *APIKeysResource) RotateKey(ctx context.Context, id string, opts ...RequestOption) (*APIKeyWithSecret, error) {
+	return r.Rotate(ctx, id, opts...)
+}
+
+// GetUsage returns request counts by day, the last-used IP, and the most frequently
+// called endpoints for an API key.
+func (r *APIKeysResource) GetUsage(ctx context.Context, id string, params *APIKeyUsageParams, opts ...RequestOption) (*APIKeyUsage, error) {
+	var resp struct {
+		Data APIKeyUsage `json:"data"`
+	}
+	if err := r.t.do(ctx, "GET", "/api/api-keys/"+url.PathEscape(id)+"/usage", params.toQuery(), nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// ListAuditEvents returns a cursor-paginated list of requests made with an API key.
+func (r *APIKeysResource) ListAuditEvents(ctx context.Context, id string, params *ListAPIKeyAuditEventsParams, opts ...RequestOption) (*CursorResponse[APIKeyAuditEvent], error) {
+	var resp struct {
+		Data       []APIKeyAuditEvent `json:"data"`
+		Pagination struct {
+			HasMore    bool    `json:"hasMore"`
+			NextCursor *string `json:"nextCursor"`
+		} `json:"pagination"`
+	}
+	if err := r.t.do(ctx, "GET", "/api/api-keys/"+url.PathEscape(id)+"/audit-events", params.toQuery(), nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &CursorResponse[APIKeyAuditEvent]{
+		Data:       resp.Data,
+		HasMore:    resp.Pagination.HasMore,
+		NextCursor: resp.Pagination.NextCursor,
+	}, nil
+}
+
+// BulkRevoke disables multiple API keys in a single request. The update is atomic:
+// either every key is disabled or none are.
+func (r *APIKeysResource) BulkRevoke(ctx context.Context, ids []string, opts ...RequestOption) (*BulkUpdateResult, error) {
+	return r.bulkUpdate(ctx, ids, map[string]interface{}{"isDisabled": true}, opts...)
+}
+
+// bulkUpdate applies the same field changes to multiple API keys.
+func (r *APIKeysResource) bulkUpdate(ctx context.Context, ids []string, fields map[string]interface{}, opts ...RequestOption) (*BulkUpdateResult, error) {
+	if len(ids) == 0 {
+		return nil, newClientValidationError("ids", "must contain at least one API key ID")
+	}
+	body := map[string]interface{}{"ids": ids}
+	for k, v := range fields {
+		body[k] = v
+	}
+	var resp BulkUpdateResult
+	if err := r.t.do(ctx, "PATCH", "/api/api-keys/bulk", nil, body, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
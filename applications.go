@@ -3,6 +3,7 @@ package hookbase
 import (
 	"context"
 	"net/url"
+	"sync"
 )
 
 // Application represents an outbound webhook application.
@@ -53,6 +54,33 @@ func (p *ListApplicationsParams) toQuery() url.Values {
 	return q
 }
 
+// ApplicationStats summarizes the health of an application's outbound delivery.
+type ApplicationStats struct {
+	ApplicationID         string           `json:"applicationId"`
+	EndpointCount         int              `json:"endpointCount"`
+	DisabledEndpointCount int              `json:"disabledEndpointCount"`
+	OpenCircuitCount      int              `json:"openCircuitCount"`
+	PendingMessages       int              `json:"pendingMessages"`
+	FailedMessages        int              `json:"failedMessages"`
+	DLQDepth              int              `json:"dlqDepth"`
+	LastDeliveryAt        *string          `json:"lastDeliveryAt"`
+	Endpoints             []EndpointHealth `json:"endpoints"`
+}
+
+// EndpointHealth is the per-endpoint part of ApplicationStats.
+type EndpointHealth struct {
+	EndpointID      string               `json:"endpointId"`
+	URL             string               `json:"url"`
+	IsDisabled      bool                 `json:"isDisabled"`
+	CircuitState    EndpointCircuitState `json:"circuitState"`
+	PendingMessages int                  `json:"pendingMessages"`
+	FailedMessages  int                  `json:"failedMessages"`
+	DLQDepth        int                  `json:"dlqDepth"`
+}
+
+// applicationStatsConcurrency bounds the number of requests GetStats runs at once.
+const applicationStatsConcurrency = 3
+
 // ApplicationsResource provides access to application-related API endpoints.
 type ApplicationsResource struct {
 	t *transport
@@ -148,3 +176,125 @@ func (r *ApplicationsResource) GetOrCreate(ctx context.Context, uid string, para
 	}
 	return &resp.Data, nil
 }
+
+// GetStats returns a health summary for an application. Message and DLQ counts come
+// from the outbound statistics summary, once for the application and once per
+// endpoint, so no messages are listed; only the application's endpoints and its most
+// recent successful delivery are. Requests run with bounded concurrency. Failed
+// messages include exhausted ones.
+func (r *ApplicationsResource) GetStats(ctx context.Context, applicationID string, opts ...RequestOption) (*ApplicationStats, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	endpointsRes := &EndpointsResource{t: r.t}
+	messagesRes := &MessagesResource{t: r.t}
+
+	// run runs jobs with at most applicationStatsConcurrency at once and returns the
+	// first error, cancelling the others.
+	run := func(jobs []func() error) error {
+		var (
+			wg       sync.WaitGroup
+			once     sync.Once
+			firstErr error
+		)
+		sem := make(chan struct{}, applicationStatsConcurrency)
+		for _, job := range jobs {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(job func() error) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				if err := job(); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}(job)
+		}
+		wg.Wait()
+		return firstErr
+	}
+
+	var (
+		endpoints    []Endpoint
+		summary      *OutboundStatsSummary
+		lastDelivery *string
+	)
+	err := run([]func() error{
+		func() error {
+			params := &ListEndpointsParams{Limit: Ptr(100), Offset: Ptr(0)}
+			for {
+				page, err := endpointsRes.List(ctx, applicationID, params, opts...)
+				if err != nil {
+					return err
+				}
+				endpoints = append(endpoints, page.Data...)
+				if !page.HasMore || len(page.Data) == 0 {
+					return nil
+				}
+				*params.Offset += len(page.Data)
+			}
+		},
+		func() error {
+			var err error
+			summary, err = messagesRes.GetStatsSummary(ctx, &OutboundStatsParams{ApplicationID: &applicationID}, opts...)
+			return err
+		},
+		func() error {
+			status := MessageSuccess
+			page, err := messagesRes.List(ctx, applicationID, &ListOutboundMessagesParams{Limit: Ptr(1), Status: &status}, opts...)
+			if err != nil {
+				return err
+			}
+			if len(page.Data) > 0 {
+				lastDelivery = page.Data[0].DeliveredAt
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &ApplicationStats{
+		ApplicationID:   applicationID,
+		EndpointCount:   len(endpoints),
+		PendingMessages: summary.Pending,
+		FailedMessages:  summary.Failed + summary.Exhausted,
+		DLQDepth:        summary.DLQ,
+		LastDeliveryAt:  lastDelivery,
+		Endpoints:       make([]EndpointHealth, len(endpoints)),
+	}
+	jobs := make([]func() error, len(endpoints))
+	for i, ep := range endpoints {
+		if ep.IsDisabled {
+			stats.DisabledEndpointCount++
+		}
+		if ep.CircuitState == EndpointCircuitOpen {
+			stats.OpenCircuitCount++
+		}
+		health := &stats.Endpoints[i]
+		*health = EndpointHealth{
+			EndpointID:   ep.ID,
+			URL:          ep.URL,
+			IsDisabled:   bool(ep.IsDisabled),
+			CircuitState: ep.CircuitState,
+		}
+		endpointID := ep.ID
+		jobs[i] = func() error {
+			s, err := messagesRes.GetStatsSummary(ctx, &OutboundStatsParams{ApplicationID: &applicationID, EndpointID: &endpointID}, opts...)
+			if err != nil {
+				return err
+			}
+			health.PendingMessages = s.Pending
+			health.FailedMessages = s.Failed + s.Exhausted
+			health.DLQDepth = s.DLQ
+			return nil
+		}
+	}
+	if err := run(jobs); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
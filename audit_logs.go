@@ -0,0 +1,150 @@
+package hookbase
+
+import (
+	"context"
+	"io"
+	"net/url"
+)
+
+// AuditActorType is the kind of actor that performed an audited action.
+type AuditActorType string
+
+// Audit log actor types.
+const (
+	ActorUser   AuditActorType = "user"
+	ActorAPIKey AuditActorType = "api_key"
+	ActorSystem AuditActorType = "system"
+)
+
+// AuditLogEntry records one action taken in the organization, such as a secret
+// rotation or a route deletion.
+type AuditLogEntry struct {
+	ID string `json:"id"`
+	// Actor is the ID of the user or API key that acted, or "system".
+	Actor     string         `json:"actor"`
+	ActorType AuditActorType `json:"actorType"`
+	// Action names what was done, such as "source.secret_rotated" or "route.deleted".
+	Action       string `json:"action"`
+	ResourceType string `json:"resourceType"`
+	ResourceID   string `json:"resourceId"`
+	// IP is the client IP address, or nil for system actions.
+	IP         *string                `json:"ip"`
+	Metadata   map[string]interface{} `json:"metadata"`
+	OccurredAt string                 `json:"occurredAt"`
+}
+
+// ListAuditLogsParams are the parameters for listing audit log entries.
+type ListAuditLogsParams struct {
+	Limit        *int            `json:"limit,omitempty"`
+	Cursor       *string         `json:"cursor,omitempty"`
+	Actor        *string         `json:"actor,omitempty"`
+	ActorType    *AuditActorType `json:"actorType,omitempty"`
+	Action       *string         `json:"action,omitempty"`
+	ResourceType *string         `json:"resourceType,omitempty"`
+	ResourceID   *string         `json:"resourceId,omitempty"`
+	StartDate    *string         `json:"startDate,omitempty"`
+	EndDate      *string         `json:"endDate,omitempty"`
+}
+
+func (p *ListAuditLogsParams) toQuery() url.Values {
+	if p == nil {
+		return nil
+	}
+	q := url.Values{}
+	if p.Limit != nil {
+		q.Set("limit", itoa(*p.Limit))
+	}
+	if p.Cursor != nil {
+		q.Set("cursor", *p.Cursor)
+	}
+	if p.Actor != nil {
+		q.Set("actor", *p.Actor)
+	}
+	if p.ActorType != nil {
+		q.Set("actorType", string(*p.ActorType))
+	}
+	if p.Action != nil {
+		q.Set("action", *p.Action)
+	}
+	if p.ResourceType != nil {
+		q.Set("resourceType", *p.ResourceType)
+	}
+	if p.ResourceID != nil {
+		q.Set("resourceId", *p.ResourceID)
+	}
+	if p.StartDate != nil {
+		q.Set("startDate", *p.StartDate)
+	}
+	if p.EndDate != nil {
+		q.Set("endDate", *p.EndDate)
+	}
+	return q
+}
+
+// AuditLogsResource provides access to the organization's audit log.
+type AuditLogsResource struct {
+	t *transport
+}
+
+// List returns a cursor-paginated list of audit log entries matching params, newest
+// first. params may be nil to list all entries.
+func (r *AuditLogsResource) List(ctx context.Context, params *ListAuditLogsParams, opts ...RequestOption) (*CursorResponse[AuditLogEntry], error) {
+	var resp struct {
+		Data       []AuditLogEntry `json:"data"`
+		Pagination struct {
+			HasMore    bool    `json:"hasMore"`
+			NextCursor *string `json:"nextCursor"`
+		} `json:"pagination"`
+	}
+	if err := r.t.do(ctx, "GET", "/api/audit-logs", params.toQuery(), nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &CursorResponse[AuditLogEntry]{
+		Data:       resp.Data,
+		HasMore:    resp.Pagination.HasMore,
+		NextCursor: resp.Pagination.NextCursor,
+	}, nil
+}
+
+// ListAll calls fn for every audit log entry matching params, following cursors
+// until the last page. params' Cursor is the starting point and Limit the page size
+// (100 if unset); params itself is not modified. If fn returns an error, ListAll
+// stops and returns it.
+func (r *AuditLogsResource) ListAll(ctx context.Context, params *ListAuditLogsParams, fn func(AuditLogEntry) error, opts ...RequestOption) error {
+	p := ListAuditLogsParams{}
+	if params != nil {
+		p = *params
+	}
+	if p.Limit == nil {
+		p.Limit = Ptr(100)
+	}
+	for {
+		page, err := r.List(ctx, &p, opts...)
+		if err != nil {
+			return err
+		}
+		for _, entry := range page.Data {
+			if err := fn(entry); err != nil {
+				return err
+			}
+		}
+		if !page.HasMore || page.NextCursor == nil {
+			return nil
+		}
+		p.Cursor = page.NextCursor
+	}
+}
+
+// Export streams audit log entries matching params to w as CSV, without holding the
+// export in memory. params' Limit and Cursor are ignored. Response interceptors are
+// not run on the export body.
+func (r *AuditLogsResource) Export(ctx context.Context, params *ListAuditLogsParams, w io.Writer, opts ...RequestOption) error {
+	q := params.toQuery()
+	if q == nil {
+		q = url.Values{}
+	}
+	q.Del("limit")
+	q.Del("cursor")
+	q.Set("format", "csv")
+	return r.t.doStream(ctx, "GET", "/api/audit-logs/export", q, w, opts...)
+}
@@ -2,11 +2,13 @@ package hookbase
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"math"
 	"math/rand"
 	"net/http"
@@ -19,32 +21,68 @@ import (
 const sdkVersion = "0.1.0"
 
 type transport struct {
-	apiKey     string
-	baseURL    string
-	timeout    time.Duration
-	maxRetries int
-	httpClient *http.Client
-	debug      bool
+	apiKey           string
+	baseURL          string
+	portalBaseURL    string
+	timeout          time.Duration
+	resourceTimeouts map[string]time.Duration
+	maxRetries       int
+	retryPolicy      *RetryPolicy
+	httpClient       *http.Client
+	debug            bool
+	gzip             bool
+	logger           *slog.Logger
+	organizationID   string
+	tracer           Tracer
+	requestHooks     []func(*http.Request) error
+	responseHooks    []func(*http.Response, []byte) error
+	clientValidation bool
+	outboundSchemas  bool
+	schemaCache      *eventTypeSchemaCache
 }
 
 func newTransport(apiKey string, cfg *clientConfig) *transport {
+	// Timeouts are applied per attempt by do, so the default client has none of its
+	// own that would cap a longer resource or request timeout.
 	httpClient := cfg.httpClient
 	if httpClient == nil {
-		httpClient = &http.Client{Timeout: cfg.timeout}
+		httpClient = &http.Client{}
+	}
+	if cfg.recorder != nil {
+		wrapped := *httpClient
+		wrapped.Transport = cfg.recorder.roundTripper(httpClient.Transport)
+		httpClient = &wrapped
+	}
+
+	logger := cfg.logger
+	if logger == nil && cfg.debug {
+		logger = slog.Default()
 	}
 
 	return &transport{
-		apiKey:     apiKey,
-		baseURL:    cfg.baseURL,
-		timeout:    cfg.timeout,
-		maxRetries: cfg.maxRetries,
-		httpClient: httpClient,
-		debug:      cfg.debug,
+		apiKey:           apiKey,
+		baseURL:          cfg.baseURL,
+		portalBaseURL:    cfg.portalBaseURL,
+		timeout:          cfg.timeout,
+		resourceTimeouts: timeoutsByPathPrefix(cfg.resourceTimeouts),
+		maxRetries:       cfg.maxRetries,
+		retryPolicy:      cfg.retryPolicy,
+		httpClient:       httpClient,
+		debug:            cfg.debug,
+		gzip:             cfg.gzip,
+		logger:           logger,
+		organizationID:   cfg.organizationID,
+		tracer:           cfg.tracer,
+		requestHooks:     cfg.requestHooks,
+		responseHooks:    cfg.responseHooks,
+		clientValidation: cfg.clientValidation,
+		outboundSchemas:  cfg.outboundSchemas,
+		schemaCache:      newEventTypeSchemaCache(),
 	}
 }
 
-func (t *transport) do(ctx context.Context, method, path string, query url.Values, body interface{}, out interface{}, opts ...RequestOption) error {
-	rc := &requestConfig{timeout: t.timeout}
+func (t *transport) do(ctx context.Context, method, path string, query url.Values, body interface{}, out interface{}, opts ...RequestOption) (err error) {
+	rc := &requestConfig{timeout: t.timeoutFor(path), organizationID: t.organizationID}
 	for _, opt := range opts {
 		opt(rc)
 	}
@@ -55,132 +93,413 @@ func (t *transport) do(ctx context.Context, method, path string, query url.Value
 	}
 
 	// Build URL
-	u := t.baseURL + path
+	baseURL := t.baseURL
+	if rc.baseURL != "" {
+		baseURL = rc.baseURL
+	}
+	u := baseURL + path
 	if len(query) > 0 {
 		u += "?" + query.Encode()
 	}
 
+	var span CallSpan
+	if t.tracer != nil {
+		ctx, span = t.tracer.StartCall(ctx, TracedCall{Method: method, Path: path, URL: u, Resource: resourceFromPath(path)})
+		defer func() { span.End(err) }()
+	}
+
 	// Encode body
-	var bodyReader io.Reader
 	var bodyBytes []byte
 	if body != nil {
-		var err error
-		bodyBytes, err = json.Marshal(body)
-		if err != nil {
+		// HTML escaping is disabled so raw payloads reach the API unchanged.
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(body); err != nil {
 			return &Error{Message: fmt.Sprintf("failed to marshal request body: %v", err)}
 		}
+		bodyBytes = bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
 	}
 
-	if t.debug {
-		log.Printf("[hookbase] %s %s", method, u)
-		if bodyBytes != nil {
-			log.Printf("[hookbase] Body: %s", string(bodyBytes))
+	var lastErr error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		retryable, err := t.attempt(ctx, rc, span, method, path, u, bodyBytes, out)
+		if !retryable || attempt >= maxRetries || !t.shouldRetry(err, attempt) {
+			return err
+		}
+		lastErr = err
+		if err := sleepContext(ctx, t.retryDelay(err, attempt)); err != nil {
+			return &TimeoutError{Message: err.Error()}
 		}
 	}
 
-	var lastErr error
-	for attempt := 0; attempt <= maxRetries; attempt++ {
-		if bodyBytes != nil {
-			bodyReader = bytes.NewReader(bodyBytes)
+	return lastErr
+}
+
+// attempt makes one attempt at a request for do. Its timeout is cancelled, and the
+// response body closed, before it returns. retryable reports whether err came from
+// the network or the API and so may be retried; other errors end the call.
+func (t *transport) attempt(ctx context.Context, rc *requestConfig, span CallSpan, method, path, u string, bodyBytes []byte, out interface{}) (retryable bool, err error) {
+	var bodyReader io.Reader
+	if bodyBytes != nil {
+		bodyReader = bytes.NewReader(bodyBytes)
+	}
+	attemptCtx := ctx
+	if rc.timeout > 0 {
+		var cancel context.CancelFunc
+		attemptCtx, cancel = context.WithTimeout(ctx, rc.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(attemptCtx, method, u, bodyReader)
+	if err != nil {
+		return false, &NetworkError{Message: "failed to create request", Cause: err}
+	}
+
+	req.Header.Set("Authorization", "Bearer "+t.apiKey)
+	req.Header.Set("User-Agent", "hookbase-go/"+sdkVersion)
+	switch out.(type) {
+	case *[]byte, streamWriter:
+		req.Header.Set("Accept", "*/*")
+	default:
+		req.Header.Set("Accept", "application/json")
+	}
+	if bodyBytes != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if t.gzip {
+		// Setting Accept-Encoding turns off net/http's own decompression, so
+		// gzip responses are decoded by decodedBody below.
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+	if rc.idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", rc.idempotencyKey)
+	}
+	if rc.ifMatch != "" {
+		req.Header.Set("If-Match", rc.ifMatch)
+	}
+	if rc.organizationID != "" {
+		req.Header.Set("X-Organization-ID", rc.organizationID)
+	}
+	if span != nil {
+		span.InjectHeaders(req.Header)
+	}
+	for _, hook := range t.requestHooks {
+		if err := hook(req); err != nil {
+			return false, err
 		}
+	}
 
-		req, err := http.NewRequestWithContext(ctx, method, u, bodyReader)
-		if err != nil {
-			return &NetworkError{Message: "failed to create request", Cause: err}
+	start := time.Now()
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		t.logRequest(ctx, method, path, 0, start, "", bodyBytes, nil, err)
+		var mismatch *ReplayMismatchError
+		if errors.As(err, &mismatch) {
+			return false, mismatch
 		}
+		if ctx.Err() != nil {
+			return false, &TimeoutError{Message: ctx.Err().Error()}
+		}
+		return true, &NetworkError{Message: err.Error(), Cause: err}
+	}
 
-		req.Header.Set("Authorization", "Bearer "+t.apiKey)
-		req.Header.Set("User-Agent", "hookbase-go/"+sdkVersion)
-		req.Header.Set("Accept", "application/json")
-		if body != nil {
-			req.Header.Set("Content-Type", "application/json")
+	defer resp.Body.Close()
+	if rc.responseMeta != nil {
+		*rc.responseMeta = ResponseMeta{
+			StatusCode: resp.StatusCode,
+			RequestID:  resp.Header.Get("X-Request-Id"),
+			Header:     resp.Header,
+			Quotas:     parseQuotaHeaders(resp.Header),
 		}
-		if rc.idempotencyKey != "" {
-			req.Header.Set("Idempotency-Key", rc.idempotencyKey)
+	}
+	decoded, err := decodedBody(resp)
+	if err != nil {
+		return false, err
+	}
+	if w, ok := out.(streamWriter); ok && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		requestID := resp.Header.Get("X-Request-Id")
+		t.logRequest(ctx, method, path, resp.StatusCode, start, requestID, bodyBytes, nil, nil)
+		if span != nil {
+			span.RecordResponse(resp.StatusCode, requestID)
+		}
+		if _, err := io.Copy(w.Writer, decoded); err != nil {
+			return false, &NetworkError{Message: "failed to read response body", Cause: err}
 		}
+		return false, nil
+	}
+	respReader := decoded
+	if rc.maxBodyBytes > 0 {
+		if resp.ContentLength > rc.maxBodyBytes {
+			return false, &Error{Message: fmt.Sprintf("hookbase: response body of %d bytes exceeds the %d byte limit", resp.ContentLength, rc.maxBodyBytes)}
+		}
+		respReader = io.LimitReader(decoded, rc.maxBodyBytes+1)
+	}
+	respBody, err := io.ReadAll(respReader)
+	if err != nil {
+		return true, &NetworkError{Message: "failed to read response body", Cause: err}
+	}
+	if rc.maxBodyBytes > 0 && int64(len(respBody)) > rc.maxBodyBytes {
+		return false, &Error{Message: fmt.Sprintf("hookbase: response body exceeds the %d byte limit", rc.maxBodyBytes)}
+	}
 
-		resp, err := t.httpClient.Do(req)
-		if err != nil {
-			lastErr = &NetworkError{Message: err.Error(), Cause: err}
-			if ctx.Err() != nil {
-				return &TimeoutError{Message: ctx.Err().Error()}
-			}
-			if attempt < maxRetries {
-				t.backoff(attempt)
-				continue
+	requestID := resp.Header.Get("X-Request-Id")
+	t.logRequest(ctx, method, path, resp.StatusCode, start, requestID, bodyBytes, respBody, nil)
+	if span != nil {
+		span.RecordResponse(resp.StatusCode, requestID)
+	}
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		for _, hook := range t.responseHooks {
+			if err := hook(resp, respBody); err != nil {
+				return false, err
 			}
-			return lastErr
 		}
+		if resp.StatusCode == 204 || out == nil {
+			return false, nil
+		}
+		if raw, ok := out.(*[]byte); ok {
+			*raw = respBody
+			return false, nil
+		}
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return false, &Error{Message: fmt.Sprintf("failed to unmarshal response: %v", err)}
+		}
+		return false, nil
+	}
 
-		defer resp.Body.Close()
-		respBody, err := io.ReadAll(resp.Body)
-		if err != nil {
-			lastErr = &NetworkError{Message: "failed to read response body", Cause: err}
-			if attempt < maxRetries {
-				t.backoff(attempt)
-				continue
-			}
-			return lastErr
+	return true, t.mapError(resp.StatusCode, respBody, requestID, resp.Header)
+}
+
+// resourcePathPrefixes maps the lowercased names of Client resource fields, as accepted
+// by WithResourceTimeout, to the API path prefixes their methods call.
+var resourcePathPrefixes = map[string][]string{
+	"sources":       {"/api/sources"},
+	"destinations":  {"/api/destinations"},
+	"routes":        {"/api/routes"},
+	"events":        {"/api/events"},
+	"deliveries":    {"/api/deliveries"},
+	"transforms":    {"/api/transforms"},
+	"filters":       {"/api/filters"},
+	"schemas":       {"/api/schemas"},
+	"apikeys":       {"/api/api-keys"},
+	"cron":          {"/api/cron", "/api/cron-groups"},
+	"tunnels":       {"/api/tunnels"},
+	"analytics":     {"/api/analytics"},
+	"organization":  {"/api/organization"},
+	"usage":         {"/api/usage"},
+	"auditlogs":     {"/api/audit-logs"},
+	"notifications": {"/api/notifications"},
+	"applications":  {"/api/webhook-applications"},
+	"endpoints":     {"/api/webhook-endpoints"},
+	"messages":      {"/api/outbound-messages", "/api/scheduled-messages", "/api/send-event", "/api/send-events-batch"},
+	"eventtypes":    {"/api/event-types"},
+	"subscriptions": {"/api/webhook-subscriptions"},
+	"portaltokens":  {"/api/portal"},
+	"dlq":           {"/api/outbound-messages/dlq"},
+}
+
+// timeoutsByPathPrefix turns WithResourceTimeout values keyed by resource name into
+// values keyed by path prefix.
+func timeoutsByPathPrefix(byResource map[string]time.Duration) map[string]time.Duration {
+	if len(byResource) == 0 {
+		return nil
+	}
+	out := make(map[string]time.Duration)
+	for name, d := range byResource {
+		for _, prefix := range resourcePathPrefixes[name] {
+			out[prefix] = d
 		}
-		resp.Body.Close()
+	}
+	return out
+}
 
-		if t.debug {
-			log.Printf("[hookbase] Response %d: %s", resp.StatusCode, string(respBody))
+// timeoutFor returns the timeout for requests to path: the WithResourceTimeout value
+// for the resource with the longest path prefix matching whole segments of path, so
+// that DLQ calls are not matched by Messages, and the client timeout otherwise.
+func (t *transport) timeoutFor(path string) time.Duration {
+	timeout, matched := t.timeout, 0
+	for prefix, d := range t.resourceTimeouts {
+		if len(prefix) > matched && (path == prefix || strings.HasPrefix(path, prefix+"/")) {
+			timeout, matched = d, len(prefix)
 		}
+	}
+	return timeout
+}
 
-		requestID := resp.Header.Get("X-Request-Id")
+// doRaw is like do but returns the response body as-is instead of decoding it as JSON.
+// Error responses are still mapped to typed errors.
+func (t *transport) doRaw(ctx context.Context, method, path string, query url.Values, body interface{}, opts ...RequestOption) ([]byte, error) {
+	var raw []byte
+	if err := t.do(ctx, method, path, query, body, &raw, opts...); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
 
-		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-			if resp.StatusCode == 204 || out == nil {
-				return nil
-			}
-			if err := json.Unmarshal(respBody, out); err != nil {
-				return &Error{Message: fmt.Sprintf("failed to unmarshal response: %v", err)}
-			}
-			return nil
+// decodedBody returns the response body, decompressing it if the server sent it with
+// gzip Content-Encoding.
+func decodedBody(resp *http.Response) (io.Reader, error) {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return resp.Body, nil
+	}
+	zr, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, &NetworkError{Message: "failed to decompress response body", Cause: err}
+	}
+	return zr, nil
+}
+
+// streamWriter is passed to do as out to copy a successful response body to Writer as
+// it is read.
+type streamWriter struct {
+	io.Writer
+}
+
+// doStream is like doRaw but copies the response body to w instead of buffering it.
+// Error responses are still mapped to typed errors. Once copying has started, the
+// request is not retried.
+func (t *transport) doStream(ctx context.Context, method, path string, query url.Values, w io.Writer, opts ...RequestOption) error {
+	return t.do(ctx, method, path, query, nil, streamWriter{w}, opts...)
+}
+
+// logRequest records one HTTP attempt on the configured logger. Entries are logged at
+// debug level, or at info level with request and response bodies when debug is enabled.
+func (t *transport) logRequest(ctx context.Context, method, path string, status int, start time.Time, requestID string, reqBody, respBody []byte, err error) {
+	if t.logger == nil {
+		return
+	}
+	level := slog.LevelDebug
+	if t.debug {
+		level = slog.LevelInfo
+	}
+	if !t.logger.Enabled(ctx, level) {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("path", path),
+		slog.Int("status_code", status),
+		slog.Int64("duration_ms", time.Since(start).Milliseconds()),
+		slog.String("request_id", requestID),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	if t.debug {
+		if reqBody != nil {
+			attrs = append(attrs, slog.String("request_body", string(redactBody(reqBody))))
+		}
+		if respBody != nil {
+			attrs = append(attrs, slog.String("response_body", string(redactBody(respBody))))
 		}
+	}
+	t.logger.LogAttrs(ctx, level, "hookbase request", attrs...)
+}
+
+// redactedFields are JSON object keys whose values are replaced before bodies are
+// logged or recorded to a cassette: portal tokens, API keys, source signing secrets,
+// tunnel auth tokens, passwords, and secrets in notification channel and endpoint
+// configs. Custom headers are redacted whole, since they often carry credentials.
+var redactedFields = map[string]bool{
+	"token":         true,
+	"secret":        true,
+	"webhookUrl":    true,
+	"routingKey":    true,
+	"headers":       true,
+	"key":           true,
+	"signingSecret": true,
+	"authToken":     true,
+	"password":      true,
+}
+
+// redactBody replaces the values of redactedFields anywhere in a JSON body. Bodies that
+// are not JSON are returned unchanged.
+func redactBody(body []byte) []byte {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return body
+	}
+	out, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return body
+	}
+	return out
+}
 
-		apiErr := t.mapError(resp.StatusCode, respBody, requestID, resp.Header)
-
-		// Don't retry client errors (except 429)
-		switch apiErr.(type) {
-		case *AuthenticationError, *ForbiddenError, *NotFoundError, *ValidationError:
-			return apiErr
-		case *RateLimitError:
-			if attempt < maxRetries {
-				rle := apiErr.(*RateLimitError)
-				time.Sleep(time.Duration(rle.RetryAfter) * time.Second)
-				continue
+func redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, child := range val {
+			if redactedFields[k] {
+				val[k] = "[REDACTED]"
+			} else {
+				val[k] = redactValue(child)
 			}
-			return apiErr
 		}
-
-		// Retry 5xx
-		lastErr = apiErr
-		if attempt < maxRetries {
-			t.backoff(attempt)
-			continue
+	case []interface{}:
+		for i, child := range val {
+			val[i] = redactValue(child)
 		}
 	}
+	return v
+}
 
-	return lastErr
+// shouldRetry reports whether a failed attempt should be retried, using the retry
+// policy's ShouldRetry if one is set.
+func (t *transport) shouldRetry(err error, attempt int) bool {
+	if t.retryPolicy != nil && t.retryPolicy.ShouldRetry != nil {
+		return t.retryPolicy.ShouldRetry(err, attempt)
+	}
+	return defaultShouldRetry(err)
 }
 
-func (t *transport) backoff(attempt int) {
+// retryDelay returns how long to wait before retrying after err. It honors the
+// Retry-After of rate limit and service unavailable errors, and otherwise backs off.
+func (t *transport) retryDelay(err error, attempt int) time.Duration {
+	switch e := err.(type) {
+	case *RateLimitError:
+		return time.Duration(e.RetryAfter) * time.Second
+	case *ServiceUnavailableError:
+		if e.RetryAfter > 0 {
+			return time.Duration(e.RetryAfter) * time.Second
+		}
+	}
+	return t.backoff(attempt)
+}
+
+func (t *transport) backoff(attempt int) time.Duration {
+	if t.retryPolicy != nil {
+		return t.retryPolicy.wait(attempt)
+	}
 	base := math.Min(float64(1000*int(math.Pow(2, float64(attempt)))), 10000)
 	jitter := rand.Float64() * 1000
-	time.Sleep(time.Duration(base+jitter) * time.Millisecond)
+	return time.Duration(base+jitter) * time.Millisecond
+}
+
+// sleepContext waits for d, returning early with ctx's error if ctx is done first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
 func (t *transport) mapError(status int, body []byte, requestID string, headers http.Header) error {
 	var errBody struct {
 		Error struct {
-			Message          string              `json:"message"`
-			Code             string              `json:"code"`
-			ValidationErrors map[string][]string `json:"validationErrors"`
+			Message               string              `json:"message"`
+			Code                  string              `json:"code"`
+			ValidationErrors      map[string][]string `json:"validationErrors"`
+			ConflictingResourceID string              `json:"conflictingResourceId"`
 		} `json:"error"`
-		Message string `json:"message"`
-		Code    string `json:"code"`
+		Message               string `json:"message"`
+		Code                  string `json:"code"`
+		ConflictingResourceID string `json:"conflictingResourceId"`
 	}
 	json.Unmarshal(body, &errBody)
 
@@ -192,12 +511,12 @@ func (t *transport) mapError(status int, body []byte, requestID string, headers
 		msg = fmt.Sprintf("API error: %d", status)
 	}
 
-	code := errBody.Error.Code
+	code := ErrorCode(errBody.Error.Code)
 	if code == "" {
-		code = errBody.Code
+		code = ErrorCode(errBody.Code)
 	}
 	if code == "" {
-		code = "unknown_error"
+		code = CodeUnknown
 	}
 
 	base := APIError{
@@ -214,24 +533,39 @@ func (t *transport) mapError(status int, body []byte, requestID string, headers
 		return &ForbiddenError{APIError: base}
 	case 404:
 		return &NotFoundError{APIError: base}
+	case 409, 412:
+		conflictingID := errBody.Error.ConflictingResourceID
+		if conflictingID == "" {
+			conflictingID = errBody.ConflictingResourceID
+		}
+		return &ConflictError{APIError: base, ConflictingResourceID: conflictingID}
 	case 400, 422:
 		return &ValidationError{
 			APIError:         base,
 			ValidationErrors: errBody.Error.ValidationErrors,
 		}
 	case 429:
-		retryAfter := 60
-		if ra := headers.Get("Retry-After"); ra != "" {
-			if v, err := strconv.Atoi(ra); err == nil {
-				retryAfter = v
-			}
-		}
-		return &RateLimitError{APIError: base, RetryAfter: retryAfter}
+		return &RateLimitError{APIError: base, RetryAfter: retryAfterSeconds(headers, 60)}
+	case 503:
+		return &ServiceUnavailableError{APIError: base, RetryAfter: retryAfterSeconds(headers, 0)}
+	case 504:
+		return &GatewayTimeoutError{APIError: base}
 	default:
 		return &base
 	}
 }
 
+// retryAfterSeconds returns the Retry-After header in seconds, or def if it is missing
+// or not a number.
+func retryAfterSeconds(headers http.Header, def int) int {
+	if ra := headers.Get("Retry-After"); ra != "" {
+		if v, err := strconv.Atoi(ra); err == nil {
+			return v
+		}
+	}
+	return def
+}
+
 // buildQuery converts a params struct to url.Values. It handles string, int, bool, and pointer types.
 func buildQuery(params map[string]interface{}) url.Values {
 	q := url.Values{}
@@ -2,7 +2,9 @@ package hookbase
 
 import (
 	"context"
+	"fmt"
 	"net/url"
+	"time"
 )
 
 // CronJob represents a scheduled cron job.
@@ -18,6 +20,7 @@ type CronJob struct {
 	Body           *string `json:"body"`
 	Timezone       string  `json:"timezone"`
 	IsActive       FlexBool `json:"isActive"`
+	GroupID        *string `json:"groupId"`
 	LastRunAt      *string `json:"lastRunAt"`
 	NextRunAt      *string `json:"nextRunAt"`
 	LastStatus     *string `json:"lastStatus"`
@@ -36,6 +39,7 @@ type CreateCronParams struct {
 	Body        *string           `json:"body,omitempty"`
 	Timezone    *string           `json:"timezone,omitempty"`
 	IsActive    *bool             `json:"isActive,omitempty"`
+	GroupID     *string           `json:"groupId,omitempty"`
 }
 
 // UpdateCronParams are the parameters for updating a cron job.
@@ -49,6 +53,9 @@ type UpdateCronParams struct {
 	Body        *string           `json:"body,omitempty"`
 	Timezone    *string           `json:"timezone,omitempty"`
 	IsActive    *bool             `json:"isActive,omitempty"`
+	// GroupID moves the job to a group with NullableValue, or out of its group with
+	// NullValue.
+	GroupID *Nullable[string] `json:"groupId,omitempty"`
 }
 
 // CronGroup represents a group of cron jobs.
@@ -68,6 +75,42 @@ type CreateCronGroupParams struct {
 	Description *string `json:"description,omitempty"`
 }
 
+// UpdateCronGroupParams are the parameters for updating a cron group.
+type UpdateCronGroupParams struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	SortOrder   *int    `json:"sortOrder,omitempty"`
+}
+
+// CronTriggerResult is the outcome of a manually triggered cron job execution.
+type CronTriggerResult struct {
+	ExecutionID     string  `json:"executionId"`
+	Status          string  `json:"status"`
+	StatusCode      *int    `json:"statusCode"`
+	DurationMs      *int    `json:"durationMs"`
+	Success         bool    `json:"success"`
+	ResponseExcerpt *string `json:"responseExcerpt"`
+}
+
+// IsTerminal reports whether the execution has finished. Executions with a "pending"
+// or "running" status are still in progress.
+func (r *CronTriggerResult) IsTerminal() bool {
+	return r.Status != "pending" && r.Status != "running"
+}
+
+// TriggerAndWaitParams configures polling for TriggerAndWait.
+type TriggerAndWaitParams struct {
+	Timeout      time.Duration // default 60s
+	PollInterval time.Duration // default 1s
+}
+
+// CronPauseSnapshot records which cron jobs were paused by PauseAll, so that
+// ResumeAll re-enables only those jobs.
+type CronPauseSnapshot struct {
+	PausedIDs []string `json:"pausedIds"`
+	PausedAt  string   `json:"pausedAt"`
+}
+
 // CronResource provides access to cron job-related API endpoints.
 type CronResource struct {
 	t *transport
@@ -97,6 +140,11 @@ func (r *CronResource) Get(ctx context.Context, id string, opts ...RequestOption
 
 // Create creates a new cron job.
 func (r *CronResource) Create(ctx context.Context, params *CreateCronParams, opts ...RequestOption) (*CronJob, error) {
+	if r.t.clientValidation {
+		if err := ValidateCronExpression(params.Schedule); err != nil {
+			return nil, err
+		}
+	}
 	var resp struct {
 		CronJob CronJob `json:"cronJob"`
 	}
@@ -108,6 +156,11 @@ func (r *CronResource) Create(ctx context.Context, params *CreateCronParams, opt
 
 // Update updates a cron job.
 func (r *CronResource) Update(ctx context.Context, id string, params *UpdateCronParams, opts ...RequestOption) (*CronJob, error) {
+	if r.t.clientValidation && params.Schedule != nil {
+		if err := ValidateCronExpression(*params.Schedule); err != nil {
+			return nil, err
+		}
+	}
 	var resp struct {
 		CronJob CronJob `json:"cronJob"`
 	}
@@ -122,9 +175,147 @@ func (r *CronResource) Delete(ctx context.Context, id string, opts ...RequestOpt
 	return r.t.do(ctx, "DELETE", "/api/cron/"+url.PathEscape(id), nil, nil, nil, opts...)
 }
 
-// Trigger manually triggers a cron job.
-func (r *CronResource) Trigger(ctx context.Context, id string, opts ...RequestOption) error {
-	return r.t.do(ctx, "POST", "/api/cron/"+url.PathEscape(id)+"/trigger", nil, nil, nil, opts...)
+// Trigger manually triggers a cron job. If the API runs the job asynchronously, the
+// returned result has a pending or running Status; use TriggerAndWait to block until
+// it finishes.
+func (r *CronResource) Trigger(ctx context.Context, id string, opts ...RequestOption) (*CronTriggerResult, error) {
+	var resp struct {
+		Execution CronTriggerResult `json:"execution"`
+	}
+	if err := r.t.do(ctx, "POST", "/api/cron/"+url.PathEscape(id)+"/trigger", nil, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Execution, nil
+}
+
+// GetExecution returns a single execution of a cron job.
+func (r *CronResource) GetExecution(ctx context.Context, id, executionID string, opts ...RequestOption) (*CronTriggerResult, error) {
+	var resp struct {
+		Execution CronTriggerResult `json:"execution"`
+	}
+	if err := r.t.do(ctx, "GET", "/api/cron/"+url.PathEscape(id)+"/executions/"+url.PathEscape(executionID), nil, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Execution, nil
+}
+
+// TriggerAndWait triggers a cron job and polls the execution until it reaches a
+// terminal status. params may be nil. If the timeout elapses first, a *TimeoutError
+// is returned; cancelling ctx stops polling with ctx.Err().
+func (r *CronResource) TriggerAndWait(ctx context.Context, id string, params *TriggerAndWaitParams, opts ...RequestOption) (*CronTriggerResult, error) {
+	timeout, interval := 60*time.Second, time.Second
+	if params != nil {
+		if params.Timeout > 0 {
+			timeout = params.Timeout
+		}
+		if params.PollInterval > 0 {
+			interval = params.PollInterval
+		}
+	}
+
+	result, err := r.Trigger(ctx, id, opts...)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	for !result.IsTerminal() {
+		if result.ExecutionID == "" {
+			return nil, &Error{Message: "hookbase: trigger response did not include an execution ID"}
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-deadline.C:
+			return nil, &TimeoutError{Message: fmt.Sprintf("cron execution %s did not finish within %s", result.ExecutionID, timeout)}
+		case <-time.After(interval):
+		}
+		if result, err = r.GetExecution(ctx, id, result.ExecutionID, opts...); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// Pause deactivates a cron job.
+func (r *CronResource) Pause(ctx context.Context, id string, opts ...RequestOption) (*CronJob, error) {
+	return r.Update(ctx, id, &UpdateCronParams{IsActive: Ptr(false)}, opts...)
+}
+
+// Resume reactivates a paused cron job.
+func (r *CronResource) Resume(ctx context.Context, id string, opts ...RequestOption) (*CronJob, error) {
+	return r.Update(ctx, id, &UpdateCronParams{IsActive: Ptr(true)}, opts...)
+}
+
+// BulkUpdate sets the active state of multiple cron jobs. Every job is attempted, even
+// after a failure. Updated counts the jobs that were changed and Success reports
+// whether all were; if any failed, the result is returned along with a *BatchError
+// whose FailedIDs lists them.
+func (r *CronResource) BulkUpdate(ctx context.Context, ids []string, isActive bool, opts ...RequestOption) (*BulkUpdateResult, error) {
+	errs, failed := r.setActive(ctx, ids, isActive, opts...)
+	result := &BulkUpdateResult{Success: failed == 0, Updated: len(ids) - failed}
+	if failed > 0 {
+		return result, newIDBatchError(ids, errs, failed)
+	}
+	return result, nil
+}
+
+// setActive updates the active state of each job in ids and returns one error per ID,
+// nil for jobs that were updated. Once ctx is done, its error is recorded for the
+// remaining jobs without sending requests.
+func (r *CronResource) setActive(ctx context.Context, ids []string, isActive bool, opts ...RequestOption) ([]error, int) {
+	errs := make([]error, len(ids))
+	failed := 0
+	for i, id := range ids {
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			failed++
+			continue
+		}
+		if _, err := r.Update(ctx, id, &UpdateCronParams{IsActive: Ptr(isActive)}, opts...); err != nil {
+			errs[i] = err
+			failed++
+		}
+	}
+	return errs, failed
+}
+
+// PauseAll deactivates every active cron job. The returned snapshot lists the jobs
+// that were paused; pass it to ResumeAll to restore them without enabling jobs that
+// were already inactive. On partial failure the snapshot contains only the jobs that
+// were actually paused, alongside a *BatchError.
+func (r *CronResource) PauseAll(ctx context.Context, opts ...RequestOption) (*CronPauseSnapshot, error) {
+	jobs, err := r.List(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var active []string
+	for _, job := range jobs {
+		if job.IsActive {
+			active = append(active, job.ID)
+		}
+	}
+	snapshot := &CronPauseSnapshot{PausedIDs: []string{}}
+	errs, failed := r.setActive(ctx, active, false, opts...)
+	for i, err := range errs {
+		if err == nil {
+			snapshot.PausedIDs = append(snapshot.PausedIDs, active[i])
+		}
+	}
+	snapshot.PausedAt = time.Now().UTC().Format(time.RFC3339)
+	if failed > 0 {
+		return snapshot, newIDBatchError(active, errs, failed)
+	}
+	return snapshot, nil
+}
+
+// ResumeAll reactivates the jobs recorded in a snapshot returned by PauseAll.
+func (r *CronResource) ResumeAll(ctx context.Context, snapshot *CronPauseSnapshot, opts ...RequestOption) error {
+	if snapshot == nil {
+		return &Error{Message: "hookbase: pause snapshot is required"}
+	}
+	_, err := r.BulkUpdate(ctx, snapshot.PausedIDs, true, opts...)
+	return err
 }
 
 // ListGroups returns all cron groups.
@@ -148,3 +339,50 @@ func (r *CronResource) CreateGroup(ctx context.Context, params *CreateCronGroupP
 	}
 	return &resp.Group, nil
 }
+
+// UpdateGroup updates a cron group.
+func (r *CronResource) UpdateGroup(ctx context.Context, id string, params *UpdateCronGroupParams, opts ...RequestOption) (*CronGroup, error) {
+	var resp struct {
+		Group CronGroup `json:"group"`
+	}
+	if err := r.t.do(ctx, "PATCH", "/api/cron-groups/"+url.PathEscape(id), nil, params, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Group, nil
+}
+
+// DeleteGroup deletes a cron group. If the group still contains jobs, reassignTo must
+// name the group to move them to; otherwise the API rejects the request with a
+// *ConflictError.
+func (r *CronResource) DeleteGroup(ctx context.Context, id string, reassignTo *string, opts ...RequestOption) error {
+	var q url.Values
+	if reassignTo != nil {
+		q = url.Values{"reassignTo": {*reassignTo}}
+	}
+	return r.t.do(ctx, "DELETE", "/api/cron-groups/"+url.PathEscape(id), q, nil, nil, opts...)
+}
+
+// ReorderGroups sets the display order of cron groups. orderedIDs lists every group ID
+// in the desired order.
+func (r *CronResource) ReorderGroups(ctx context.Context, orderedIDs []string, opts ...RequestOption) ([]CronGroup, error) {
+	var resp struct {
+		Groups []CronGroup `json:"groups"`
+	}
+	body := map[string]interface{}{"groupIds": orderedIDs}
+	if err := r.t.do(ctx, "POST", "/api/cron-groups/reorder", nil, body, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return resp.Groups, nil
+}
+
+// ListByGroup returns the cron jobs assigned to a group.
+func (r *CronResource) ListByGroup(ctx context.Context, groupID string, opts ...RequestOption) ([]CronJob, error) {
+	var resp struct {
+		CronJobs []CronJob `json:"cronJobs"`
+	}
+	q := url.Values{"groupId": {groupID}}
+	if err := r.t.do(ctx, "GET", "/api/cron", q, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return resp.CronJobs, nil
+}
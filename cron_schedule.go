@@ -0,0 +1,247 @@
+package hookbase
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var cronAliases = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var cronMonthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var cronDayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var cronFields = [5]cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: cronMonthNames},
+	{name: "day of week", min: 0, max: 7, names: cronDayNames},
+}
+
+// cronSchedule is a parsed 5-field cron expression. Each field is a bitset of allowed values.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domRestricted, dowRestricted  bool
+}
+
+// ValidateCronExpression checks that expr is a standard 5-field cron expression
+// (minute, hour, day of month, month, day of week) or one of the aliases @yearly,
+// @annually, @monthly, @weekly, @daily, @midnight, and @hourly. Fields support
+// "*", lists, ranges, steps, and month/day names. It returns a *ValidationError
+// describing the first problem found.
+func ValidateCronExpression(expr string) error {
+	_, err := parseCronExpression(expr)
+	return err
+}
+
+// NextCronRuns returns the next n times after from at which expr fires in the given
+// IANA timezone (UTC if empty).
+//
+// Daylight saving transitions are handled as follows: a run scheduled at a wall-clock
+// time that does not exist (for example 02:30 on a spring-forward day) is shifted
+// forward by the length of the gap, and a run at a wall-clock time that occurs twice
+// (on a fall-back day) fires once, at the first occurrence.
+func NextCronRuns(expr, timezone string, n int, from time.Time) ([]time.Time, error) {
+	sched, err := parseCronExpression(expr)
+	if err != nil {
+		return nil, err
+	}
+	loc := time.UTC
+	if timezone != "" {
+		loc, err = time.LoadLocation(timezone)
+		if err != nil {
+			return nil, newClientValidationError("timezone", fmt.Sprintf("unknown timezone %q", timezone))
+		}
+	}
+
+	var runs []time.Time
+	start := from.In(loc)
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+	// A day-of-month and day-of-week combination such as Feb 29 on a Monday can
+	// take decades to recur, so bound the search generously.
+	for i := 0; len(runs) < n && i < 366*30; i++ {
+		y, m, d := day.Date()
+		for _, t := range sched.runsOn(y, m, d, loc) {
+			if len(runs) == n {
+				break
+			}
+			if !t.After(from) || (len(runs) > 0 && !t.After(runs[len(runs)-1])) {
+				continue
+			}
+			runs = append(runs, t)
+		}
+		day = time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+	}
+	return runs, nil
+}
+
+// runsOn returns the sorted run times for a single calendar day.
+func (s *cronSchedule) runsOn(y int, m time.Month, d int, loc *time.Location) []time.Time {
+	if s.month&(1<<uint(m)) == 0 {
+		return nil
+	}
+	wd := int(time.Date(y, m, d, 12, 0, 0, 0, loc).Weekday())
+	domMatch := s.dom&(1<<uint(d)) != 0
+	dowMatch := s.dow&(1<<uint(wd)) != 0
+	var dayMatch bool
+	switch {
+	case s.domRestricted && s.dowRestricted:
+		dayMatch = domMatch || dowMatch
+	case s.domRestricted:
+		dayMatch = domMatch
+	case s.dowRestricted:
+		dayMatch = dowMatch
+	default:
+		dayMatch = true
+	}
+	if !dayMatch {
+		return nil
+	}
+
+	var times []time.Time
+	for h := 0; h < 24; h++ {
+		if s.hour&(1<<uint(h)) == 0 {
+			continue
+		}
+		for min := 0; min < 60; min++ {
+			if s.minute&(1<<uint(min)) == 0 {
+				continue
+			}
+			t := time.Date(y, m, d, h, min, 0, 0, loc)
+			if t.Hour() != h || t.Minute() != min {
+				// The wall-clock time falls in a gap; interpret it with the offset in
+				// effect before the transition so it lands after the gap.
+				_, offset := t.Add(-3 * time.Hour).Zone()
+				t = time.Date(y, m, d, h, min, 0, 0, time.UTC).Add(-time.Duration(offset) * time.Second).In(loc)
+			} else if earlier := t.Add(-time.Hour); earlier.Hour() == h && earlier.Minute() == min && earlier.Day() == d {
+				t = earlier
+			}
+			times = append(times, t)
+		}
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	return times
+}
+
+func parseCronExpression(expr string) (*cronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "@") {
+		alias, ok := cronAliases[strings.ToLower(expr)]
+		if !ok {
+			return nil, newClientValidationError("cronExpression", fmt.Sprintf("unknown alias %q", expr))
+		}
+		expr = alias
+	}
+	parts := strings.Fields(expr)
+	if len(parts) != 5 {
+		return nil, newClientValidationError("cronExpression", fmt.Sprintf("expected 5 fields, got %d", len(parts)))
+	}
+
+	var bits [5]uint64
+	for i, part := range parts {
+		b, err := parseCronField(part, cronFields[i])
+		if err != nil {
+			return nil, newClientValidationError("cronExpression", err.Error())
+		}
+		bits[i] = b
+	}
+	// 7 is an alias for Sunday.
+	if bits[4]&(1<<7) != 0 {
+		bits[4] = bits[4]&^(1<<7) | 1
+	}
+	return &cronSchedule{
+		minute:        bits[0],
+		hour:          bits[1],
+		dom:           bits[2],
+		month:         bits[3],
+		dow:           bits[4],
+		domRestricted: parts[2] != "*" && parts[2] != "?",
+		dowRestricted: parts[4] != "*" && parts[4] != "?",
+	}, nil
+}
+
+func parseCronField(field string, f cronField) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(field, ",") {
+		if item == "" {
+			return 0, fmt.Errorf("empty value in %s field %q", f.name, field)
+		}
+		rangePart, step := item, 1
+		if idx := strings.Index(item, "/"); idx >= 0 {
+			rangePart = item[:idx]
+			s, err := strconv.Atoi(item[idx+1:])
+			if err != nil || s <= 0 {
+				return 0, fmt.Errorf("invalid step in %s field %q", f.name, item)
+			}
+			step = s
+		}
+
+		lo, hi := f.min, f.max
+		switch {
+		case rangePart == "*" || rangePart == "?":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = parseCronValue(bounds[0], f); err != nil {
+				return 0, err
+			}
+			if hi, err = parseCronValue(bounds[1], f); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid range in %s field %q", f.name, item)
+			}
+		default:
+			v, err := parseCronValue(rangePart, f)
+			if err != nil {
+				return 0, err
+			}
+			lo = v
+			if step == 1 {
+				hi = v
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(s string, f cronField) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q in %s field", s, f.name)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("%s value %d out of range %d-%d", f.name, v, f.min, f.max)
+	}
+	return v, nil
+}
@@ -0,0 +1,119 @@
+package hookbase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestValidateCronExpression(t *testing.T) {
+	valid := []string{"* * * * *", "*/15 9-17 * * mon-fri", "0 0 1,15 * *", "30 2 * jan,jul sun", "0 0 * * 7", "@daily", "@hourly", "@weekly"}
+	for _, expr := range valid {
+		if err := ValidateCronExpression(expr); err != nil {
+			t.Errorf("%q: unexpected error: %v", expr, err)
+		}
+	}
+
+	invalid := []string{"* * * *", "* * * * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "5-1 * * * *", "*/0 * * * *", "@every", "a * * * *", ""}
+	for _, expr := range invalid {
+		err := ValidateCronExpression(expr)
+		var verr *ValidationError
+		if !errors.As(err, &verr) {
+			t.Errorf("%q: expected ValidationError, got %v", expr, err)
+		}
+	}
+}
+
+func TestNextCronRuns(t *testing.T) {
+	from := time.Date(2024, 1, 1, 10, 7, 0, 0, time.UTC) // Monday
+	runs, err := NextCronRuns("*/15 * * * *", "", 3, from)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"2024-01-01T10:15:00Z", "2024-01-01T10:30:00Z", "2024-01-01T10:45:00Z"}
+	for i, w := range want {
+		if runs[i].Format(time.RFC3339) != w {
+			t.Errorf("run %d: got %s, want %s", i, runs[i].Format(time.RFC3339), w)
+		}
+	}
+
+	// Day of month and day of week are ORed when both are restricted.
+	runs, err = NextCronRuns("0 9 13 * fri", "UTC", 3, from)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = []string{"2024-01-05T09:00:00Z", "2024-01-12T09:00:00Z", "2024-01-13T09:00:00Z"}
+	for i, w := range want {
+		if runs[i].Format(time.RFC3339) != w {
+			t.Errorf("run %d: got %s, want %s", i, runs[i].Format(time.RFC3339), w)
+		}
+	}
+
+	if _, err := NextCronRuns("@daily", "Mars/Olympus", 1, from); err == nil {
+		t.Error("expected error for unknown timezone")
+	}
+}
+
+func TestNextCronRunsDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+
+	// 2024-03-10 is spring-forward: 02:00 EST jumps to 03:00 EDT.
+	from := time.Date(2024, 3, 9, 12, 0, 0, 0, loc)
+	runs, err := NextCronRuns("30 2 * * *", "America/New_York", 3, from)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 02:30 does not exist on the 10th and shifts forward by the one-hour gap.
+	want := []string{"2024-03-10T03:30:00-04:00", "2024-03-11T02:30:00-04:00", "2024-03-12T02:30:00-04:00"}
+	for i, w := range want {
+		if got := runs[i].Format(time.RFC3339); got != w {
+			t.Errorf("spring forward run %d: got %s, want %s", i, got, w)
+		}
+	}
+
+	// 2024-11-03 is fall-back: 01:30 occurs twice and must fire only once.
+	from = time.Date(2024, 11, 2, 12, 0, 0, 0, loc)
+	runs, err = NextCronRuns("30 1 * * *", "America/New_York", 2, from)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = []string{"2024-11-03T01:30:00-04:00", "2024-11-04T01:30:00-05:00"}
+	for i, w := range want {
+		if got := runs[i].Format(time.RFC3339); got != w {
+			t.Errorf("fall back run %d: got %s, want %s", i, got, w)
+		}
+	}
+}
+
+func TestCronCreateValidatesExpression(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"cronJob":{"id":"cron_1"}}`))
+	}))
+	defer server.Close()
+
+	params := &CreateCronParams{Name: "Bad", Schedule: "* * * *", URL: "https://example.com"}
+
+	client := New("test_key", WithBaseURL(server.URL))
+	if _, err := client.Cron.Create(context.Background(), params); err == nil {
+		t.Fatal("expected validation error")
+	}
+	if requests != 0 {
+		t.Errorf("expected no request, got %d", requests)
+	}
+
+	client = New("test_key", WithBaseURL(server.URL), WithClientValidation(false))
+	if _, err := client.Cron.Create(context.Background(), params); err != nil {
+		t.Fatalf("unexpected error with validation disabled: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+}
@@ -2,39 +2,116 @@ package hookbase
 
 import (
 	"context"
+	"encoding/json"
+	"io"
 	"net/url"
+	"time"
 )
 
+// MaxDLQBulkIDs is the maximum number of message IDs accepted by RetryBulk and DeleteBulk.
+const MaxDLQBulkIDs = 100
+
+// DLQReason is why a message was moved to the dead letter queue.
+type DLQReason string
+
+const (
+	ReasonMaxRetriesExceeded DLQReason = "max_retries_exceeded"
+	ReasonEndpointDisabled   DLQReason = "endpoint_disabled"
+	ReasonEndpointDeleted    DLQReason = "endpoint_deleted"
+	ReasonCircuitOpen        DLQReason = "circuit_open"
+	ReasonPayloadTooLarge    DLQReason = "payload_too_large"
+	ReasonTransformFailed    DLQReason = "transform_failed"
+	ReasonManual             DLQReason = "manual"
+	// ReasonOther collects reasons this version of the SDK does not know about in
+	// DLQStats.ByReasonTyped.
+	ReasonOther DLQReason = "other"
+)
+
+// knownDLQReasons are the reasons reported as themselves by DLQStats.ByReasonTyped.
+var knownDLQReasons = map[DLQReason]bool{
+	ReasonMaxRetriesExceeded: true,
+	ReasonEndpointDisabled:   true,
+	ReasonEndpointDeleted:    true,
+	ReasonCircuitOpen:        true,
+	ReasonPayloadTooLarge:    true,
+	ReasonTransformFailed:    true,
+	ReasonManual:             true,
+}
+
 // DLQMessage represents a dead letter queue message.
 type DLQMessage struct {
-	ID                 string  `json:"id"`
-	MessageID          string  `json:"messageId"`
-	EndpointID         string  `json:"endpointId"`
-	EndpointURL        *string `json:"endpointUrl,omitempty"`
-	ApplicationID      string  `json:"applicationId"`
-	ApplicationName    *string `json:"applicationName,omitempty"`
-	EventType          string  `json:"eventType"`
-	Status             string  `json:"status"`
-	DLQReason          *string `json:"dlqReason"`
-	DLQMovedAt         *string `json:"dlqMovedAt"`
-	Attempts           int     `json:"attempts"`
-	MaxAttempts        int     `json:"maxAttempts"`
-	LastAttemptAt      *string `json:"lastAttemptAt"`
-	LastResponseStatus *int    `json:"lastResponseStatus"`
-	LastError          *string `json:"lastError"`
-	CreatedAt          string  `json:"createdAt"`
-	UpdatedAt          string  `json:"updatedAt"`
+	ID                 string     `json:"id"`
+	MessageID          string     `json:"messageId"`
+	EndpointID         string     `json:"endpointId"`
+	EndpointURL        *string    `json:"endpointUrl,omitempty"`
+	ApplicationID      string     `json:"applicationId"`
+	ApplicationName    *string    `json:"applicationName,omitempty"`
+	EventType          string     `json:"eventType"`
+	Status             string     `json:"status"`
+	DLQReason          *DLQReason `json:"dlqReason"`
+	DLQMovedAt         *string    `json:"dlqMovedAt"`
+	Attempts           int        `json:"attempts"`
+	MaxAttempts        int        `json:"maxAttempts"`
+	LastAttemptAt      *string    `json:"lastAttemptAt"`
+	LastResponseStatus *int       `json:"lastResponseStatus"`
+	LastError          *string    `json:"lastError"`
+	CreatedAt          string     `json:"createdAt"`
+	UpdatedAt          string     `json:"updatedAt"`
+}
+
+// DLQAttempt is a single delivery attempt of a DLQ message. ResponseBody is an excerpt
+// of the endpoint's response, truncated by the server.
+type DLQAttempt struct {
+	AttemptNumber  int     `json:"attemptNumber"`
+	ResponseStatus *int    `json:"responseStatus"`
+	ResponseBody   *string `json:"responseBody"`
+	Error          *string `json:"error"`
+	DurationMs     *int    `json:"durationMs"`
+	AttemptedAt    string  `json:"attemptedAt"`
+}
+
+// DLQMessageDetail is a DLQ message with its original payload, the headers that would
+// have been sent, and its attempt history. Attempts shadows the embedded attempt
+// count, which remains available as DLQMessage.Attempts.
+type DLQMessageDetail struct {
+	DLQMessage
+	// Payload is the message payload exactly as it would have been delivered.
+	Payload        json.RawMessage   `json:"payload"`
+	RequestHeaders map[string]string `json:"requestHeaders"`
+	Attempts       []DLQAttempt      `json:"attemptHistory"`
+	// DLQReasonDetail explains DLQReason, such as the last error or the retry policy
+	// that was exhausted.
+	DLQReasonDetail *string `json:"dlqReasonDetail"`
+}
+
+// DLQEndpointCount is the number of DLQ messages for one endpoint.
+type DLQEndpointCount struct {
+	EndpointID  string `json:"endpointId"`
+	EndpointURL string `json:"endpointUrl"`
+	Count       int    `json:"count"`
 }
 
 // DLQStats contains DLQ statistics.
 type DLQStats struct {
-	Total             int                `json:"total"`
-	ByReason          map[string]int     `json:"byReason"`
-	TopFailingEndpoints []struct {
-		EndpointID  string `json:"endpointId"`
-		EndpointURL string `json:"endpointUrl"`
-		Count       int    `json:"count"`
-	} `json:"topFailingEndpoints"`
+	Total int `json:"total"`
+	// ByReason counts messages by the reason string reported by the server. Use
+	// ByReasonTyped to key by DLQReason.
+	ByReason            map[string]int     `json:"byReason"`
+	TopFailingEndpoints []DLQEndpointCount `json:"topFailingEndpoints"`
+}
+
+// ByReasonTyped returns ByReason keyed by DLQReason. Counts for reasons the SDK does
+// not know are added together under ReasonOther rather than dropped.
+func (s *DLQStats) ByReasonTyped() map[DLQReason]int {
+	out := make(map[DLQReason]int, len(s.ByReason))
+	for reason, n := range s.ByReason {
+		r := DLQReason(reason)
+		if !knownDLQReasons[r] {
+			r = ReasonOther
+		}
+		out[r] += n
+	}
+	return out
 }
 
 // DLQRetryResult is the result of retrying a DLQ message.
@@ -63,14 +140,38 @@ type DLQBulkDeleteResult struct {
 	Deleted int `json:"deleted"`
 }
 
+// DLQBatchResult summarizes a client-side bulk operation over DLQ messages.
+type DLQBatchResult struct {
+	// Processed is the number of messages sent to the bulk endpoint.
+	Processed int
+	// Succeeded is the number of messages retried or deleted.
+	Succeeded int
+	Failed    int
+	// LastCursor is the cursor of the first page not yet fully processed. When the
+	// operation returns an error, set it as the params Cursor to resume the run. It is
+	// nil once every page has been processed.
+	LastCursor *string
+}
+
 // ListDLQParams are the parameters for listing DLQ messages.
 type ListDLQParams struct {
-	Limit         *int    `json:"limit,omitempty"`
-	Cursor        *string `json:"cursor,omitempty"`
-	EndpointID    *string `json:"endpointId,omitempty"`
-	ApplicationID *string `json:"applicationId,omitempty"`
-	DLQReason     *string `json:"dlqReason,omitempty"`
-	EventType     *string `json:"eventType,omitempty"`
+	Limit         *int       `json:"limit,omitempty"`
+	Cursor        *string    `json:"cursor,omitempty"`
+	EndpointID    *string    `json:"endpointId,omitempty"`
+	ApplicationID *string    `json:"applicationId,omitempty"`
+	DLQReason     *DLQReason `json:"dlqReason,omitempty"`
+	EventType     *string    `json:"eventType,omitempty"`
+	// DLQMovedAfter and DLQMovedBefore restrict results to messages moved to the DLQ
+	// within a date range (RFC 3339 or YYYY-MM-DD).
+	DLQMovedAfter  *string `json:"dlqMovedAfter,omitempty"`
+	DLQMovedBefore *string `json:"dlqMovedBefore,omitempty"`
+	// StartDate and EndDate are aliases for DLQMovedAfter and DLQMovedBefore, matching
+	// the naming used by other list params. The DLQMoved fields take precedence.
+	StartDate *string `json:"startDate,omitempty"`
+	EndDate   *string `json:"endDate,omitempty"`
+	// Force confirms that DeleteAll may purge the entire DLQ when no other filter is set.
+	// It is ignored by List.
+	Force *bool `json:"force,omitempty"`
 }
 
 func (p *ListDLQParams) toQuery() url.Values {
@@ -91,14 +192,61 @@ func (p *ListDLQParams) toQuery() url.Values {
 		q.Set("applicationId", *p.ApplicationID)
 	}
 	if p.DLQReason != nil {
-		q.Set("dlqReason", *p.DLQReason)
+		q.Set("dlqReason", string(*p.DLQReason))
 	}
 	if p.EventType != nil {
 		q.Set("eventType", *p.EventType)
 	}
+	if after := p.movedAfter(); after != nil {
+		q.Set("dlqMovedAfter", *after)
+	}
+	if before := p.movedBefore(); before != nil {
+		q.Set("dlqMovedBefore", *before)
+	}
 	return q
 }
 
+func (p *ListDLQParams) movedAfter() *string {
+	if p.DLQMovedAfter != nil {
+		return p.DLQMovedAfter
+	}
+	return p.StartDate
+}
+
+func (p *ListDLQParams) movedBefore() *string {
+	if p.DLQMovedBefore != nil {
+		return p.DLQMovedBefore
+	}
+	return p.EndDate
+}
+
+// filterBody returns the filter fields of p as a request body, omitting pagination.
+func (p *ListDLQParams) filterBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if p == nil {
+		return body
+	}
+	if p.EndpointID != nil {
+		body["endpointId"] = *p.EndpointID
+	}
+	if p.ApplicationID != nil {
+		body["applicationId"] = *p.ApplicationID
+	}
+	if p.DLQReason != nil {
+		body["dlqReason"] = string(*p.DLQReason)
+	}
+	if p.EventType != nil {
+		body["eventType"] = *p.EventType
+	}
+	if after := p.movedAfter(); after != nil {
+		body["dlqMovedAfter"] = *after
+	}
+	if before := p.movedBefore(); before != nil {
+		body["dlqMovedBefore"] = *before
+	}
+	return body
+}
+
 // DLQResource provides access to dead letter queue API endpoints.
 type DLQResource struct {
 	t *transport
@@ -127,6 +275,17 @@ func (r *DLQResource) List(ctx context.Context, params *ListDLQParams, opts ...R
 	}, nil
 }
 
+// Get returns a DLQ message with its full payload and attempt history.
+func (r *DLQResource) Get(ctx context.Context, id string, opts ...RequestOption) (*DLQMessageDetail, error) {
+	var resp struct {
+		Data DLQMessageDetail `json:"data"`
+	}
+	if err := r.t.do(ctx, "GET", "/api/outbound-messages/dlq/"+url.PathEscape(id), nil, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
 // GetStats returns DLQ statistics.
 func (r *DLQResource) GetStats(ctx context.Context, opts ...RequestOption) (*DLQStats, error) {
 	var resp struct {
@@ -149,7 +308,7 @@ func (r *DLQResource) Retry(ctx context.Context, id string, opts ...RequestOptio
 	return &resp.Data, nil
 }
 
-// RetryBulk retries multiple DLQ messages (up to 100).
+// RetryBulk retries multiple DLQ messages (up to MaxDLQBulkIDs).
 func (r *DLQResource) RetryBulk(ctx context.Context, messageIDs []string, opts ...RequestOption) (*DLQBulkRetryResult, error) {
 	var resp struct {
 		Data DLQBulkRetryResult `json:"data"`
@@ -161,12 +320,121 @@ func (r *DLQResource) RetryBulk(ctx context.Context, messageIDs []string, opts .
 	return &resp.Data, nil
 }
 
+// RetryAll retries every DLQ message matching filter, or all DLQ messages if filter is
+// nil. The server pages through matching messages itself and returns aggregate counts;
+// filter's Limit and Cursor are ignored.
+func (r *DLQResource) RetryAll(ctx context.Context, filter *ListDLQParams, opts ...RequestOption) (*DLQBulkRetryResult, error) {
+	var resp struct {
+		Data DLQBulkRetryResult `json:"data"`
+	}
+	if err := r.t.do(ctx, "POST", "/api/outbound-messages/dlq/retry-all", nil, filter.filterBody(), &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// RetryByFilter pages through DLQ messages matching params and retries each page with
+// RetryBulk. Unlike RetryAll, it works against any number of messages without one long
+// server-side call. Pages hold at most MaxDLQBulkIDs messages; a larger params Limit
+// is lowered to it. progress, which may be nil, is called after each page with done
+// messages processed out of total matching messages listed so far. The API does not
+// report how many messages match, so total grows with each page and is final only
+// when the last page has been processed. Since retried messages leave the DLQ, each
+// page is listed again from the same cursor until it holds only messages already
+// processed, so offset cursors do not skip messages. On error the partial result is
+// returned along with the error; its LastCursor resumes the run.
+func (r *DLQResource) RetryByFilter(ctx context.Context, params *ListDLQParams, progress func(done, total int), opts ...RequestOption) (*DLQBatchResult, error) {
+	return r.batchByFilter(ctx, params, progress, func(ids []string) (int, int, error) {
+		res, err := r.RetryBulk(ctx, ids, opts...)
+		if err != nil {
+			return 0, 0, err
+		}
+		return res.Retried, res.Failed, nil
+	}, opts...)
+}
+
+// PurgeByFilter pages through DLQ messages matching params and deletes each page with
+// DeleteBulk. Pages hold at most MaxDLQBulkIDs messages and, as with RetryByFilter,
+// are listed again from the same cursor until they hold only messages already
+// processed. On error the partial result is returned along with the error; its
+// LastCursor resumes the run.
+func (r *DLQResource) PurgeByFilter(ctx context.Context, params *ListDLQParams, opts ...RequestOption) (*DLQBatchResult, error) {
+	return r.batchByFilter(ctx, params, nil, func(ids []string) (int, int, error) {
+		res, err := r.DeleteBulk(ctx, ids, opts...)
+		if err != nil {
+			return 0, 0, err
+		}
+		return res.Deleted, len(ids) - res.Deleted, nil
+	}, opts...)
+}
+
+// PurgeOlderThan deletes every DLQ message moved to the DLQ before cutoff. See
+// PurgeByFilter.
+func (r *DLQResource) PurgeOlderThan(ctx context.Context, cutoff time.Time, opts ...RequestOption) (*DLQBatchResult, error) {
+	return r.PurgeByFilter(ctx, &ListDLQParams{DLQMovedBefore: Ptr(cutoff.UTC().Format(time.RFC3339))}, opts...)
+}
+
+// batchByFilter lists DLQ messages matching params page by page and passes the IDs of
+// each page to fn. Pages are limited to MaxDLQBulkIDs so that each is a single bulk
+// call, which keeps LastCursor exact: a page is either fully processed or not at all.
+//
+// Processed messages leave the DLQ, so following NextCursor after a bulk call would
+// skip messages whenever the cursor is an offset. Instead the same cursor is listed
+// again until it returns only messages already processed, such as failed retries that
+// stay in the DLQ, and only then does the cursor advance past them. This is correct
+// for offset and keyset cursors alike.
+func (r *DLQResource) batchByFilter(ctx context.Context, params *ListDLQParams, progress func(done, total int), fn func(ids []string) (succeeded, failed int, err error), opts ...RequestOption) (*DLQBatchResult, error) {
+	p := ListDLQParams{}
+	if params != nil {
+		p = *params
+	}
+	if p.Limit == nil || *p.Limit <= 0 || *p.Limit > MaxDLQBulkIDs {
+		p.Limit = Ptr(MaxDLQBulkIDs)
+	}
+	result := &DLQBatchResult{LastCursor: p.Cursor}
+	processed := make(map[string]bool)
+	for {
+		page, err := r.List(ctx, &p, opts...)
+		if err != nil {
+			return result, err
+		}
+		ids := make([]string, 0, len(page.Data))
+		for _, m := range page.Data {
+			if !processed[m.ID] {
+				ids = append(ids, m.ID)
+			}
+		}
+		if len(ids) > 0 {
+			succeeded, failed, err := fn(ids)
+			if err != nil {
+				return result, err
+			}
+			for _, id := range ids {
+				processed[id] = true
+			}
+			result.Processed += len(ids)
+			result.Succeeded += succeeded
+			result.Failed += failed
+			if progress != nil {
+				progress(result.Processed, len(processed))
+			}
+			continue
+		}
+		if !page.HasMore || page.NextCursor == nil || len(page.Data) == 0 {
+			result.LastCursor = nil
+			return result, nil
+		}
+		p.Cursor = page.NextCursor
+		result.LastCursor = page.NextCursor
+	}
+}
+
 // Delete deletes a single DLQ message.
 func (r *DLQResource) Delete(ctx context.Context, id string, opts ...RequestOption) error {
 	return r.t.do(ctx, "DELETE", "/api/outbound-messages/dlq/"+url.PathEscape(id), nil, nil, nil, opts...)
 }
 
-// DeleteBulk deletes multiple DLQ messages (up to 100).
+// DeleteBulk deletes multiple DLQ messages (up to MaxDLQBulkIDs).
 func (r *DLQResource) DeleteBulk(ctx context.Context, messageIDs []string, opts ...RequestOption) (*DLQBulkDeleteResult, error) {
 	var resp struct {
 		Data DLQBulkDeleteResult `json:"data"`
@@ -177,3 +445,40 @@ func (r *DLQResource) DeleteBulk(ctx context.Context, messageIDs []string, opts
 	}
 	return &resp.Data, nil
 }
+
+// DeleteAll deletes every DLQ message matching filter. To purge the entire DLQ, pass a
+// filter with no fields set except Force; without a filter field or Force, a
+// *ValidationError is returned and nothing is deleted.
+func (r *DLQResource) DeleteAll(ctx context.Context, filter *ListDLQParams, opts ...RequestOption) (*DLQBulkDeleteResult, error) {
+	body := filter.filterBody()
+	if len(body) == 0 {
+		if filter == nil || filter.Force == nil || !*filter.Force {
+			return nil, newClientValidationError("force", "must be true to delete all DLQ messages without a filter")
+		}
+		body["force"] = true
+	}
+	var resp struct {
+		Data DLQBulkDeleteResult `json:"data"`
+	}
+	if err := r.t.do(ctx, "DELETE", "/api/outbound-messages/dlq/bulk-all", nil, body, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Export streams DLQ messages matching params to w as CSV or JSON, depending on format
+// ("csv" or "json"), without holding the export in memory. params' Limit and Cursor
+// are ignored. Response interceptors are not run on the export body.
+func (r *DLQResource) Export(ctx context.Context, params *ListDLQParams, w io.Writer, format string, opts ...RequestOption) error {
+	if format != "csv" && format != "json" {
+		return newClientValidationError("format", `must be "csv" or "json"`)
+	}
+	q := params.toQuery()
+	if q == nil {
+		q = url.Values{}
+	}
+	q.Del("limit")
+	q.Del("cursor")
+	q.Set("format", format)
+	return r.t.doStream(ctx, "GET", "/api/outbound-messages/dlq/export", q, w, opts...)
+}
@@ -0,0 +1,122 @@
+package hookbase
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// EndpointHeaders is an ordered list of custom headers sent with every delivery to an
+// endpoint. It is used both when reading endpoints and in Create/Update params, so
+// headers read from an endpoint can be sent back unchanged.
+//
+// It marshals as a JSON object in list order when every name is unique, and as an
+// array of {"name", "value"} objects when a name repeats, so duplicates are never
+// silently collapsed. It unmarshals from either form.
+type EndpointHeaders []EndpointHeader
+
+// HeadersFromMap converts a map to EndpointHeaders, sorted by name.
+func HeadersFromMap(m map[string]string) EndpointHeaders {
+	if m == nil {
+		return nil
+	}
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	headers := make(EndpointHeaders, len(names))
+	for i, name := range names {
+		headers[i] = EndpointHeader{Name: name, Value: m[name]}
+	}
+	return headers
+}
+
+// ToMap converts the headers to a map. If a name repeats, the last value wins.
+func (h EndpointHeaders) ToMap() map[string]string {
+	if h == nil {
+		return nil
+	}
+	m := make(map[string]string, len(h))
+	for _, header := range h {
+		m[header.Name] = header.Value
+	}
+	return m
+}
+
+func (h EndpointHeaders) hasDuplicates() bool {
+	seen := make(map[string]bool, len(h))
+	for _, header := range h {
+		if seen[header.Name] {
+			return true
+		}
+		seen[header.Name] = true
+	}
+	return false
+}
+
+func (h EndpointHeaders) MarshalJSON() ([]byte, error) {
+	if h == nil {
+		return []byte("null"), nil
+	}
+	if h.hasDuplicates() {
+		return json.Marshal([]EndpointHeader(h))
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, header := range h {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(header.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(header.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (h *EndpointHeaders) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		*h = nil
+		return nil
+	case len(data) > 0 && data[0] == '[':
+		var list []EndpointHeader
+		if err := json.Unmarshal(data, &list); err != nil {
+			return err
+		}
+		*h = list
+		return nil
+	}
+
+	// Decode objects token by token to keep the order the API returned.
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return fmt.Errorf("hookbase: cannot decode %s as endpoint headers", data)
+	}
+	headers := EndpointHeaders{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var value string
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		headers = append(headers, EndpointHeader{Name: tok.(string), Value: value})
+	}
+	*h = headers
+	return nil
+}
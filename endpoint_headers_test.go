@@ -0,0 +1,91 @@
+package hookbase
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestEndpointHeadersJSON(t *testing.T) {
+	unique := EndpointHeaders{{Name: "X-Z", Value: "1"}, {Name: "X-A", Value: "2"}}
+	data, err := json.Marshal(unique)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != `{"X-Z":"1","X-A":"2"}` {
+		t.Errorf("unexpected object form: %s", data)
+	}
+
+	dup := EndpointHeaders{{Name: "X-Tag", Value: "a"}, {Name: "X-Tag", Value: "b"}}
+	data, err = json.Marshal(dup)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != `[{"name":"X-Tag","value":"a"},{"name":"X-Tag","value":"b"}]` {
+		t.Errorf("unexpected array form: %s", data)
+	}
+
+	var decoded EndpointHeaders
+	if err := json.Unmarshal([]byte(`{"X-Z":"1","X-A":"2"}`), &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, unique) {
+		t.Errorf("object decode lost order: %+v", decoded)
+	}
+	if err := json.Unmarshal([]byte(`[{"name":"X-Tag","value":"a"},{"name":"X-Tag","value":"b"}]`), &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, dup) {
+		t.Errorf("array decode lost duplicates: %+v", decoded)
+	}
+
+	if m := dup.ToMap(); len(m) != 1 || m["X-Tag"] != "b" {
+		t.Errorf("unexpected map: %v", m)
+	}
+	if h := HeadersFromMap(map[string]string{"b": "2", "a": "1"}); !reflect.DeepEqual(h, EndpointHeaders{{Name: "a", Value: "1"}, {Name: "b", Value: "2"}}) {
+		t.Errorf("unexpected headers from map: %+v", h)
+	}
+}
+
+func TestEndpointHeadersRoundTrip(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			w.Write([]byte(`{"data":{"id":"ep_1","headers":[{"name":"X-Tag","value":"a"},{"name":"Authorization","value":"Bearer t"},{"name":"X-Tag","value":"b"}]}}`))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.Write([]byte(`{"data":{"id":"ep_1"}}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	ctx := context.Background()
+	ep, err := client.Endpoints.Get(ctx, "app_1", "ep_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Endpoints.Update(ctx, "app_1", "ep_1", &UpdateEndpointParams{Headers: ep.Headers}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Endpoints.Disable(ctx, "app_1", "ep_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Endpoints.Update(ctx, "app_1", "ep_1", &UpdateEndpointParams{Headers: HeadersFromMap(map[string]string{"X-Env": "prod"})}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{
+		`{"headers":[{"name":"X-Tag","value":"a"},{"name":"Authorization","value":"Bearer t"},{"name":"X-Tag","value":"b"}]}`,
+		`{"isDisabled":true}`,
+		`{"headers":{"X-Env":"prod"}}`,
+	}
+	if !reflect.DeepEqual(bodies, want) {
+		t.Errorf("unexpected bodies:\n got %q\nwant %q", bodies, want)
+	}
+}
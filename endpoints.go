@@ -3,8 +3,21 @@ package hookbase
 import (
 	"context"
 	"net/url"
+	"strings"
+	"time"
 )
 
+// RecoverWindow is how far back Endpoints.Recover can resend messages. Older messages
+// are outside the retention window.
+const RecoverWindow = 30 * 24 * time.Hour
+
+// PausedUntilMetadataKey is the endpoint metadata key where PauseUntil records the
+// resume time (RFC 3339, UTC), for API versions that do not store pausedUntil natively.
+const PausedUntilMetadataKey = "hookbase_paused_until"
+
+// maxFilterTypeAttempts bounds the read-modify-write retries of the filter type helpers.
+const maxFilterTypeAttempts = 5
+
 // EndpointCircuitState represents the circuit breaker state of an endpoint.
 type EndpointCircuitState string
 
@@ -33,8 +46,9 @@ type Endpoint struct {
 	FilterTypes      []string               `json:"filterTypes"`
 	RateLimit        *int                   `json:"rateLimit"`
 	RateLimitPeriod  *int                   `json:"rateLimitPeriod"`
-	Headers          []EndpointHeader       `json:"headers"`
+	Headers          EndpointHeaders        `json:"headers"`
 	Metadata         map[string]interface{} `json:"metadata"`
+	PausedUntil      *string                `json:"pausedUntil,omitempty"`
 	TotalMessages    int                    `json:"totalMessages"`
 	TotalSuccesses   int                    `json:"totalSuccesses"`
 	TotalFailures    int                    `json:"totalFailures"`
@@ -42,14 +56,58 @@ type Endpoint struct {
 	UpdatedAt        string                 `json:"updatedAt"`
 }
 
+// Values of EndpointStats.Source.
+const (
+	// EndpointStatsSourceServer means the stats were returned by the stats API.
+	EndpointStatsSourceServer = "server"
+	// EndpointStatsSourceComputed means the stats API was unavailable and the stats were
+	// derived from the endpoint's lifetime counters. Latency, RecentFailures, and
+	// Timeline are not populated in that case.
+	EndpointStatsSourceComputed = "computed"
+)
+
 // EndpointStats contains statistics for an endpoint.
 type EndpointStats struct {
-	TotalMessages  int     `json:"totalMessages"`
-	TotalSuccesses int     `json:"totalSuccesses"`
-	TotalFailures  int     `json:"totalFailures"`
-	SuccessRate    float64 `json:"successRate"`
+	TotalMessages  int                  `json:"totalMessages"`
+	TotalSuccesses int                  `json:"totalSuccesses"`
+	TotalFailures  int                  `json:"totalFailures"`
+	SuccessRate    float64              `json:"successRate"`
+	AverageLatency float64              `json:"averageLatency"`
+	P50Latency     float64              `json:"p50Latency"`
+	P95Latency     float64              `json:"p95Latency"`
+	P99Latency     float64              `json:"p99Latency"`
+	RecentFailures int                  `json:"recentFailures"`
+	Timeline       []EndpointStatsPoint `json:"timeline"`
+	Source         string               `json:"source"`
+}
+
+// EndpointStatsPoint is one bucket of an endpoint's delivery timeline.
+type EndpointStatsPoint struct {
+	Timestamp      Time    `json:"timestamp"`
+	Total          int     `json:"total"`
+	Successes      int     `json:"successes"`
+	Failures       int     `json:"failures"`
 	AverageLatency float64 `json:"averageLatency"`
-	RecentFailures int     `json:"recentFailures"`
+}
+
+// EndpointStatsParams are the parameters for retrieving endpoint statistics.
+type EndpointStatsParams struct {
+	StartDate *string `json:"startDate,omitempty"`
+	EndDate   *string `json:"endDate,omitempty"`
+}
+
+func (p *EndpointStatsParams) toQuery() url.Values {
+	if p == nil {
+		return nil
+	}
+	q := url.Values{}
+	if p.StartDate != nil {
+		q.Set("startDate", *p.StartDate)
+	}
+	if p.EndDate != nil {
+		q.Set("endDate", *p.EndDate)
+	}
+	return q
 }
 
 // CreateEndpointParams are the parameters for creating an endpoint.
@@ -59,8 +117,11 @@ type CreateEndpointParams struct {
 	FilterTypes     []string               `json:"filterTypes,omitempty"`
 	RateLimit       *int                   `json:"rateLimit,omitempty"`
 	RateLimitPeriod *int                   `json:"rateLimitPeriod,omitempty"`
-	Headers         map[string]string      `json:"headers,omitempty"`
+	Headers         EndpointHeaders        `json:"headers,omitempty"`
 	Metadata        map[string]interface{} `json:"metadata,omitempty"`
+	// IsDisabled creates the endpoint disabled, so it receives no deliveries until
+	// enabled.
+	IsDisabled *bool `json:"isDisabled,omitempty"`
 }
 
 // UpdateEndpointParams are the parameters for updating an endpoint.
@@ -71,10 +132,58 @@ type UpdateEndpointParams struct {
 	FilterTypes     []string               `json:"filterTypes,omitempty"`
 	RateLimit       *int                   `json:"rateLimit,omitempty"`
 	RateLimitPeriod *int                   `json:"rateLimitPeriod,omitempty"`
-	Headers         map[string]string      `json:"headers,omitempty"`
+	Headers         EndpointHeaders        `json:"headers,omitempty"`
 	Metadata        map[string]interface{} `json:"metadata,omitempty"`
 }
 
+// CloneEndpointParams are the parameters for cloning an endpoint.
+type CloneEndpointParams struct {
+	URL         string  `json:"url"`
+	Description *string `json:"description,omitempty"`
+}
+
+// EndpointTestParams are the parameters for sending a test event of a chosen type to an endpoint.
+type EndpointTestParams struct {
+	EventType string                 `json:"eventType"`
+	Payload   map[string]interface{} `json:"payload"`
+}
+
+// TestEndpointParams is the former name of EndpointTestParams.
+//
+// Deprecated: Use EndpointTestParams.
+type TestEndpointParams = EndpointTestParams
+
+// EndpointTestResult is the outcome of delivering a test event to an endpoint.
+type EndpointTestResult struct {
+	Success              bool              `json:"success"`
+	StatusCode           *int              `json:"statusCode"`
+	DurationMs           int               `json:"durationMs"`
+	ResponseBody         *string           `json:"responseBody"`
+	SignatureHeadersSent map[string]string `json:"signatureHeadersSent"`
+	Error                *string           `json:"error"`
+}
+
+// EndpointSecret is a signing secret of an endpoint. During a rotation grace period an
+// endpoint has more than one active secret. Secret is only set when the API returns the
+// full value; Prefix is always set.
+type EndpointSecret struct {
+	ID        string  `json:"id"`
+	Secret    *string `json:"secret"`
+	Prefix    string  `json:"prefix"`
+	CreatedAt string  `json:"createdAt"`
+	ExpiresAt *string `json:"expiresAt"`
+}
+
+// RecoverResult describes an endpoint recover operation. Small recoveries complete
+// synchronously with Status "completed"; larger ones return a TaskID with Status
+// "pending" or "running" that can be polled with GetRecoverStatus.
+type RecoverResult struct {
+	TaskID   *string `json:"taskId"`
+	Status   string  `json:"status"`
+	Requeued int     `json:"requeued"`
+	Since    string  `json:"since"`
+}
+
 // ListEndpointsParams are the parameters for listing endpoints.
 type ListEndpointsParams struct {
 	Limit      *int  `json:"limit,omitempty"`
@@ -166,6 +275,9 @@ func (r *EndpointsResource) Create(ctx context.Context, applicationID string, pa
 	if params.Metadata != nil {
 		body["metadata"] = params.Metadata
 	}
+	if params.IsDisabled != nil {
+		body["isDisabled"] = *params.IsDisabled
+	}
 	var resp struct {
 		Data Endpoint `json:"data"`
 	}
@@ -202,6 +314,40 @@ func (r *EndpointsResource) RotateSecret(ctx context.Context, applicationID, end
 	return resp.Secret, nil
 }
 
+// RotateSecretWithGrace issues a new signing secret for an endpoint and keeps the
+// current one valid for graceSeconds, so consumers can deploy the new secret before the
+// old one stops verifying. Verify with NewWebhookMulti during the grace period.
+func (r *EndpointsResource) RotateSecretWithGrace(ctx context.Context, applicationID, endpointID string, graceSeconds int, opts ...RequestOption) (string, error) {
+	if graceSeconds < 0 {
+		return "", newClientValidationError("gracePeriodSeconds", "must not be negative")
+	}
+	var resp struct {
+		Secret string `json:"secret"`
+	}
+	body := map[string]interface{}{"gracePeriodSeconds": graceSeconds}
+	if err := r.t.do(ctx, "POST", "/api/webhook-endpoints/"+url.PathEscape(endpointID)+"/rotate-secret", nil, body, &resp, opts...); err != nil {
+		return "", err
+	}
+	return resp.Secret, nil
+}
+
+// ListSecrets returns the active signing secrets of an endpoint, newest first.
+func (r *EndpointsResource) ListSecrets(ctx context.Context, applicationID, endpointID string, opts ...RequestOption) ([]EndpointSecret, error) {
+	var resp struct {
+		Data []EndpointSecret `json:"data"`
+	}
+	if err := r.t.do(ctx, "GET", "/api/webhook-endpoints/"+url.PathEscape(endpointID)+"/secrets", nil, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// ExpireSecret immediately invalidates one of an endpoint's signing secrets, ending its
+// grace period early.
+func (r *EndpointsResource) ExpireSecret(ctx context.Context, applicationID, endpointID, secretID string, opts ...RequestOption) error {
+	return r.t.do(ctx, "POST", "/api/webhook-endpoints/"+url.PathEscape(endpointID)+"/secrets/"+url.PathEscape(secretID)+"/expire", nil, nil, nil, opts...)
+}
+
 // Enable enables a disabled endpoint.
 func (r *EndpointsResource) Enable(ctx context.Context, applicationID, endpointID string, opts ...RequestOption) (*Endpoint, error) {
 	return r.Update(ctx, applicationID, endpointID, &UpdateEndpointParams{IsDisabled: Ptr(false)}, opts...)
@@ -212,8 +358,22 @@ func (r *EndpointsResource) Disable(ctx context.Context, applicationID, endpoint
 	return r.Update(ctx, applicationID, endpointID, &UpdateEndpointParams{IsDisabled: Ptr(true)}, opts...)
 }
 
-// GetStats returns statistics for an endpoint.
-func (r *EndpointsResource) GetStats(ctx context.Context, applicationID, endpointID string, opts ...RequestOption) (*EndpointStats, error) {
+// GetStats returns delivery statistics for an endpoint, optionally limited to a date
+// range. If the stats API is not available (404), it falls back to the endpoint's
+// lifetime counters and sets Source to EndpointStatsSourceComputed.
+func (r *EndpointsResource) GetStats(ctx context.Context, applicationID, endpointID string, params *EndpointStatsParams, opts ...RequestOption) (*EndpointStats, error) {
+	var resp struct {
+		Data EndpointStats `json:"data"`
+	}
+	err := r.t.do(ctx, "GET", "/api/webhook-endpoints/"+url.PathEscape(endpointID)+"/stats", params.toQuery(), nil, &resp, opts...)
+	if err == nil {
+		resp.Data.Source = EndpointStatsSourceServer
+		return &resp.Data, nil
+	}
+	if _, ok := err.(*NotFoundError); !ok {
+		return nil, err
+	}
+
 	ep, err := r.Get(ctx, applicationID, endpointID, opts...)
 	if err != nil {
 		return nil, err
@@ -227,6 +387,7 @@ func (r *EndpointsResource) GetStats(ctx context.Context, applicationID, endpoin
 		TotalSuccesses: ep.TotalSuccesses,
 		TotalFailures:  ep.TotalFailures,
 		SuccessRate:    successRate,
+		Source:         EndpointStatsSourceComputed,
 	}, nil
 }
 
@@ -238,11 +399,340 @@ func (r *EndpointsResource) RecoverCircuit(ctx context.Context, applicationID, e
 	return r.Get(ctx, applicationID, endpointID, opts...)
 }
 
-// Test sends a test event to an endpoint.
-func (r *EndpointsResource) Test(ctx context.Context, applicationID, endpointID string, opts ...RequestOption) (interface{}, error) {
-	var resp interface{}
-	if err := r.t.do(ctx, "POST", "/api/webhook-endpoints/"+url.PathEscape(endpointID)+"/test", nil, nil, &resp, opts...); err != nil {
+// Test sends a canned ping event to an endpoint.
+func (r *EndpointsResource) Test(ctx context.Context, applicationID, endpointID string, opts ...RequestOption) (*EndpointTestResult, error) {
+	return r.test(ctx, endpointID, nil, opts...)
+}
+
+// TestWithEvent sends a test event with the given type and payload to an endpoint. The
+// event goes through the full signing and delivery pipeline but no permanent message
+// record is created.
+func (r *EndpointsResource) TestWithEvent(ctx context.Context, applicationID, endpointID string, params *EndpointTestParams, opts ...RequestOption) (*EndpointTestResult, error) {
+	if params == nil || params.EventType == "" {
+		return nil, newClientValidationError("eventType", "is required")
+	}
+	return r.test(ctx, endpointID, params, opts...)
+}
+
+// TestWithPayload sends a custom test event to an endpoint. Unlike TestWithEvent, it
+// does not require EventType; without one, the API uses its default test event type.
+//
+// Deprecated: Use TestWithEvent.
+func (r *EndpointsResource) TestWithPayload(ctx context.Context, applicationID, endpointID string, params *TestEndpointParams, opts ...RequestOption) (*EndpointTestResult, error) {
+	return r.test(ctx, endpointID, params, opts...)
+}
+
+func (r *EndpointsResource) test(ctx context.Context, endpointID string, params *EndpointTestParams, opts ...RequestOption) (*EndpointTestResult, error) {
+	var body interface{}
+	if params != nil {
+		body = params
+	}
+	var resp struct {
+		Data EndpointTestResult `json:"data"`
+	}
+	if err := r.t.do(ctx, "POST", "/api/webhook-endpoints/"+url.PathEscape(endpointID)+"/test", nil, body, &resp, opts...); err != nil {
 		return nil, err
 	}
-	return resp, nil
+	return &resp.Data, nil
+}
+
+// Clone creates a copy of an endpoint within the same application. Filter types, rate
+// limits, headers, and metadata are copied from the original. The clone is created
+// disabled, so it receives no deliveries until enabled.
+func (r *EndpointsResource) Clone(ctx context.Context, applicationID, endpointID string, params *CloneEndpointParams, opts ...RequestOption) (*Endpoint, error) {
+	if params == nil || params.URL == "" {
+		return nil, newClientValidationError("url", "is required")
+	}
+	src, err := r.Get(ctx, applicationID, endpointID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	create := &CreateEndpointParams{
+		URL:             params.URL,
+		Description:     src.Description,
+		FilterTypes:     src.FilterTypes,
+		RateLimit:       src.RateLimit,
+		RateLimitPeriod: src.RateLimitPeriod,
+		Metadata:        src.Metadata,
+		IsDisabled:      Ptr(true),
+	}
+	if params.Description != nil {
+		create.Description = params.Description
+	}
+	if len(src.Headers) > 0 {
+		create.Headers = src.Headers
+	}
+	return r.Create(ctx, applicationID, create, opts...)
+}
+
+// Recover resends every outbound message to an endpoint that failed or was never
+// delivered since the given time. since must not be in the future or older than
+// RecoverWindow.
+func (r *EndpointsResource) Recover(ctx context.Context, applicationID, endpointID string, since time.Time, opts ...RequestOption) (*RecoverResult, error) {
+	now := time.Now()
+	if since.After(now) {
+		return nil, newClientValidationError("since", "must not be in the future")
+	}
+	if now.Sub(since) > RecoverWindow {
+		return nil, newClientValidationError("since", "must be within the last "+itoa(int(RecoverWindow.Hours()/24))+" days")
+	}
+	var resp struct {
+		Data RecoverResult `json:"data"`
+	}
+	body := map[string]interface{}{"since": since.UTC().Format(time.RFC3339)}
+	if err := r.t.do(ctx, "POST", "/api/webhook-endpoints/"+url.PathEscape(endpointID)+"/recover", nil, body, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// GetRecoverStatus returns the progress of an asynchronous recover operation.
+func (r *EndpointsResource) GetRecoverStatus(ctx context.Context, applicationID, endpointID, taskID string, opts ...RequestOption) (*RecoverResult, error) {
+	var resp struct {
+		Data RecoverResult `json:"data"`
+	}
+	if err := r.t.do(ctx, "GET", "/api/webhook-endpoints/"+url.PathEscape(endpointID)+"/recover/"+url.PathEscape(taskID), nil, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// AddFilterTypes adds event types to an endpoint's filter types, skipping any already
+// present. The endpoint is re-read and the change retried if another writer modifies
+// it concurrently.
+func (r *EndpointsResource) AddFilterTypes(ctx context.Context, applicationID, endpointID string, types []string, opts ...RequestOption) (*Endpoint, error) {
+	return r.modifyFilterTypes(ctx, applicationID, endpointID, func(current []string) []string {
+		return dedupeStrings(append(append([]string{}, current...), types...))
+	}, opts...)
+}
+
+// RemoveFilterTypes removes event types from an endpoint's filter types. Like
+// AddFilterTypes, it retries if the endpoint is modified concurrently.
+func (r *EndpointsResource) RemoveFilterTypes(ctx context.Context, applicationID, endpointID string, types []string, opts ...RequestOption) (*Endpoint, error) {
+	remove := make(map[string]bool, len(types))
+	for _, t := range types {
+		remove[t] = true
+	}
+	return r.modifyFilterTypes(ctx, applicationID, endpointID, func(current []string) []string {
+		kept := []string{}
+		for _, t := range dedupeStrings(current) {
+			if !remove[t] {
+				kept = append(kept, t)
+			}
+		}
+		return kept
+	}, opts...)
+}
+
+// SetFilterTypes replaces an endpoint's filter types. If validate is true, every type
+// is first checked against the organization's event types and a *ValidationError
+// listing unknown names is returned instead of creating subscriptions that can never
+// match.
+func (r *EndpointsResource) SetFilterTypes(ctx context.Context, applicationID, endpointID string, types []string, validate bool, opts ...RequestOption) (*Endpoint, error) {
+	types = dedupeStrings(types)
+	if validate {
+		if err := r.validateEventTypeNames(ctx, types, opts...); err != nil {
+			return nil, err
+		}
+	}
+	var resp struct {
+		Data Endpoint `json:"data"`
+	}
+	body := map[string]interface{}{"filterTypes": types}
+	if err := r.t.do(ctx, "PATCH", "/api/webhook-endpoints/"+url.PathEscape(endpointID), nil, body, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// modifyFilterTypes applies change to the endpoint's current filter types and writes the
+// result conditionally, with If-Match, on the endpoint still having the ETag it was
+// read with. If the API sent no ETag, the write is unconditional.
+func (r *EndpointsResource) modifyFilterTypes(ctx context.Context, applicationID, endpointID string, change func([]string) []string, opts ...RequestOption) (*Endpoint, error) {
+	var lastErr error
+	for attempt := 0; attempt < maxFilterTypeAttempts; attempt++ {
+		var meta ResponseMeta
+		getOpts := append(append([]RequestOption{}, opts...), WithResponseMeta(&meta))
+		ep, err := r.Get(ctx, applicationID, endpointID, getOpts...)
+		if err != nil {
+			return nil, err
+		}
+		types := change(ep.FilterTypes)
+		if stringSlicesEqual(types, ep.FilterTypes) {
+			return ep, nil
+		}
+		var resp struct {
+			Data Endpoint `json:"data"`
+		}
+		body := map[string]interface{}{"filterTypes": types}
+		updateOpts := opts
+		if etag := meta.Header.Get("ETag"); etag != "" {
+			updateOpts = append(append([]RequestOption{}, opts...), withIfMatch(etag))
+		}
+		err = r.t.do(ctx, "PATCH", "/api/webhook-endpoints/"+url.PathEscape(endpointID), nil, body, &resp, updateOpts...)
+		if err == nil {
+			return &resp.Data, nil
+		}
+		if _, ok := err.(*ConflictError); !ok {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+func (r *EndpointsResource) validateEventTypeNames(ctx context.Context, names []string, opts ...RequestOption) error {
+	eventTypes := &EventTypesResource{t: r.t}
+	known := map[string]bool{}
+	params := &ListEventTypesParams{Limit: Ptr(100), Offset: Ptr(0)}
+	for {
+		page, err := eventTypes.List(ctx, params, opts...)
+		if err != nil {
+			return err
+		}
+		for _, et := range page.Data {
+			known[et.Name] = true
+		}
+		if !page.HasMore || len(page.Data) == 0 {
+			break
+		}
+		*params.Offset += len(page.Data)
+	}
+	var unknown []string
+	for _, name := range names {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		return newClientValidationError("filterTypes", "unknown event type(s): "+strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+func dedupeStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	out := []string{}
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// PauseUntil disables an endpoint and records when it should be re-enabled. The resume
+// time is sent as pausedUntil and also stored in the endpoint's metadata under
+// PausedUntilMetadataKey. The endpoint is not re-enabled automatically; call
+// ResumeIfDue periodically, for example from a cron job.
+func (r *EndpointsResource) PauseUntil(ctx context.Context, applicationID, endpointID string, until time.Time, opts ...RequestOption) (*Endpoint, error) {
+	if !until.After(time.Now()) {
+		return nil, newClientValidationError("until", "must be in the future")
+	}
+	ep, err := r.Get(ctx, applicationID, endpointID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	untilStr := until.UTC().Format(time.RFC3339)
+	metadata := make(map[string]interface{}, len(ep.Metadata)+1)
+	for k, v := range ep.Metadata {
+		metadata[k] = v
+	}
+	metadata[PausedUntilMetadataKey] = untilStr
+	body := map[string]interface{}{
+		"isDisabled":  true,
+		"pausedUntil": untilStr,
+		"metadata":    metadata,
+	}
+	return r.patch(ctx, endpointID, body, opts...)
+}
+
+// ResumeIfDue re-enables every disabled endpoint in an application whose PauseUntil
+// time has passed, and returns the endpoints it resumed. Endpoints disabled without
+// PauseUntil are left alone.
+func (r *EndpointsResource) ResumeIfDue(ctx context.Context, applicationID string, opts ...RequestOption) ([]Endpoint, error) {
+	var due []Endpoint
+	now := time.Now()
+	params := &ListEndpointsParams{Limit: Ptr(100), Offset: Ptr(0), IsDisabled: Ptr(true)}
+	for {
+		page, err := r.List(ctx, applicationID, params, opts...)
+		if err != nil {
+			return nil, err
+		}
+		for _, ep := range page.Data {
+			if until, ok := ep.pausedUntil(); ok && bool(ep.IsDisabled) && !until.After(now) {
+				due = append(due, ep)
+			}
+		}
+		if !page.HasMore || len(page.Data) == 0 {
+			break
+		}
+		*params.Offset += len(page.Data)
+	}
+
+	resumed := []Endpoint{}
+	for _, ep := range due {
+		body := map[string]interface{}{
+			"isDisabled":  false,
+			"pausedUntil": nil,
+		}
+		if _, ok := ep.Metadata[PausedUntilMetadataKey]; ok {
+			metadata := make(map[string]interface{}, len(ep.Metadata))
+			for k, v := range ep.Metadata {
+				if k != PausedUntilMetadataKey {
+					metadata[k] = v
+				}
+			}
+			body["metadata"] = metadata
+		}
+		updated, err := r.patch(ctx, ep.ID, body, opts...)
+		if err != nil {
+			return resumed, err
+		}
+		resumed = append(resumed, *updated)
+	}
+	return resumed, nil
+}
+
+// pausedUntil returns the resume time recorded by PauseUntil, preferring the API field
+// over the metadata fallback.
+func (e *Endpoint) pausedUntil() (time.Time, bool) {
+	var s string
+	if e.PausedUntil != nil && *e.PausedUntil != "" {
+		s = *e.PausedUntil
+	} else if v, ok := e.Metadata[PausedUntilMetadataKey].(string); ok {
+		s = v
+	} else {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// patch sends a partial update with a hand-built body, for fields that
+// UpdateEndpointParams cannot express, such as explicit nulls.
+func (r *EndpointsResource) patch(ctx context.Context, endpointID string, body map[string]interface{}, opts ...RequestOption) (*Endpoint, error) {
+	var resp struct {
+		Data Endpoint `json:"data"`
+	}
+	if err := r.t.do(ctx, "PATCH", "/api/webhook-endpoints/"+url.PathEscape(endpointID), nil, body, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
 }
@@ -1,6 +1,11 @@
 package hookbase
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
 
 // Error is the base error type for all Hookbase SDK errors.
 type Error struct {
@@ -11,11 +16,44 @@ func (e *Error) Error() string {
 	return e.Message
 }
 
+// ErrorCode is the machine-readable code of an API error. The API may return codes
+// other than the constants below.
+type ErrorCode string
+
+// Known error codes.
+const (
+	CodeAuthentication       ErrorCode = "authentication_error"
+	CodeInvalidAPIKey        ErrorCode = "invalid_api_key"
+	CodeInvalidToken         ErrorCode = "invalid_token"
+	CodeForbidden            ErrorCode = "forbidden"
+	CodeNotFound             ErrorCode = "not_found"
+	CodeConflict             ErrorCode = "conflict"
+	CodePreconditionFailed   ErrorCode = "precondition_failed"
+	CodeIdempotencyKeyReused ErrorCode = "idempotency_key_reused"
+	CodeGroupNotEmpty        ErrorCode = "group_not_empty"
+	CodeSubdomainTaken       ErrorCode = "subdomain_taken"
+	CodeMessageTerminal      ErrorCode = "message_terminal"
+	CodeValidation           ErrorCode = "validation_error"
+	CodeRateLimitExceeded    ErrorCode = "rate_limit_exceeded"
+	CodeInternal             ErrorCode = "internal_error"
+	CodeServiceUnavailable   ErrorCode = "service_unavailable"
+	CodeGatewayTimeout       ErrorCode = "gateway_timeout"
+	// CodeUnknown is used when the API error body has no code.
+	CodeUnknown ErrorCode = "unknown_error"
+
+	// CodeClientValidation marks a *ValidationError from a check the SDK performed
+	// before sending the request.
+	CodeClientValidation ErrorCode = "client_validation_error"
+	// CodeSchemaValidation marks a *ValidationError from validating a message payload
+	// against its event type schema before sending.
+	CodeSchemaValidation ErrorCode = "schema_validation_error"
+)
+
 // APIError is returned when the API responds with an error status code.
 type APIError struct {
 	Message   string
 	Status    int
-	Code      string
+	Code      ErrorCode
 	RequestID string
 	Details   map[string]interface{}
 }
@@ -27,11 +65,75 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("hookbase: API error %d (%s): %s", e.Status, e.Code, e.Message)
 }
 
+// apiError returns e. The typed errors that embed APIError inherit it, which lets
+// IsErrorCode find the APIError inside any of them.
+func (e *APIError) apiError() *APIError {
+	return e
+}
+
+// IsErrorCode reports whether err, or an error it wraps, is an API error with code.
+// It matches every typed error that embeds APIError, such as *NotFoundError.
+func IsErrorCode(err error, code ErrorCode) bool {
+	var target interface{ apiError() *APIError }
+	return errors.As(err, &target) && target.apiError().Code == code
+}
+
+// statusError is the type of the status sentinels such as ErrNotFound.
+type statusError struct {
+	msg      string
+	statuses []int
+}
+
+func (e *statusError) Error() string {
+	return e.msg
+}
+
+// Sentinels for use with errors.Is. An API error matches a sentinel when its status
+// code does, whichever typed error carries it:
+//
+//	if errors.Is(err, hookbase.ErrNotFound) { ... }
+var (
+	// ErrUnauthorized matches 401 errors.
+	ErrUnauthorized error = &statusError{"hookbase: unauthorized", []int{401}}
+	// ErrForbidden matches 403 errors.
+	ErrForbidden error = &statusError{"hookbase: forbidden", []int{403}}
+	// ErrNotFound matches 404 errors.
+	ErrNotFound error = &statusError{"hookbase: not found", []int{404}}
+	// ErrConflict matches 409 and 412 errors, the statuses of *ConflictError.
+	ErrConflict error = &statusError{"hookbase: conflict", []int{409, 412}}
+	// ErrRateLimit matches 429 errors.
+	ErrRateLimit error = &statusError{"hookbase: rate limit exceeded", []int{429}}
+)
+
+// Is reports whether target is a status sentinel, such as ErrNotFound, that matches
+// e's status code. The typed errors that embed APIError inherit it.
+func (e *APIError) Is(target error) bool {
+	s, ok := target.(*statusError)
+	if !ok {
+		return false
+	}
+	for _, status := range s.statuses {
+		if e.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
 // AuthenticationError is returned when the API key is invalid or missing (401).
 type AuthenticationError struct {
 	APIError
 }
 
+// InvalidPortalTokenError is returned by PortalTokens.Introspect when the API rejects
+// the portal token itself (401 with code CodeInvalidToken), for example because it is
+// malformed or unknown. A
+// token that exists but has expired or been revoked is not an error; check
+// PortalTokenInfo instead.
+type InvalidPortalTokenError struct {
+	APIError
+}
+
 // ForbiddenError is returned when access is denied (403).
 type ForbiddenError struct {
 	APIError
@@ -42,6 +144,16 @@ type NotFoundError struct {
 	APIError
 }
 
+// ConflictError is returned when the request conflicts with the current state of a
+// resource (409), or when a conditional write finds the resource has changed (412).
+type ConflictError struct {
+	APIError
+	// ConflictingResourceID is the ID of the existing resource that caused the
+	// conflict, such as the source that already uses a slug. It is empty if the API
+	// did not report one.
+	ConflictingResourceID string
+}
+
 // ValidationError is returned when request validation fails (400/422).
 type ValidationError struct {
 	APIError
@@ -56,12 +168,109 @@ func (e *ValidationError) Error() string {
 	return base
 }
 
+// FirstError returns the first message of the first field in ValidationErrors,
+// ordered by field name. If there are no field errors, it returns Message.
+func (e *ValidationError) FirstError() string {
+	fields := e.sortedFields()
+	for _, field := range fields {
+		if msgs := e.ValidationErrors[field]; len(msgs) > 0 {
+			return msgs[0]
+		}
+	}
+	return e.Message
+}
+
+// AllErrors returns every field error as "field: message", ordered by field name and
+// then as reported for each field.
+func (e *ValidationError) AllErrors() []string {
+	var all []string
+	for _, field := range e.sortedFields() {
+		for _, msg := range e.ValidationErrors[field] {
+			all = append(all, field+": "+msg)
+		}
+	}
+	return all
+}
+
+func (e *ValidationError) sortedFields() []string {
+	fields := make([]string, 0, len(e.ValidationErrors))
+	for field := range e.ValidationErrors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// newClientValidationError returns a ValidationError for a check performed by the SDK
+// before any request is sent. Its Status is 0.
+func newClientValidationError(field, message string) *ValidationError {
+	return &ValidationError{
+		APIError: APIError{
+			Message: field + " " + message,
+			Code:    CodeClientValidation,
+		},
+		ValidationErrors: map[string][]string{field: {message}},
+	}
+}
+
 // RateLimitError is returned when the rate limit is exceeded (429).
 type RateLimitError struct {
 	APIError
 	RetryAfter int // seconds
 }
 
+// ServiceUnavailableError is returned when the API is temporarily unavailable (503),
+// such as during planned maintenance. It is retried.
+type ServiceUnavailableError struct {
+	APIError
+	RetryAfter int // seconds, or 0 if the API did not send Retry-After
+}
+
+// GatewayTimeoutError is returned when the API did not respond in time to its gateway
+// (504). It is retried.
+type GatewayTimeoutError struct {
+	APIError
+}
+
+// InUseError is returned when a resource cannot be deleted because routes still reference it.
+type InUseError struct {
+	Resource string
+	ID       string
+	Routes   []RouteRef
+}
+
+func (e *InUseError) Error() string {
+	return fmt.Sprintf("hookbase: %s %s is referenced by %d route(s)", e.Resource, e.ID, len(e.Routes))
+}
+
+// BatchError is returned when some items in a batch operation fail. Errors has one
+// entry per item in the batch, with nil for items that succeeded. For batches of
+// existing resources, FailedIDs lists the IDs of the items that failed, in order.
+type BatchError struct {
+	Errors    []error
+	Failed    int
+	FailedIDs []string
+}
+
+func (e *BatchError) Error() string {
+	if len(e.FailedIDs) > 0 {
+		return fmt.Sprintf("hookbase: %d of %d batch items failed: %s", e.Failed, len(e.Errors), strings.Join(e.FailedIDs, ", "))
+	}
+	return fmt.Sprintf("hookbase: %d of %d batch items failed", e.Failed, len(e.Errors))
+}
+
+// newIDBatchError returns a *BatchError for a batch keyed by ids, where errs has one
+// entry per ID.
+func newIDBatchError(ids []string, errs []error, failed int) *BatchError {
+	e := &BatchError{Errors: errs, Failed: failed, FailedIDs: make([]string, 0, failed)}
+	for i, err := range errs {
+		if err != nil {
+			e.FailedIDs = append(e.FailedIDs, ids[i])
+		}
+	}
+	return e
+}
+
 // TimeoutError is returned when a request times out.
 type TimeoutError struct {
 	Message string
@@ -96,3 +305,13 @@ type WebhookVerificationError struct {
 func (e *WebhookVerificationError) Error() string {
 	return fmt.Sprintf("hookbase: webhook verification failed: %s", e.Message)
 }
+
+// WebhookBodyTooLargeError is returned by Webhook.VerifyFromRequest when the request
+// body is larger than the verifier's limit. The signature is not checked.
+type WebhookBodyTooLargeError struct {
+	Limit int64
+}
+
+func (e *WebhookBodyTooLargeError) Error() string {
+	return fmt.Sprintf("hookbase: webhook body exceeds the %d byte limit", e.Limit)
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestErrorTypes(t *testing.T) {
@@ -16,6 +19,7 @@ func TestErrorTypes(t *testing.T) {
 		body       map[string]interface{}
 		checkType  func(error) bool
 		wantStatus int
+		wantCode   ErrorCode
 	}{
 		{
 			name:   "401 authentication error",
@@ -26,6 +30,7 @@ func TestErrorTypes(t *testing.T) {
 				return errors.As(err, &e)
 			},
 			wantStatus: 401,
+			wantCode:   CodeAuthentication,
 		},
 		{
 			name:   "403 forbidden error",
@@ -36,6 +41,7 @@ func TestErrorTypes(t *testing.T) {
 				return errors.As(err, &e)
 			},
 			wantStatus: 403,
+			wantCode:   CodeForbidden,
 		},
 		{
 			name:   "404 not found error",
@@ -46,6 +52,44 @@ func TestErrorTypes(t *testing.T) {
 				return errors.As(err, &e)
 			},
 			wantStatus: 404,
+			wantCode:   CodeNotFound,
+		},
+		{
+			name:   "409 conflict error",
+			status: 409,
+			body:   map[string]interface{}{"error": map[string]interface{}{"message": "Group is not empty", "code": "conflict"}},
+			checkType: func(err error) bool {
+				var e *ConflictError
+				return errors.As(err, &e)
+			},
+			wantStatus: 409,
+			wantCode:   CodeConflict,
+		},
+		{
+			name:   "409 duplicate slug",
+			status: 409,
+			body: map[string]interface{}{"error": map[string]interface{}{
+				"message":               "Slug already in use",
+				"code":                  "conflict",
+				"conflictingResourceId": "src_existing",
+			}},
+			checkType: func(err error) bool {
+				var e *ConflictError
+				return errors.As(err, &e) && e.ConflictingResourceID == "src_existing"
+			},
+			wantStatus: 409,
+			wantCode:   CodeConflict,
+		},
+		{
+			name:   "412 precondition failed",
+			status: 412,
+			body:   map[string]interface{}{"error": map[string]interface{}{"message": "Resource was modified", "code": "precondition_failed"}},
+			checkType: func(err error) bool {
+				var e *ConflictError
+				return errors.As(err, &e)
+			},
+			wantStatus: 412,
+			wantCode:   CodePreconditionFailed,
 		},
 		{
 			name:   "400 validation error",
@@ -62,6 +106,7 @@ func TestErrorTypes(t *testing.T) {
 				return errors.As(err, &e) && len(e.ValidationErrors) > 0
 			},
 			wantStatus: 400,
+			wantCode:   CodeValidation,
 		},
 		{
 			name:   "429 rate limit error",
@@ -72,6 +117,29 @@ func TestErrorTypes(t *testing.T) {
 				return errors.As(err, &e) && e.RetryAfter > 0
 			},
 			wantStatus: 429,
+			wantCode:   CodeRateLimitExceeded,
+		},
+		{
+			name:   "503 service unavailable",
+			status: 503,
+			body:   map[string]interface{}{"error": map[string]interface{}{"message": "Down for maintenance", "code": "service_unavailable"}},
+			checkType: func(err error) bool {
+				var e *ServiceUnavailableError
+				return errors.As(err, &e) && e.RetryAfter == 30
+			},
+			wantStatus: 503,
+			wantCode:   CodeServiceUnavailable,
+		},
+		{
+			name:   "504 gateway timeout",
+			status: 504,
+			body:   map[string]interface{}{"error": map[string]interface{}{"message": "Gateway timeout", "code": "gateway_timeout"}},
+			checkType: func(err error) bool {
+				var e *GatewayTimeoutError
+				return errors.As(err, &e)
+			},
+			wantStatus: 504,
+			wantCode:   CodeGatewayTimeout,
 		},
 		{
 			name:   "500 server error",
@@ -82,13 +150,14 @@ func TestErrorTypes(t *testing.T) {
 				return errors.As(err, &e) && e.Status == 500
 			},
 			wantStatus: 500,
+			wantCode:   CodeInternal,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				if tt.status == 429 {
+				if tt.status == 429 || tt.status == 503 {
 					w.Header().Set("Retry-After", "30")
 				}
 				w.WriteHeader(tt.status)
@@ -104,6 +173,9 @@ func TestErrorTypes(t *testing.T) {
 			if !tt.checkType(err) {
 				t.Errorf("error type check failed: %T: %v", err, err)
 			}
+			if !IsErrorCode(err, tt.wantCode) {
+				t.Errorf("expected code %s, got %v", tt.wantCode, err)
+			}
 
 			// Check APIError status
 			var apiErr *APIError
@@ -116,6 +188,100 @@ func TestErrorTypes(t *testing.T) {
 	}
 }
 
+func TestIsErrorCode(t *testing.T) {
+	notFound := &NotFoundError{APIError: APIError{Status: 404, Code: CodeNotFound}}
+	if !IsErrorCode(fmt.Errorf("loading source: %w", notFound), CodeNotFound) {
+		t.Error("expected wrapped NotFoundError to match")
+	}
+	if IsErrorCode(notFound, CodeConflict) {
+		t.Error("expected a different code not to match")
+	}
+	if !IsErrorCode(&APIError{Status: 500, Code: CodeInternal}, CodeInternal) {
+		t.Error("expected APIError to match")
+	}
+	if !IsErrorCode(newClientValidationError("ids", "is required"), CodeClientValidation) {
+		t.Error("expected client validation error to match")
+	}
+	if IsErrorCode(&NetworkError{Message: "refused"}, CodeUnknown) || IsErrorCode(nil, CodeUnknown) {
+		t.Error("expected non-API errors not to match")
+	}
+}
+
+func TestStatusSentinels(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{"not found", &NotFoundError{APIError: APIError{Status: 404}}, ErrNotFound, true},
+		{"wrapped not found", fmt.Errorf("loading source: %w", &NotFoundError{APIError: APIError{Status: 404}}), ErrNotFound, true},
+		{"unauthorized", &AuthenticationError{APIError: APIError{Status: 401}}, ErrUnauthorized, true},
+		{"portal token", &InvalidPortalTokenError{APIError: APIError{Status: 401}}, ErrUnauthorized, true},
+		{"forbidden", &ForbiddenError{APIError: APIError{Status: 403}}, ErrForbidden, true},
+		{"conflict", &ConflictError{APIError: APIError{Status: 409}}, ErrConflict, true},
+		{"precondition failed", &ConflictError{APIError: APIError{Status: 412}}, ErrConflict, true},
+		{"rate limit", &RateLimitError{APIError: APIError{Status: 429}, RetryAfter: 5}, ErrRateLimit, true},
+		{"plain APIError", &APIError{Status: 404}, ErrNotFound, true},
+		{"other status", &NotFoundError{APIError: APIError{Status: 404}}, ErrForbidden, false},
+		{"client validation", newClientValidationError("ids", "is required"), ErrNotFound, false},
+		{"network error", &NetworkError{Message: "refused"}, ErrNotFound, false},
+		{"nil", nil, ErrNotFound, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(tt.err, tt.target); got != tt.want {
+				t.Errorf("errors.Is(%v, %v) = %v, want %v", tt.err, tt.target, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidationErrorHelpers(t *testing.T) {
+	tests := []struct {
+		name      string
+		errors    map[string][]string
+		wantFirst string
+		wantAll   []string
+	}{
+		{
+			name:      "empty",
+			errors:    nil,
+			wantFirst: "Validation failed",
+			wantAll:   nil,
+		},
+		{
+			name:      "single field",
+			errors:    map[string][]string{"url": {"must use https", "must be public"}},
+			wantFirst: "must use https",
+			wantAll:   []string{"url: must use https", "url: must be public"},
+		},
+		{
+			name: "multiple fields",
+			errors: map[string][]string{
+				"url":       {"must use https"},
+				"eventType": {"is required"},
+				"name":      {"is too long"},
+			},
+			wantFirst: "is required",
+			wantAll:   []string{"eventType: is required", "name: is too long", "url: must use https"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &ValidationError{APIError: APIError{Message: "Validation failed"}, ValidationErrors: tt.errors}
+			if got := e.FirstError(); got != tt.wantFirst {
+				t.Errorf("FirstError() = %q, want %q", got, tt.wantFirst)
+			}
+			if got := e.AllErrors(); !reflect.DeepEqual(got, tt.wantAll) {
+				t.Errorf("AllErrors() = %q, want %q", got, tt.wantAll)
+			}
+		})
+	}
+}
+
 func TestNoRetryOnClientErrors(t *testing.T) {
 	attempts := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -166,3 +332,60 @@ func TestRetryOnServerErrors(t *testing.T) {
 		t.Errorf("expected 3 attempts, got %d", attempts)
 	}
 }
+
+func TestRetryOnServiceUnavailable(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		switch attempts {
+		case 1:
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(503)
+			json.NewEncoder(w).Encode(map[string]interface{}{"error": map[string]interface{}{"message": "maintenance"}})
+		case 2:
+			w.WriteHeader(504)
+			json.NewEncoder(w).Encode(map[string]interface{}{"error": map[string]interface{}{"message": "gateway timeout"}})
+		default:
+			json.NewEncoder(w).Encode(map[string]interface{}{"source": map[string]interface{}{"id": "src_1", "name": "Test"}})
+		}
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL), WithRetryPolicy(FixedInterval(3, 10*time.Millisecond)))
+	start := time.Now()
+	if _, err := client.Sources.Get(context.Background(), "src_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("expected the 503 Retry-After to be honored, took %v", elapsed)
+	}
+}
+
+func TestRetryWaitHonorsContext(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(429)
+		json.NewEncoder(w).Encode(map[string]interface{}{"error": map[string]interface{}{"message": "slow down"}})
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.Sources.Get(ctx, "src_1")
+	if _, ok := err.(*TimeoutError); !ok {
+		t.Errorf("expected TimeoutError, got %T %v", err, err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the Retry-After wait to end with the context, took %v", elapsed)
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+}
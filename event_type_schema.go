@@ -0,0 +1,133 @@
+package hookbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OutboundSchemaCacheTTL is how long event type schemas fetched for payload
+// validation are reused before being fetched again. Updating or deleting an event type
+// through the same client clears its cached schema immediately.
+const OutboundSchemaCacheTTL = 5 * time.Minute
+
+// eventTypeSchemaCache caches event types by name and ID for payload validation.
+type eventTypeSchemaCache struct {
+	mu      sync.Mutex
+	entries map[string]eventTypeSchemaEntry
+	now     func() time.Time
+}
+
+type eventTypeSchemaEntry struct {
+	eventType *EventType
+	expires   time.Time
+}
+
+func newEventTypeSchemaCache() *eventTypeSchemaCache {
+	return &eventTypeSchemaCache{entries: map[string]eventTypeSchemaEntry{}, now: time.Now}
+}
+
+func (c *eventTypeSchemaCache) get(key string) *EventType {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok || !c.now().Before(e.expires) {
+		return nil
+	}
+	return e.eventType
+}
+
+func (c *eventTypeSchemaCache) put(et *EventType) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e := eventTypeSchemaEntry{eventType: et, expires: c.now().Add(OutboundSchemaCacheTTL)}
+	c.entries[et.ID] = e
+	c.entries[et.Name] = e
+}
+
+// invalidate drops every entry for the event type with the given ID.
+func (c *eventTypeSchemaCache) invalidate(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, e := range c.entries {
+		if e.eventType.ID == id {
+			delete(c.entries, k)
+		}
+	}
+}
+
+// ValidatePayload validates payload against the schema of the event type with the
+// given name or ID, without sending anything. An event type without a schema accepts
+// any payload. Schemas are cached for OutboundSchemaCacheTTL.
+func (r *EventTypesResource) ValidatePayload(ctx context.Context, nameOrID string, payload interface{}, opts ...RequestOption) (*SchemaValidationResult, error) {
+	et, err := r.lookupCached(ctx, nameOrID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if et.Schema == nil {
+		return &SchemaValidationResult{Valid: true}, nil
+	}
+	schema, err := json.Marshal(et.Schema)
+	if err != nil {
+		return nil, &Error{Message: fmt.Sprintf("hookbase: invalid schema for event type %q: %v", et.Name, err)}
+	}
+	return ValidateAgainstSchema(schema, payload)
+}
+
+// lookupCached returns an event type by name, falling back to ID, using the schema cache.
+func (r *EventTypesResource) lookupCached(ctx context.Context, nameOrID string, opts ...RequestOption) (*EventType, error) {
+	if et := r.t.schemaCache.get(nameOrID); et != nil {
+		return et, nil
+	}
+	et, err := r.GetByName(ctx, nameOrID, opts...)
+	if _, notFound := err.(*NotFoundError); notFound {
+		et, err = r.Get(ctx, nameOrID, opts...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	r.t.schemaCache.put(et)
+	return et, nil
+}
+
+// validateOutboundPayload checks a message payload against its event type's schema
+// when WithOutboundSchemaValidation is enabled. Unknown event types are left for the
+// API to reject.
+func (r *MessagesResource) validateOutboundPayload(ctx context.Context, params *SendMessageParams, opts ...RequestOption) error {
+	if !r.t.outboundSchemas {
+		return nil
+	}
+	var payload interface{} = params.Payload
+	if params.RawPayload != nil {
+		payload = params.RawPayload
+	}
+	eventTypes := &EventTypesResource{t: r.t}
+	result, err := eventTypes.ValidatePayload(ctx, params.EventType, payload, opts...)
+	if _, notFound := err.(*NotFoundError); notFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if result.Valid {
+		return nil
+	}
+	details := map[string][]string{}
+	for _, e := range result.Errors {
+		pointer, msg := "", e
+		if i := strings.Index(e, ": "); i >= 0 {
+			pointer, msg = e[:i], e[i+2:]
+		}
+		details[pointer] = append(details[pointer], msg)
+	}
+	return &ValidationError{
+		APIError: APIError{
+			Message: fmt.Sprintf("payload does not match the schema for event type %q", params.EventType),
+			Code:    CodeSchemaValidation,
+		},
+		ValidationErrors: details,
+	}
+}
@@ -2,7 +2,12 @@ package hookbase
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/url"
+	"reflect"
+	"sort"
+	"strings"
 )
 
 // EventType represents an outbound event type definition.
@@ -27,6 +32,9 @@ type CreateEventTypeParams struct {
 	Description *string                `json:"description,omitempty"`
 	Category    *string                `json:"category,omitempty"`
 	Schema      map[string]interface{} `json:"schema,omitempty"`
+	// IsEnabled creates the event type disabled when false. The API enables new
+	// event types by default.
+	IsEnabled *bool `json:"isEnabled,omitempty"`
 }
 
 // UpdateEventTypeParams are the parameters for updating an event type.
@@ -36,6 +44,7 @@ type UpdateEventTypeParams struct {
 	Category    *string                `json:"category,omitempty"`
 	Schema      map[string]interface{} `json:"schema,omitempty"`
 	IsEnabled   *bool                  `json:"isEnabled,omitempty"`
+	IsArchived  *bool                  `json:"isArchived,omitempty"`
 }
 
 // ListEventTypesParams are the parameters for listing event types.
@@ -45,6 +54,8 @@ type ListEventTypesParams struct {
 	Category  *string `json:"category,omitempty"`
 	IsEnabled *bool   `json:"isEnabled,omitempty"`
 	Search    *string `json:"search,omitempty"`
+	// IncludeArchived includes archived event types, which are omitted by default.
+	IncludeArchived *bool `json:"includeArchived,omitempty"`
 }
 
 func (p *ListEventTypesParams) toQuery() url.Values {
@@ -67,9 +78,81 @@ func (p *ListEventTypesParams) toQuery() url.Values {
 	if p.Search != nil {
 		q.Set("search", *p.Search)
 	}
+	if p.IncludeArchived != nil {
+		q.Set("includeArchived", btoa(*p.IncludeArchived))
+	}
 	return q
 }
 
+// EventTypeStats contains subscription and message volume statistics for an event type.
+type EventTypeStats struct {
+	SubscriptionCount int `json:"subscriptionCount"`
+	MessagesLast24h   int `json:"messagesLast24h"`
+	MessagesLast7d    int `json:"messagesLast7d"`
+	MessagesTotal     int `json:"messagesTotal"`
+}
+
+// BulkCreateResult is the result of a bulk create operation. Items are validated
+// independently, so some may be created while others fail.
+type BulkCreateResult[T any] struct {
+	Created []T             `json:"created"`
+	Errors  []BulkItemError `json:"errors"`
+}
+
+// BulkItemError describes why a single item in a bulk operation failed.
+type BulkItemError struct {
+	Index   int    `json:"index"`
+	Name    string `json:"name,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+// MaxEventTypeNameLength is the longest event type name the API accepts.
+const MaxEventTypeNameLength = 100
+
+// ValidateEventTypeName checks that name follows the API's naming rules: one or more
+// dot-separated segments of lowercase letters, digits, underscores, and hyphens, each
+// starting with a letter, such as "order.created", and at most MaxEventTypeNameLength
+// characters. It returns a *ValidationError describing the problem.
+func ValidateEventTypeName(name string) error {
+	if name == "" {
+		return newClientValidationError("name", "is required")
+	}
+	if len(name) > MaxEventTypeNameLength {
+		return newClientValidationError("name", fmt.Sprintf("must be at most %d characters", MaxEventTypeNameLength))
+	}
+	for _, segment := range strings.Split(name, ".") {
+		if segment == "" {
+			return newClientValidationError("name", fmt.Sprintf("%q has an empty segment", name))
+		}
+		if segment[0] < 'a' || segment[0] > 'z' {
+			return newClientValidationError("name", fmt.Sprintf("%q: each segment must start with a lowercase letter", name))
+		}
+		for _, c := range segment {
+			if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '_' && c != '-' {
+				return newClientValidationError("name", fmt.Sprintf("%q contains invalid character %q", name, c))
+			}
+		}
+	}
+	return nil
+}
+
+// SyncOptions configures EventTypes.Sync.
+type SyncOptions struct {
+	// ArchiveExtra archives event types that are not in the desired set.
+	ArchiveExtra bool
+	// DryRun computes the result without creating, updating, or archiving anything.
+	DryRun bool
+}
+
+// SyncResult lists, by name, what EventTypes.Sync changed or would change.
+type SyncResult struct {
+	Created   []string
+	Updated   []string
+	Archived  []string
+	Unchanged []string
+}
+
 // EventTypesResource provides access to event type-related API endpoints.
 type EventTypesResource struct {
 	t *transport
@@ -109,8 +192,39 @@ func (r *EventTypesResource) Get(ctx context.Context, id string, opts ...Request
 	return &resp.Data, nil
 }
 
+// GetByName returns the event type with exactly the given name. If there is none, a
+// *NotFoundError is returned.
+func (r *EventTypesResource) GetByName(ctx context.Context, name string, opts ...RequestOption) (*EventType, error) {
+	params := &ListEventTypesParams{Limit: Ptr(100), Offset: Ptr(0), Search: Ptr(name), IncludeArchived: Ptr(true)}
+	for {
+		page, err := r.List(ctx, params, opts...)
+		if err != nil {
+			return nil, err
+		}
+		for i := range page.Data {
+			if page.Data[i].Name == name {
+				return &page.Data[i], nil
+			}
+		}
+		if !page.HasMore || len(page.Data) == 0 {
+			break
+		}
+		*params.Offset += len(page.Data)
+	}
+	return nil, &NotFoundError{APIError: APIError{
+		Message: fmt.Sprintf("event type %q not found", name),
+		Status:  404,
+		Code:    CodeNotFound,
+	}}
+}
+
 // Create creates a new event type.
 func (r *EventTypesResource) Create(ctx context.Context, params *CreateEventTypeParams, opts ...RequestOption) (*EventType, error) {
+	if r.t.clientValidation {
+		if err := ValidateEventTypeName(params.Name); err != nil {
+			return nil, err
+		}
+	}
 	var resp struct {
 		Data EventType `json:"data"`
 	}
@@ -125,6 +239,7 @@ func (r *EventTypesResource) Update(ctx context.Context, id string, params *Upda
 	var resp struct {
 		Data EventType `json:"data"`
 	}
+	defer r.t.schemaCache.invalidate(id)
 	if err := r.t.do(ctx, "PATCH", "/api/event-types/"+url.PathEscape(id), nil, params, &resp, opts...); err != nil {
 		return nil, err
 	}
@@ -133,15 +248,255 @@ func (r *EventTypesResource) Update(ctx context.Context, id string, params *Upda
 
 // Delete deletes an event type.
 func (r *EventTypesResource) Delete(ctx context.Context, id string, opts ...RequestOption) error {
+	defer r.t.schemaCache.invalidate(id)
 	return r.t.do(ctx, "DELETE", "/api/event-types/"+url.PathEscape(id), nil, nil, nil, opts...)
 }
 
-// Archive archives an event type (sets isEnabled to false).
+// Archive archives an event type (sets isArchived to true). Archived event types are
+// hidden from the portal but kept for history. To stop deliveries, use Disable.
 func (r *EventTypesResource) Archive(ctx context.Context, id string, opts ...RequestOption) (*EventType, error) {
-	return r.Update(ctx, id, &UpdateEventTypeParams{IsEnabled: Ptr(false)}, opts...)
+	return r.Update(ctx, id, &UpdateEventTypeParams{IsArchived: Ptr(true)}, opts...)
 }
 
-// Unarchive unarchives an event type (sets isEnabled to true).
+// Unarchive unarchives an event type (sets isArchived to false).
 func (r *EventTypesResource) Unarchive(ctx context.Context, id string, opts ...RequestOption) (*EventType, error) {
+	return r.Update(ctx, id, &UpdateEventTypeParams{IsArchived: Ptr(false)}, opts...)
+}
+
+// Enable enables deliveries for an event type (sets isEnabled to true).
+func (r *EventTypesResource) Enable(ctx context.Context, id string, opts ...RequestOption) (*EventType, error) {
 	return r.Update(ctx, id, &UpdateEventTypeParams{IsEnabled: Ptr(true)}, opts...)
 }
+
+// Disable suppresses deliveries for an event type (sets isEnabled to false).
+func (r *EventTypesResource) Disable(ctx context.Context, id string, opts ...RequestOption) (*EventType, error) {
+	return r.Update(ctx, id, &UpdateEventTypeParams{IsEnabled: Ptr(false)}, opts...)
+}
+
+// Clone creates a copy of an event type under newName. The schema, display name,
+// description, and category are copied from the original. The clone is created
+// disabled, so it never receives deliveries before it is enabled.
+func (r *EventTypesResource) Clone(ctx context.Context, id string, newName string, opts ...RequestOption) (*EventType, error) {
+	if newName == "" {
+		return nil, &Error{Message: "hookbase: clone name is required"}
+	}
+	src, err := r.Get(ctx, id, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return r.Create(ctx, &CreateEventTypeParams{
+		Name:        newName,
+		DisplayName: src.DisplayName,
+		Description: src.Description,
+		Category:    src.Category,
+		Schema:      src.Schema,
+		IsEnabled:   Ptr(false),
+	}, opts...)
+}
+
+// GetStats returns subscription and message volume statistics for an event type.
+func (r *EventTypesResource) GetStats(ctx context.Context, id string, opts ...RequestOption) (*EventTypeStats, error) {
+	var resp struct {
+		Data EventTypeStats `json:"data"`
+	}
+	if err := r.t.do(ctx, "GET", "/api/event-types/"+url.PathEscape(id)+"/stats", nil, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// BulkCreate creates multiple event types in one call. Partial success is allowed;
+// items that fail validation are reported in BulkCreateResult.Errors.
+func (r *EventTypesResource) BulkCreate(ctx context.Context, params []CreateEventTypeParams, opts ...RequestOption) (*BulkCreateResult[EventType], error) {
+	var resp struct {
+		Data BulkCreateResult[EventType] `json:"data"`
+	}
+	body := map[string]interface{}{"eventTypes": params}
+	if err := r.t.do(ctx, "POST", "/api/event-types/bulk", nil, body, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// BulkDelete deletes multiple event types. By default the API returns a
+// *ValidationError if any of them is still referenced by an active subscription; pass
+// WithForce to delete those subscriptions too.
+func (r *EventTypesResource) BulkDelete(ctx context.Context, ids []string, opts ...RequestOption) (*BulkDeleteResult, error) {
+	rc := &requestConfig{}
+	for _, opt := range opts {
+		opt(rc)
+	}
+	var resp BulkDeleteResult
+	body := map[string]interface{}{"ids": ids}
+	if rc.force {
+		body["force"] = true
+	}
+	if err := r.t.do(ctx, "DELETE", "/api/event-types/bulk", nil, body, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Export exports event types as JSON.
+func (r *EventTypesResource) Export(ctx context.Context, ids []string, opts ...RequestOption) (interface{}, error) {
+	var q url.Values
+	if len(ids) > 0 {
+		q = url.Values{"ids": {joinIDs(ids)}}
+	}
+	var resp interface{}
+	if err := r.t.do(ctx, "GET", "/api/event-types/export", q, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ImportEventTypesParams are the parameters for importing event types.
+type ImportEventTypesParams struct {
+	EventTypes       []map[string]interface{} `json:"eventTypes"`
+	ConflictStrategy *string                  `json:"conflictStrategy,omitempty"`
+	ValidateOnly     *bool                    `json:"validateOnly,omitempty"`
+}
+
+// Import imports event types from JSON.
+func (r *EventTypesResource) Import(ctx context.Context, params *ImportEventTypesParams, opts ...RequestOption) (*ImportResult, error) {
+	var resp ImportResult
+	if err := r.t.do(ctx, "POST", "/api/event-types/import", nil, params, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Sync converges the organization's event types to desired. Missing types are
+// created, and existing types whose display name, description, category, or schema
+// differ are updated; nil fields in desired are left as they are. Archived types in
+// desired are unarchived. Types not in desired are archived only if opts.ArchiveExtra
+// is set. With opts.DryRun, the result describes the plan and nothing is changed.
+//
+// If a change fails, Sync stops and returns the changes made so far with the error.
+func (r *EventTypesResource) Sync(ctx context.Context, desired []CreateEventTypeParams, opts *SyncOptions, reqOpts ...RequestOption) (*SyncResult, error) {
+	if opts == nil {
+		opts = &SyncOptions{}
+	}
+	seen := make(map[string]bool, len(desired))
+	for _, d := range desired {
+		if r.t.clientValidation {
+			if err := ValidateEventTypeName(d.Name); err != nil {
+				return nil, err
+			}
+		}
+		if seen[d.Name] {
+			return nil, newClientValidationError("name", fmt.Sprintf("%q appears more than once", d.Name))
+		}
+		seen[d.Name] = true
+	}
+
+	existing := map[string]EventType{}
+	params := &ListEventTypesParams{Limit: Ptr(100), Offset: Ptr(0), IncludeArchived: Ptr(true)}
+	for {
+		page, err := r.List(ctx, params, reqOpts...)
+		if err != nil {
+			return nil, err
+		}
+		for _, et := range page.Data {
+			existing[et.Name] = et
+		}
+		if !page.HasMore || len(page.Data) == 0 {
+			break
+		}
+		*params.Offset += len(page.Data)
+	}
+
+	result := &SyncResult{}
+	for i := range desired {
+		d := &desired[i]
+		current, ok := existing[d.Name]
+		if !ok {
+			if !opts.DryRun {
+				if _, err := r.Create(ctx, d, reqOpts...); err != nil {
+					return result, err
+				}
+			}
+			result.Created = append(result.Created, d.Name)
+			continue
+		}
+		update := eventTypeChanges(&current, d)
+		if update == nil {
+			result.Unchanged = append(result.Unchanged, d.Name)
+			continue
+		}
+		if !opts.DryRun {
+			if _, err := r.Update(ctx, current.ID, update, reqOpts...); err != nil {
+				return result, err
+			}
+		}
+		result.Updated = append(result.Updated, d.Name)
+	}
+
+	if opts.ArchiveExtra {
+		var extra []EventType
+		for name, et := range existing {
+			if !seen[name] && !et.archived() {
+				extra = append(extra, et)
+			}
+		}
+		sort.Slice(extra, func(i, j int) bool { return extra[i].Name < extra[j].Name })
+		for _, et := range extra {
+			if !opts.DryRun {
+				if _, err := r.Archive(ctx, et.ID, reqOpts...); err != nil {
+					return result, err
+				}
+			}
+			result.Archived = append(result.Archived, et.Name)
+		}
+	}
+	return result, nil
+}
+
+// eventTypeChanges returns the update needed to bring current in line with desired, or
+// nil if it already matches.
+func eventTypeChanges(current *EventType, desired *CreateEventTypeParams) *UpdateEventTypeParams {
+	update := &UpdateEventTypeParams{}
+	changed := false
+	if desired.DisplayName != nil && !stringPtrEqual(current.DisplayName, desired.DisplayName) {
+		update.DisplayName, changed = desired.DisplayName, true
+	}
+	if desired.Description != nil && !stringPtrEqual(current.Description, desired.Description) {
+		update.Description, changed = desired.Description, true
+	}
+	if desired.Category != nil && !stringPtrEqual(current.Category, desired.Category) {
+		update.Category, changed = desired.Category, true
+	}
+	if desired.Schema != nil && !sameJSON(current.Schema, desired.Schema) {
+		update.Schema, changed = desired.Schema, true
+	}
+	if current.archived() {
+		update.IsArchived, changed = Ptr(false), true
+	}
+	if !changed {
+		return nil
+	}
+	return update
+}
+
+func (e *EventType) archived() bool {
+	return e.IsArchived != nil && *e.IsArchived
+}
+
+func stringPtrEqual(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// sameJSON reports whether a and b encode to the same JSON value, so that, for
+// example, int and float64 numbers compare equal.
+func sameJSON(a, b interface{}) bool {
+	var na, nb interface{}
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	if errA != nil || errB != nil || json.Unmarshal(ja, &na) != nil || json.Unmarshal(jb, &nb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(na, nb)
+}
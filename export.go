@@ -0,0 +1,88 @@
+package hookbase
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ConfigExport bundles the exported routing configuration of an organization
+// into a single document. Each list can be passed to the matching Import method.
+type ConfigExport struct {
+	ExportedAt   string                   `json:"exportedAt"`
+	Sources      []map[string]interface{} `json:"sources"`
+	Destinations []map[string]interface{} `json:"destinations"`
+	Routes       []map[string]interface{} `json:"routes"`
+	Filters      []map[string]interface{} `json:"filters"`
+	Transforms   []map[string]interface{} `json:"transforms"`
+	Schemas      []map[string]interface{} `json:"schemas"`
+}
+
+// ExportAll exports sources, destinations, routes, filters, transforms, and schemas
+// into a single document, using each resource's Export method. It fails if an export
+// response does not contain the expected list.
+func (c *Client) ExportAll(ctx context.Context, opts ...RequestOption) (*ConfigExport, error) {
+	out := &ConfigExport{ExportedAt: time.Now().UTC().Format(time.RFC3339)}
+
+	untyped := []struct {
+		key    string
+		export func(context.Context, []string, ...RequestOption) (interface{}, error)
+		dst    *[]map[string]interface{}
+	}{
+		{"sources", c.Sources.Export, &out.Sources},
+		{"destinations", c.Destinations.Export, &out.Destinations},
+		{"routes", c.Routes.Export, &out.Routes},
+	}
+	for _, u := range untyped {
+		resp, err := u.export(ctx, nil, opts...)
+		if err != nil {
+			return nil, err
+		}
+		if *u.dst, err = exportedList(resp, u.key); err != nil {
+			return nil, err
+		}
+	}
+
+	filters, err := c.Filters.Export(ctx, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	transforms, err := c.Transforms.Export(ctx, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	schemas, err := c.Schemas.Export(ctx, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	out.Filters, out.Transforms, out.Schemas = filters.Filters, transforms.Transforms, schemas.Schemas
+	for key, list := range map[string][]map[string]interface{}{"filters": out.Filters, "transforms": out.Transforms, "schemas": out.Schemas} {
+		if list == nil {
+			return nil, missingExportListError(key)
+		}
+	}
+
+	return out, nil
+}
+
+// exportedList returns the list under key in an export response decoded into
+// interface{}. A missing or null key, or one that is not a list of objects, is an
+// error.
+func exportedList(resp interface{}, key string) ([]map[string]interface{}, error) {
+	obj, _ := resp.(map[string]interface{})
+	items, ok := obj[key].([]interface{})
+	if !ok {
+		return nil, missingExportListError(key)
+	}
+	list := make([]map[string]interface{}, len(items))
+	for i, item := range items {
+		if list[i], ok = item.(map[string]interface{}); !ok {
+			return nil, &Error{Message: fmt.Sprintf("hookbase: %s export item %d is not an object", key, i)}
+		}
+	}
+	return list, nil
+}
+
+func missingExportListError(key string) error {
+	return &Error{Message: fmt.Sprintf("hookbase: %s export response has no %q list", key, key)}
+}
@@ -150,3 +150,40 @@ func (r *FiltersResource) Test(ctx context.Context, params *FilterTestParams, op
 	}
 	return &resp, nil
 }
+
+// FiltersExport is the document returned by Filters.Export. The filters it
+// contains can be passed directly to Filters.Import.
+type FiltersExport struct {
+	Version    string                   `json:"version,omitempty"`
+	ExportedAt string                   `json:"exportedAt,omitempty"`
+	Filters    []map[string]interface{} `json:"filters"`
+}
+
+// Export exports filters as JSON.
+func (r *FiltersResource) Export(ctx context.Context, ids []string, opts ...RequestOption) (*FiltersExport, error) {
+	var q url.Values
+	if len(ids) > 0 {
+		q = url.Values{"ids": {joinIDs(ids)}}
+	}
+	var resp FiltersExport
+	if err := r.t.do(ctx, "GET", "/api/filters/export", q, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ImportFiltersParams are the parameters for importing filters.
+type ImportFiltersParams struct {
+	Filters          []map[string]interface{} `json:"filters"`
+	ConflictStrategy *string                  `json:"conflictStrategy,omitempty"`
+	ValidateOnly     *bool                    `json:"validateOnly,omitempty"`
+}
+
+// Import imports filters from JSON.
+func (r *FiltersResource) Import(ctx context.Context, params *ImportFiltersParams, opts ...RequestOption) (*ImportResult, error) {
+	var resp ImportResult
+	if err := r.t.do(ctx, "POST", "/api/filters/import", nil, params, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
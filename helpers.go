@@ -2,7 +2,9 @@ package hookbase
 
 import (
 	"encoding/json"
+	"fmt"
 	"strconv"
+	"time"
 )
 
 func itoa(i int) string {
@@ -18,6 +20,43 @@ func Ptr[T any](v T) *T {
 	return &v
 }
 
+// Nullable is an update field that can be set to a value or cleared. A nil *Nullable
+// is omitted from the request, NullableValue sends a value, and NullValue sends JSON
+// null to clear the field.
+type Nullable[T any] struct {
+	Value T
+	Valid bool
+}
+
+// NullableValue returns a Nullable that sends v.
+func NullableValue[T any](v T) *Nullable[T] {
+	return &Nullable[T]{Value: v, Valid: true}
+}
+
+// NullValue returns a Nullable that sends null, clearing the field.
+func NullValue[T any]() *Nullable[T] {
+	return &Nullable[T]{}
+}
+
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.Value)
+}
+
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = Nullable[T]{}
+		return nil
+	}
+	if err := json.Unmarshal(data, &n.Value); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
 // FlexBool handles JSON booleans that may arrive as integers (0/1) from D1/SQLite.
 type FlexBool bool
 
@@ -64,3 +103,62 @@ func (j *JSONString[T]) UnmarshalJSON(data []byte) error {
 	json.Unmarshal([]byte(s), &j.Value)
 	return nil
 }
+
+func (j JSONString[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.Value)
+}
+
+// Time is a time.Time that decodes the timestamp formats returned by the API: RFC 3339
+// strings, SQLite "2006-01-02 15:04:05" strings (UTC), and Unix timestamps in seconds
+// or milliseconds. null and empty strings decode to the zero time.
+type Time struct {
+	time.Time
+}
+
+// timeLayouts are the string formats accepted by Time, tried in order.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+func (t *Time) UnmarshalJSON(data []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	switch v := raw.(type) {
+	case nil:
+		t.Time = time.Time{}
+	case float64:
+		// Values above 1e11 are too far in the future to be seconds.
+		if v > 1e11 {
+			t.Time = time.UnixMilli(int64(v)).UTC()
+		} else {
+			t.Time = time.Unix(int64(v), 0).UTC()
+		}
+	case string:
+		if v == "" {
+			t.Time = time.Time{}
+			return nil
+		}
+		for _, layout := range timeLayouts {
+			if parsed, err := time.Parse(layout, v); err == nil {
+				t.Time = parsed
+				return nil
+			}
+		}
+		return fmt.Errorf("hookbase: cannot parse %q as a time", v)
+	default:
+		return fmt.Errorf("hookbase: cannot parse %s as a time", data)
+	}
+	return nil
+}
+
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.Format(time.RFC3339Nano))
+}
@@ -23,23 +23,33 @@
 //	})
 package hookbase
 
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
 // Client is the main Hookbase API client.
 type Client struct {
 	transport *transport
 
 	// Inbound resources
-	Sources      *SourcesResource
-	Destinations *DestinationsResource
-	Routes       *RoutesResource
-	Events       *EventsResource
-	Deliveries   *DeliveriesResource
-	Transforms   *TransformsResource
-	Filters      *FiltersResource
-	Schemas      *SchemasResource
-	APIKeys      *APIKeysResource
-	Cron         *CronResource
-	Tunnels      *TunnelsResource
-	Analytics    *AnalyticsResource
+	Sources       *SourcesResource
+	Destinations  *DestinationsResource
+	Routes        *RoutesResource
+	Events        *EventsResource
+	Deliveries    *DeliveriesResource
+	Transforms    *TransformsResource
+	Filters       *FiltersResource
+	Schemas       *SchemasResource
+	APIKeys       *APIKeysResource
+	Cron          *CronResource
+	Tunnels       *TunnelsResource
+	Analytics     *AnalyticsResource
+	Organization  *OrganizationResource
+	Usage         *UsageResource
+	AuditLogs     *AuditLogsResource
+	Notifications *NotificationsResource
 
 	// Outbound resources
 	Applications  *ApplicationsResource
@@ -79,6 +89,10 @@ func New(apiKey string, opts ...ClientOption) *Client {
 	c.Cron = &CronResource{t: t}
 	c.Tunnels = &TunnelsResource{t: t}
 	c.Analytics = &AnalyticsResource{t: t}
+	c.Organization = &OrganizationResource{t: t}
+	c.Usage = &UsageResource{t: t}
+	c.AuditLogs = &AuditLogsResource{t: t}
+	c.Notifications = &NotificationsResource{t: t}
 
 	// Outbound
 	c.Applications = &ApplicationsResource{t: t}
@@ -91,3 +105,56 @@ func New(apiKey string, opts ...ClientOption) *Client {
 
 	return c
 }
+
+// PingResult is the API's response to Client.Ping.
+type PingResult struct {
+	Status string `json:"status"`
+	// Version is the release of the API server that answered.
+	Version string `json:"version"`
+	// APIVersion is the dated API version the key's requests are served with, such as
+	// "2024-06-01". Unlike Version, it changes only when the API's behavior does.
+	APIVersion string `json:"apiVersion"`
+	// OrganizationID is the organization the API key belongs to.
+	OrganizationID string `json:"organizationId"`
+	// KeyPrefix is the prefix of the API key used, for identifying it in logs.
+	KeyPrefix string `json:"keyPrefix"`
+	// Scopes are the API key's scopes, such as ScopeSourcesRead.
+	Scopes []string `json:"scopes"`
+}
+
+// HasScope reports whether the API key has scope.
+func (p *PingResult) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// RequireScopes returns an error naming the API key and every scope in scopes that it
+// lacks, or nil if it has them all. Call it after Ping at startup to fail fast on a
+// misconfigured key.
+func (p *PingResult) RequireScopes(scopes ...string) error {
+	var missing []string
+	for _, scope := range scopes {
+		if !p.HasScope(scope) {
+			missing = append(missing, scope)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return &Error{Message: fmt.Sprintf("hookbase: API key %s... is missing scopes: %s", p.KeyPrefix, strings.Join(missing, ", "))}
+}
+
+// Ping checks that the API is reachable and the API key is accepted, and reports the
+// key's organization and scopes. Network failures and rejected keys return the usual
+// typed errors, such as *NetworkError and *AuthenticationError.
+func (c *Client) Ping(ctx context.Context, opts ...RequestOption) (*PingResult, error) {
+	var resp PingResult
+	if err := c.transport.do(ctx, "GET", "/api/health", nil, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
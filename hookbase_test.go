@@ -1,11 +1,22 @@
 package hookbase
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewClient(t *testing.T) {
@@ -267,3 +278,4336 @@ func TestDeliveriesReplay(t *testing.T) {
 		t.Errorf("expected del_1, got %s", result.DeliveryID)
 	}
 }
+
+func TestTransformsTestDecodeOutput(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   *ContentFormat
+		output   interface{}
+		wantJSON bool
+		wantText string
+	}{
+		{name: "json", format: Ptr(ContentJSON), output: map[string]interface{}{"id": 9007199254740993}, wantJSON: true},
+		{name: "default", format: nil, output: map[string]interface{}{"id": 9007199254740993}, wantJSON: true},
+		{name: "xml", format: Ptr(ContentXML), output: "<order><id>1</id></order>", wantText: "<order><id>1</id></order>"},
+		{name: "form", format: Ptr(ContentForm), output: "id=1&name=test", wantText: "id=1&name=test"},
+		{name: "text", format: Ptr(ContentText), output: "order 1 created", wantText: "order 1 created"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/transforms/test" {
+					t.Errorf("expected /api/transforms/test, got %s", r.URL.Path)
+				}
+				// Large integers must survive without float64 rounding.
+				w.Write([]byte(`{"success":true,"output":`))
+				if tt.wantJSON {
+					w.Write([]byte(`{"id":9007199254740993}`))
+				} else {
+					json.NewEncoder(w).Encode(tt.output)
+				}
+				w.Write([]byte(`}`))
+			}))
+			defer server.Close()
+
+			client := New("test_key", WithBaseURL(server.URL))
+			result, err := client.Transforms.Test(context.Background(), &TransformTestParams{
+				TransformType: TransformJSONata,
+				Code:          "$",
+				OutputFormat:  tt.format,
+				Payload:       map[string]interface{}{"id": 1},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			type order struct {
+				ID int64 `json:"id"`
+			}
+			decoded, err := DecodeTransformOutput[order](result)
+			if tt.wantJSON {
+				if err != nil {
+					t.Fatalf("unexpected decode error: %v", err)
+				}
+				if decoded.ID != 9007199254740993 {
+					t.Errorf("expected lossless id, got %d", decoded.ID)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected decode error for non-JSON output")
+			}
+			text, err := result.OutputText()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if text != tt.wantText {
+				t.Errorf("expected %q, got %q", tt.wantText, text)
+			}
+		})
+	}
+}
+
+func TestEndpointsClone(t *testing.T) {
+	var created map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/webhook-endpoints/ep_1":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{
+					"id": "ep_1", "applicationId": "app_1", "url": "https://a.com",
+					"filterTypes": []string{"order.created"}, "rateLimit": 10,
+					"headers":  []map[string]string{{"name": "X-Team", "value": "billing"}},
+					"metadata": map[string]interface{}{"tier": "gold"},
+				},
+			})
+		case r.Method == "POST" && r.URL.Path == "/api/webhook-endpoints":
+			json.NewDecoder(r.Body).Decode(&created)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{"id": "ep_2", "applicationId": "app_1", "url": "https://b.com", "isDisabled": true},
+			})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	ep, err := client.Endpoints.Clone(context.Background(), "app_1", "ep_1", &CloneEndpointParams{URL: "https://b.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ep.ID != "ep_2" || !ep.IsDisabled.Bool() {
+		t.Errorf("expected disabled ep_2, got %s (disabled=%v)", ep.ID, ep.IsDisabled)
+	}
+	if created["isDisabled"] != true {
+		t.Errorf("expected clone to be created disabled, got %v", created)
+	}
+	if created["url"] != "https://b.com" || created["rateLimit"] != float64(10) {
+		t.Errorf("unexpected create body: %v", created)
+	}
+	if headers, _ := created["headers"].(map[string]interface{}); headers["X-Team"] != "billing" {
+		t.Errorf("expected headers to be copied, got %v", created["headers"])
+	}
+
+	_, err = client.Endpoints.Clone(context.Background(), "app_1", "ep_1", &CloneEndpointParams{})
+	if !IsErrorCode(err, CodeClientValidation) {
+		t.Errorf("expected client validation error for missing URL, got %v", err)
+	}
+}
+
+func TestTransformsListUsage(t *testing.T) {
+	var pages []string
+	deleted := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			deleted = true
+			w.WriteHeader(204)
+			return
+		}
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		pageNum, _ := strconv.Atoi(page)
+		routes := []map[string]interface{}{
+			{"id": "rte_" + page + "a", "name": "A", "sourceId": "src_1", "transformId": "tfm_1", "isActive": 1},
+			{"id": "rte_" + page + "b", "name": "B", "sourceId": "src_1", "transformId": "tfm_2", "isActive": 1},
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"routes":     routes,
+			"pagination": map[string]interface{}{"total": 6, "page": pageNum, "pageSize": 2},
+		})
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	refs, err := client.Transforms.ListUsage(context.Background(), "tfm_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pages) != 3 || pages[2] != "3" {
+		t.Fatalf("expected 3 pages to be walked, got %v", pages)
+	}
+	if len(refs) != 3 || refs[2].ID != "rte_3a" || !refs[0].IsActive {
+		t.Errorf("unexpected refs: %+v", refs)
+	}
+
+	err = client.Transforms.DeleteIfUnused(context.Background(), "tfm_1")
+	var inUse *InUseError
+	if !errors.As(err, &inUse) || len(inUse.Routes) != 3 {
+		t.Fatalf("expected InUseError with 3 routes, got %v", err)
+	}
+	if deleted {
+		t.Error("expected transform in use not to be deleted")
+	}
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	store := map[string][]interface{}{
+		"filters":    {map[string]interface{}{"name": "Only orders", "slug": "only-orders"}},
+		"transforms": {map[string]interface{}{"name": "Flatten", "slug": "flatten"}},
+		"schemas":    {map[string]interface{}{"name": "Order", "slug": "order"}},
+	}
+	exportOnly := map[string][]interface{}{
+		"sources":      {map[string]interface{}{"name": "Stripe", "slug": "stripe"}},
+		"destinations": {map[string]interface{}{"name": "API", "slug": "api"}},
+		"routes":       {},
+	}
+	imported := map[string]map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/"), "/")
+		resource, action := parts[0], parts[1]
+		switch action {
+		case "export":
+			items, ok := store[resource]
+			if !ok {
+				items, ok = exportOnly[resource]
+			}
+			if !ok {
+				json.NewEncoder(w).Encode(map[string]interface{}{"version": "1", "data": []interface{}{}})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"version": "1", resource: items})
+		case "import":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			imported[resource] = body
+			json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "imported": 1})
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := New("test_key", WithBaseURL(server.URL))
+
+	filters, err := client.Filters.Export(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Filters.Import(ctx, &ImportFiltersParams{Filters: filters.Filters, ConflictStrategy: Ptr("skip")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	transforms, err := client.Transforms.Export(ctx, []string{"tfm_1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Transforms.Import(ctx, &ImportTransformsParams{Transforms: transforms.Transforms}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	schemas, err := client.Schemas.Export(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result, err := client.Schemas.Import(ctx, &ImportSchemasParams{Schemas: schemas.Schemas, ValidateOnly: Ptr(true)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Imported != 1 {
+		t.Errorf("expected 1 imported, got %d", result.Imported)
+	}
+
+	for resource, items := range store {
+		got, _ := imported[resource][resource].([]interface{})
+		if len(got) != 1 || got[0].(map[string]interface{})["slug"] != items[0].(map[string]interface{})["slug"] {
+			t.Errorf("%s did not round-trip: %v", resource, imported[resource])
+		}
+	}
+	if imported["filters"]["conflictStrategy"] != "skip" {
+		t.Errorf("expected conflictStrategy skip, got %v", imported["filters"]["conflictStrategy"])
+	}
+	if imported["schemas"]["validateOnly"] != true {
+		t.Errorf("expected validateOnly true, got %v", imported["schemas"]["validateOnly"])
+	}
+
+	all, err := client.ExportAll(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(all.Filters) != 1 || len(all.Transforms) != 1 || len(all.Schemas) != 1 {
+		t.Errorf("unexpected bundle: %+v", all)
+	}
+	if len(all.Sources) != 1 || all.Sources[0]["slug"] != "stripe" || len(all.Destinations) != 1 || all.Routes == nil || len(all.Routes) != 0 {
+		t.Errorf("unexpected sources, destinations, or routes: %+v", all)
+	}
+
+	// A response without the expected list fails instead of exporting nothing.
+	delete(exportOnly, "destinations")
+	if _, err := client.ExportAll(ctx); err == nil || !strings.Contains(err.Error(), `"destinations"`) {
+		t.Errorf("expected an error for the missing destinations list, got %v", err)
+	}
+}
+
+func TestMessagesSendBatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/send-events-batch" {
+			t.Errorf("expected /api/send-events-batch, got %s", r.URL.Path)
+		}
+		var body struct {
+			ApplicationID string                   `json:"applicationId"`
+			Events        []map[string]interface{} `json:"events"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.ApplicationID != "app_1" || len(body.Events) != 2 {
+			t.Errorf("unexpected body: %+v", body)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"results": []map[string]interface{}{
+					{"eventId": "evt_1", "messagesQueued": 1, "endpoints": []map[string]interface{}{{"id": "ep_1", "url": "https://a.com"}}},
+					{"error": "unknown event type"},
+				},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	results, err := client.Messages.SendBatch(context.Background(), "app_1", []SendMessageParams{
+		{EventType: "order.created", Payload: map[string]interface{}{"id": 1}},
+		{EventType: "inventory.updated", Payload: map[string]interface{}{"sku": "a"}},
+	})
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected BatchError, got %v", err)
+	}
+	if batchErr.Failed != 1 || batchErr.Errors[0] != nil || batchErr.Errors[1] == nil {
+		t.Errorf("unexpected batch errors: %+v", batchErr.Errors)
+	}
+	if results[0] == nil || results[0].MessageID != "evt_1" || results[1] != nil {
+		t.Errorf("unexpected results: %+v", results)
+	}
+
+	if _, err := client.Messages.SendBatch(context.Background(), "app_1", make([]SendMessageParams, MaxMessageBatchSize+1)); err == nil {
+		t.Error("expected error for oversized batch")
+	}
+}
+
+func TestMessagesSchedule(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["deliverAt"] != "2030-01-02T03:04:05Z" {
+			t.Errorf("expected RFC3339 deliverAt, got %v", body["deliverAt"])
+		}
+		if body["eventType"] != "invoice.due" {
+			t.Errorf("expected eventType invoice.due, got %v", body["eventType"])
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"eventId": "evt_1", "scheduledFor": "2030-01-02T03:04:05Z", "status": "scheduled"},
+		})
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	deliverAt := time.Date(2030, 1, 2, 4, 4, 5, 0, time.FixedZone("CET", 3600))
+	result, err := client.Messages.Schedule(context.Background(), "app_1", &ScheduleMessageParams{
+		SendMessageParams: SendMessageParams{EventType: "invoice.due", Payload: map[string]interface{}{"id": 1}},
+		DeliverAt:         deliverAt,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.MessageID != "evt_1" || result.Status != "scheduled" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	for _, params := range []*ScheduleMessageParams{nil, {SendMessageParams: SendMessageParams{EventType: "invoice.due"}}} {
+		if _, err := client.Messages.Schedule(context.Background(), "app_1", params); !IsErrorCode(err, CodeClientValidation) {
+			t.Errorf("expected client validation error for %+v, got %v", params, err)
+		}
+	}
+}
+
+func TestMessagesGetByEventID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Query().Get("applicationId") != "app_1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		switch r.URL.EscapedPath() {
+		case "/api/outbound-messages/by-event-id/order%2F42":
+			w.Write([]byte(`{"data":{"id":"omsg_1","messageId":"evt_1","endpointId":"ep_1","eventType":"order.created","status":"success","attempts":1}}`))
+		case "/api/outbound-messages/by-event-id/missing":
+			w.WriteHeader(404)
+			w.Write([]byte(`{"error":{"message":"Message not found","code":"not_found"}}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.EscapedPath())
+		}
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	msg, err := client.Messages.GetByEventID(context.Background(), "app_1", "order/42")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.ID != "omsg_1" || msg.Status != MessageSuccess || msg.Attempts != 1 {
+		t.Errorf("unexpected message: %+v", msg)
+	}
+	if _, err := client.Messages.GetByEventID(context.Background(), "app_1", "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestMessagesBulkRetry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/outbound-messages/bulk-retry" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"applicationId":"app_1","messageIds":["omsg_1","omsg_2"]}` {
+			t.Errorf("unexpected body: %s", body)
+		}
+		w.Write([]byte(`{"data":{"total":2,"retried":1,"failed":1,"results":[
+			{"messageId":"omsg_1","status":"retried","newMessageId":"omsg_3"},
+			{"messageId":"omsg_2","status":"failed","error":"endpoint disabled"}]}}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	result, err := client.Messages.BulkRetry(context.Background(), "app_1", []string{"omsg_1", "omsg_2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Retried != 1 || result.Failed != 1 || *result.Results[0].NewMessageID != "omsg_3" || *result.Results[1].Error != "endpoint disabled" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	for _, ids := range [][]string{nil, make([]string, MaxBulkMessageIDs+1)} {
+		if _, err := client.Messages.BulkRetry(context.Background(), "app_1", ids); !IsErrorCode(err, CodeClientValidation) {
+			t.Errorf("expected client validation error for %d IDs, got %v", len(ids), err)
+		}
+	}
+}
+
+func TestMessagesCancelScheduled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Query().Get("applicationId") != "app_1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		switch r.URL.Path {
+		case "/api/scheduled-messages/evt_1":
+			w.WriteHeader(204)
+		case "/api/scheduled-messages/evt_sent":
+			w.WriteHeader(404)
+			w.Write([]byte(`{"error":{"message":"Scheduled message not found","code":"not_found"}}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	if err := client.Messages.CancelScheduled(context.Background(), "app_1", "evt_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var notFound *NotFoundError
+	if err := client.Messages.CancelScheduled(context.Background(), "app_1", "evt_sent"); !errors.As(err, &notFound) {
+		t.Errorf("expected NotFoundError, got %v", err)
+	}
+}
+
+func TestAPIKeysRotateKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/api-keys/key_1/rotate" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Idempotency-Key") != "rotate-1" {
+			t.Errorf("expected request options to be applied, got headers %v", r.Header)
+		}
+		w.Write([]byte(`{"data":{"id":"key_1","name":"CI","keyPrefix":"hb_live_9f","scopes":["sources:read"],"key":"hb_live_9f2c1e0d"}}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	key, err := client.APIKeys.RotateKey(context.Background(), "key_1", WithIdempotencyKey("rotate-1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if key.ID != "key_1" || key.Name != "CI" || key.Key != "hb_live_9f2c1e0d" || !reflect.DeepEqual(key.Scopes, []string{ScopeSourcesRead}) {
+		t.Errorf("unexpected rotated key: %+v", key)
+	}
+}
+
+func TestAPIKeysRotateAndScopes(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method != "POST" || r.URL.Path != "/api/api-keys/key_1/rotate" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"id": "key_1", "name": "CI", "keyPrefix": "hb_new", "scopes": []string{ScopeSourcesRead},
+				"key": "hb_new_secret", "previousKeyExpiresAt": "2024-01-02T00:00:00Z",
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	key, err := client.APIKeys.Rotate(context.Background(), "key_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if key.Key != "hb_new_secret" || key.ID != "key_1" || key.PreviousKeyExpiresAt == nil {
+		t.Errorf("unexpected rotated key: %+v", key)
+	}
+
+	_, err = client.APIKeys.Create(context.Background(), &CreateAPIKeyParams{
+		Name:   "Bad",
+		Scopes: []string{ScopeSourcesRead, "sources:admin"},
+	})
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	if !strings.Contains(verr.ValidationErrors["scopes"][0], "sources:admin") {
+		t.Errorf("expected unknown scope in error, got %v", verr.ValidationErrors)
+	}
+	if requests != 1 {
+		t.Errorf("expected invalid scopes to be rejected without a request, got %d requests", requests)
+	}
+	if err := ValidateScopes([]string{ScopeMessagesSend, ScopeDLQWrite}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestAPIKeysNilParams(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/api/api-keys/key_1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.Write([]byte(`{"data":{"id":"key_1","name":"CI"}}`))
+	}))
+	defer server.Close()
+
+	for _, validate := range []bool{true, false} {
+		client := New("test_key", WithBaseURL(server.URL), WithClientValidation(validate))
+		if _, err := client.APIKeys.Create(context.Background(), nil); !IsErrorCode(err, CodeClientValidation) {
+			t.Errorf("expected client validation error for nil create params, got %v", err)
+		}
+		key, err := client.APIKeys.Update(context.Background(), "key_1", nil)
+		if err != nil || key.ID != "key_1" {
+			t.Errorf("unexpected update result %+v, %v", key, err)
+		}
+	}
+	if want := []string{"{}", "{}"}; !reflect.DeepEqual(bodies, want) {
+		t.Errorf("expected empty update bodies and no create requests, got %q", bodies)
+	}
+}
+
+func TestEventTypesBulkCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/event-types/bulk" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			EventTypes []CreateEventTypeParams `json:"eventTypes"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body.EventTypes) != 2 {
+			t.Errorf("expected 2 event types, got %d", len(body.EventTypes))
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"created": []map[string]interface{}{{"id": "et_1", "name": "order.created", "isEnabled": true}},
+				"errors":  []map[string]interface{}{{"index": 1, "name": "bad name", "message": "invalid name"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	result, err := client.EventTypes.BulkCreate(context.Background(), []CreateEventTypeParams{
+		{Name: "order.created"},
+		{Name: "bad name"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Created) != 1 || result.Created[0].ID != "et_1" {
+		t.Errorf("unexpected created: %+v", result.Created)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Index != 1 {
+		t.Errorf("unexpected errors: %+v", result.Errors)
+	}
+}
+
+func TestEventTypesClone(t *testing.T) {
+	schema := map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"id"},
+		"properties": map[string]interface{}{
+			"id":    map[string]interface{}{"type": "string"},
+			"total": map[string]interface{}{"type": "number", "minimum": float64(0)},
+		},
+	}
+	var created map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/event-types/et_1":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{
+					"id": "et_1", "name": "order.created", "displayName": "Order Created",
+					"category": "orders", "schema": schema, "isEnabled": true,
+				},
+			})
+		case r.Method == "POST" && r.URL.Path == "/api/event-types":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["isEnabled"] != false {
+				t.Errorf("expected the clone to be created disabled, got isEnabled=%v", body["isEnabled"])
+			}
+			created = body
+			created["id"] = "et_2"
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": created})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	src, err := client.EventTypes.Get(context.Background(), "et_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	clone, err := client.EventTypes.Clone(context.Background(), "et_1", "order.created.v2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if clone.Name != "order.created.v2" || clone.IsEnabled {
+		t.Errorf("unexpected clone: %+v", clone)
+	}
+	if clone.DisplayName == nil || *clone.DisplayName != "Order Created" || clone.Category == nil || *clone.Category != "orders" {
+		t.Errorf("expected display name and category to be copied, got %+v", clone)
+	}
+	if !reflect.DeepEqual(src.Schema, clone.Schema) {
+		t.Errorf("schema mismatch:\nsource: %v\nclone:  %v", src.Schema, clone.Schema)
+	}
+	if !reflect.DeepEqual(created["schema"], schema) {
+		t.Errorf("expected the create request to carry the source schema, got %v", created["schema"])
+	}
+	wantJSON, _ := json.Marshal(schema)
+	gotJSON, _ := json.Marshal(clone.Schema)
+	if !bytes.Equal(wantJSON, gotJSON) {
+		t.Errorf("schema JSON mismatch:\nwant: %s\ngot:  %s", wantJSON, gotJSON)
+	}
+}
+
+func TestAPIKeysUsageAndAudit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.URL.Path {
+		case "/api/api-keys/key_1/usage":
+			if q.Get("startDate") != "2024-01-01" || q.Get("endDate") != "2024-01-31" {
+				t.Errorf("unexpected query: %s", r.URL.RawQuery)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{
+					"keyId": "key_1", "totalRequests": 42, "lastUsedIp": "10.0.0.1",
+					"daily":        []map[string]interface{}{{"date": "2024-01-01", "requests": 40, "errors": 2}},
+					"topEndpoints": []map[string]interface{}{{"method": "GET", "path": "/api/sources", "requests": 30}},
+				},
+			})
+		case "/api/api-keys/key_1/audit-events":
+			if q.Get("cursor") != "c1" || q.Get("limit") != "10" {
+				t.Errorf("unexpected query: %s", r.URL.RawQuery)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data":       []map[string]interface{}{{"id": "aud_1", "method": "DELETE", "path": "/api/sources/src_1", "statusCode": 204, "createdAt": "2024-01-01"}},
+				"pagination": map[string]interface{}{"hasMore": true, "nextCursor": "c2"},
+			})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := New("test_key", WithBaseURL(server.URL))
+	usage, err := client.APIKeys.GetUsage(ctx, "key_1", &APIKeyUsageParams{StartDate: Ptr("2024-01-01"), EndDate: Ptr("2024-01-31")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if usage.TotalRequests != 42 || *usage.LastUsedIP != "10.0.0.1" || usage.Daily[0].Errors != 2 || usage.TopEndpoints[0].Path != "/api/sources" {
+		t.Errorf("unexpected usage: %+v", usage)
+	}
+
+	page, err := client.APIKeys.ListAuditEvents(ctx, "key_1", &ListAPIKeyAuditEventsParams{Limit: Ptr(10), Cursor: Ptr("c1")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Data) != 1 || page.Data[0].StatusCode != 204 || !page.HasMore || *page.NextCursor != "c2" {
+		t.Errorf("unexpected page: %+v", page)
+	}
+}
+
+func TestEventTypesGetStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/api/event-types/et_1/stats" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"data":{"subscriptionCount":4,"messagesLast24h":120,"messagesLast7d":910,"messagesTotal":15230}}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	stats, err := client.EventTypes.GetStats(context.Background(), "et_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := EventTypeStats{SubscriptionCount: 4, MessagesLast24h: 120, MessagesLast7d: 910, MessagesTotal: 15230}
+	if *stats != want {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestEventTypesExportImport(t *testing.T) {
+	existing := map[string]bool{"order.created": true}
+	var imported map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/event-types/export":
+			if ids := r.URL.Query().Get("ids"); ids != "et_1,et_2" {
+				t.Errorf("unexpected ids: %q", ids)
+			}
+			w.Write([]byte(`{"version":"1","eventTypes":[
+				{"name":"order.created","category":"orders"},
+				{"name":"order.refunded","category":"orders"}]}`))
+		case r.Method == "POST" && r.URL.Path == "/api/event-types/import":
+			imported = nil
+			json.NewDecoder(r.Body).Decode(&imported)
+			items, _ := imported["eventTypes"].([]interface{})
+			result := map[string]interface{}{"success": true}
+			var results []map[string]interface{}
+			counts := map[string]int{}
+			for _, item := range items {
+				name := item.(map[string]interface{})["name"].(string)
+				switch {
+				case !existing[name]:
+					results = append(results, map[string]interface{}{"name": name, "status": "imported"})
+					counts["imported"]++
+				case imported["conflictStrategy"] == "skip":
+					results = append(results, map[string]interface{}{"name": name, "status": "skipped"})
+					counts["skipped"]++
+				default:
+					w.WriteHeader(409)
+					w.Write([]byte(`{"error":{"message":"Event type order.created already exists","code":"conflict"}}`))
+					return
+				}
+			}
+			result["imported"], result["skipped"], result["results"] = counts["imported"], counts["skipped"], results
+			json.NewEncoder(w).Encode(result)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := New("test_key", WithBaseURL(server.URL))
+	exported, err := client.EventTypes.Export(ctx, []string{"et_1", "et_2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var eventTypes []map[string]interface{}
+	for _, item := range exported.(map[string]interface{})["eventTypes"].([]interface{}) {
+		eventTypes = append(eventTypes, item.(map[string]interface{}))
+	}
+
+	result, err := client.EventTypes.Import(ctx, &ImportEventTypesParams{EventTypes: eventTypes, ConflictStrategy: Ptr("skip")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Imported != 1 || result.Skipped != 1 || result.Results[0].Status != "skipped" || result.Results[1].Name != "order.refunded" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if got, _ := imported["eventTypes"].([]interface{}); len(got) != 2 || got[1].(map[string]interface{})["category"] != "orders" {
+		t.Errorf("event types did not round-trip: %v", imported)
+	}
+
+	_, err = client.EventTypes.Import(ctx, &ImportEventTypesParams{EventTypes: eventTypes})
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) {
+		t.Errorf("expected ConflictError without a conflict strategy, got %v", err)
+	}
+}
+
+func TestEventTypesBulkDelete(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["force"] != true {
+			w.WriteHeader(422)
+			json.NewEncoder(w).Encode(map[string]interface{}{"error": map[string]interface{}{"message": "event types have active subscriptions", "code": "validation_error"}})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "deleted": 2})
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	ids := []string{"et_1", "et_2"}
+	_, err := client.EventTypes.BulkDelete(context.Background(), ids)
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	result, err := client.EventTypes.BulkDelete(context.Background(), ids, WithForce())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Deleted != 2 {
+		t.Errorf("expected 2 deleted, got %d", result.Deleted)
+	}
+}
+
+func TestCronPauseResumeAll(t *testing.T) {
+	active := map[string]bool{"cron_1": true, "cron_2": false, "cron_3": true}
+	var updates []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/cron":
+			var jobs []map[string]interface{}
+			for _, id := range []string{"cron_1", "cron_2", "cron_3"} {
+				jobs = append(jobs, map[string]interface{}{"id": id, "isActive": active[id]})
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"cronJobs": jobs})
+		case r.Method == "PATCH" && strings.HasPrefix(r.URL.Path, "/api/cron/"):
+			id := strings.TrimPrefix(r.URL.Path, "/api/cron/")
+			var body UpdateCronParams
+			json.NewDecoder(r.Body).Decode(&body)
+			if body.IsActive == nil {
+				t.Fatalf("expected isActive in update body")
+			}
+			active[id] = *body.IsActive
+			updates = append(updates, id)
+			json.NewEncoder(w).Encode(map[string]interface{}{"cronJob": map[string]interface{}{"id": id, "isActive": active[id]}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	snapshot, err := client.Cron.PauseAll(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(snapshot.PausedIDs, ",") != "cron_1,cron_3" {
+		t.Errorf("unexpected paused IDs: %v", snapshot.PausedIDs)
+	}
+	for id, on := range active {
+		if on {
+			t.Errorf("expected %s to be paused", id)
+		}
+	}
+
+	updates = nil
+	if err := client.Cron.ResumeAll(context.Background(), snapshot); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(updates, ",") != "cron_1,cron_3" {
+		t.Errorf("expected only previously active jobs to be resumed, got %v", updates)
+	}
+	if !active["cron_1"] || !active["cron_3"] {
+		t.Errorf("expected cron_1 and cron_3 to be active, got %v", active)
+	}
+	if active["cron_2"] {
+		t.Error("expected previously inactive cron_2 to stay inactive")
+	}
+}
+
+func TestCronBulkUpdatePartialFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/api/cron/")
+		if id == "cron_missing" {
+			w.WriteHeader(404)
+			w.Write([]byte(`{"error":"Cron job not found"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"cronJob": map[string]interface{}{"id": id, "isActive": false}})
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	result, err := client.Cron.BulkUpdate(context.Background(), []string{"cron_1", "cron_missing", "cron_3"}, false)
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || batchErr.Failed != 1 {
+		t.Fatalf("expected BatchError with 1 failure, got %v", err)
+	}
+	var notFound *NotFoundError
+	if !errors.As(batchErr.Errors[1], &notFound) {
+		t.Errorf("expected NotFoundError for second item, got %v", batchErr.Errors[1])
+	}
+	if !reflect.DeepEqual(batchErr.FailedIDs, []string{"cron_missing"}) || !strings.Contains(err.Error(), "cron_missing") {
+		t.Errorf("expected the failed ID to be listed, got %v (%v)", batchErr.FailedIDs, err)
+	}
+	if result == nil || result.Success || result.Updated != 2 {
+		t.Errorf("expected the jobs after the failure to be updated too, got %+v", result)
+	}
+
+	result, err = client.Cron.BulkUpdate(context.Background(), []string{"cron_1"}, true)
+	if err != nil || !result.Success || result.Updated != 1 {
+		t.Errorf("unexpected result %+v, %v", result, err)
+	}
+}
+
+func TestCronGroups(t *testing.T) {
+	var patches []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "PATCH" && r.URL.Path == "/api/cron-groups/grp_1":
+			var body UpdateCronGroupParams
+			json.NewDecoder(r.Body).Decode(&body)
+			if body.Name == nil || *body.Name != "Nightly" {
+				t.Errorf("unexpected update body: %+v", body)
+			}
+			w.Write([]byte(`{"group":{"id":"grp_1","name":"Nightly","sortOrder":2}}`))
+		case r.Method == "DELETE" && r.URL.Path == "/api/cron-groups/grp_1":
+			if r.URL.Query().Get("reassignTo") == "" {
+				w.WriteHeader(409)
+				w.Write([]byte(`{"error":{"message":"Group has 3 cron jobs","code":"group_not_empty"}}`))
+				return
+			}
+			if r.URL.Query().Get("reassignTo") != "grp_2" {
+				t.Errorf("unexpected reassignTo: %s", r.URL.RawQuery)
+			}
+			w.WriteHeader(204)
+		case r.Method == "POST" && r.URL.Path == "/api/cron-groups/reorder":
+			var body struct {
+				GroupIDs []string `json:"groupIds"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			if strings.Join(body.GroupIDs, ",") != "grp_2,grp_1" {
+				t.Errorf("unexpected order: %v", body.GroupIDs)
+			}
+			w.Write([]byte(`{"groups":[{"id":"grp_2","sortOrder":0},{"id":"grp_1","sortOrder":1}]}`))
+		case r.Method == "GET" && r.URL.Path == "/api/cron":
+			if r.URL.Query().Get("groupId") != "grp_1" {
+				t.Errorf("unexpected query: %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"cronJobs":[{"id":"cron_1","groupId":"grp_1"}]}`))
+		case r.Method == "POST" && r.URL.Path == "/api/cron":
+			var body CreateCronParams
+			json.NewDecoder(r.Body).Decode(&body)
+			if body.GroupID == nil || *body.GroupID != "grp_1" {
+				t.Errorf("expected groupId in create body, got %+v", body)
+			}
+			w.Write([]byte(`{"cronJob":{"id":"cron_2","groupId":"grp_1"}}`))
+		case r.Method == "PATCH" && r.URL.Path == "/api/cron/cron_2":
+			body, _ := io.ReadAll(r.Body)
+			patches = append(patches, string(body))
+			w.Write([]byte(`{"cronJob":{"id":"cron_2","groupId":null}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	group, err := client.Cron.UpdateGroup(ctx, "grp_1", &UpdateCronGroupParams{Name: Ptr("Nightly")})
+	if err != nil || group.Name != "Nightly" {
+		t.Fatalf("UpdateGroup: %+v, %v", group, err)
+	}
+
+	err = client.Cron.DeleteGroup(ctx, "grp_1", nil)
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) || conflict.Code != "group_not_empty" {
+		t.Fatalf("expected ConflictError, got %v", err)
+	}
+	if err := client.Cron.DeleteGroup(ctx, "grp_1", Ptr("grp_2")); err != nil {
+		t.Fatalf("DeleteGroup with reassign: %v", err)
+	}
+
+	groups, err := client.Cron.ReorderGroups(ctx, []string{"grp_2", "grp_1"})
+	if err != nil || len(groups) != 2 || groups[0].ID != "grp_2" {
+		t.Fatalf("ReorderGroups: %+v, %v", groups, err)
+	}
+
+	jobs, err := client.Cron.ListByGroup(ctx, "grp_1")
+	if err != nil || len(jobs) != 1 || jobs[0].GroupID == nil || *jobs[0].GroupID != "grp_1" {
+		t.Fatalf("ListByGroup: %+v, %v", jobs, err)
+	}
+
+	job, err := client.Cron.Create(ctx, &CreateCronParams{Name: "Job", Schedule: "@daily", URL: "https://example.com", GroupID: Ptr("grp_1")})
+	if err != nil || job.GroupID == nil || *job.GroupID != "grp_1" {
+		t.Fatalf("Create: %+v, %v", job, err)
+	}
+
+	for _, params := range []*UpdateCronParams{
+		{GroupID: NullableValue("grp_2")},
+		{GroupID: NullValue[string]()},
+		{Name: Ptr("Renamed")},
+	} {
+		if _, err := client.Cron.Update(ctx, "cron_2", params); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+	}
+	want := []string{`{"groupId":"grp_2"}`, `{"groupId":null}`, `{"name":"Renamed"}`}
+	if !reflect.DeepEqual(patches, want) {
+		t.Errorf("unexpected update bodies: %q", patches)
+	}
+}
+
+func TestSubscriptionsBulkCreate(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/webhook-subscriptions/bulk" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		calls = append(calls, string(body))
+		w.Write([]byte(`{"created":2,"skipped":1,"subscriptions":[
+			{"id":"sub_1","endpointId":"ep_1","eventTypeId":"et_1"},
+			{"id":"sub_2","endpointId":"ep_2","eventTypeId":"et_1"}]}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	result, err := client.Subscriptions.BulkCreate(context.Background(), "app_1", []CreateSubscriptionParams{
+		{EndpointID: "ep_1", EventTypeID: "et_1"},
+		{EndpointID: "ep_2", EventTypeID: "et_1"},
+		{EndpointID: "ep_1", EventTypeID: "et_2"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{`{"applicationId":"app_1","subscriptions":[{"endpointId":"ep_1","eventTypeId":"et_1"},{"endpointId":"ep_2","eventTypeId":"et_1"},{"endpointId":"ep_1","eventTypeId":"et_2"}]}`}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("expected one application-scoped request, got %q", calls)
+	}
+	if result.Created != 2 || result.Skipped != 1 || len(result.Subscriptions) != 2 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	for _, appID := range []string{"", "app_1"} {
+		if _, err := client.Subscriptions.BulkCreate(context.Background(), appID, nil); !IsErrorCode(err, CodeClientValidation) {
+			t.Errorf("expected client validation error, got %v", err)
+		}
+	}
+	if len(calls) != 1 {
+		t.Errorf("expected invalid calls not to reach the API, got %d requests", len(calls))
+	}
+}
+
+func TestCronTrigger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/cron/cron_1/trigger" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"execution":{"executionId":"exec_1","status":"success","statusCode":200,"durationMs":153,"success":true,"responseExcerpt":"ok"}}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	result, err := client.Cron.Trigger(context.Background(), "cron_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.ExecutionID != "exec_1" || !result.Success || !result.IsTerminal() {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if result.StatusCode == nil || *result.StatusCode != 200 || result.DurationMs == nil || *result.DurationMs != 153 {
+		t.Errorf("unexpected status code or duration: %+v", result)
+	}
+}
+
+func TestCronTriggerAndWait(t *testing.T) {
+	statuses := []string{"running", "running", "failed"}
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/cron/cron_1/trigger":
+			w.WriteHeader(202)
+			w.Write([]byte(`{"execution":{"executionId":"exec_1","status":"pending"}}`))
+		case r.Method == "GET" && r.URL.Path == "/api/cron/cron_1/executions/exec_1":
+			status := statuses[polls]
+			polls++
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"execution": map[string]interface{}{"executionId": "exec_1", "status": status, "success": false, "statusCode": 500},
+			})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	result, err := client.Cron.TriggerAndWait(context.Background(), "cron_1", &TriggerAndWaitParams{PollInterval: time.Millisecond, Timeout: time.Second})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if polls != 3 || result.Status != "failed" || result.Success {
+		t.Errorf("unexpected result after %d polls: %+v", polls, result)
+	}
+
+	statuses = []string{"running", "running", "running", "running", "running", "running", "running", "running", "running", "running"}
+	polls = 0
+	_, err = client.Cron.TriggerAndWait(context.Background(), "cron_1", &TriggerAndWaitParams{PollInterval: 20 * time.Millisecond, Timeout: 50 * time.Millisecond})
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("expected TimeoutError, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	polls = 0
+	if _, err := client.Cron.TriggerAndWait(ctx, "cron_1", nil); err == nil {
+		t.Fatal("expected error for cancelled context")
+	}
+}
+
+func TestSubscriptionsBulkDelete(t *testing.T) {
+	owned := map[string]bool{"sub_1": true, "sub_2": true}
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Path != "/api/webhook-subscriptions/bulk" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			ApplicationID string   `json:"applicationId"`
+			IDs           []string `json:"ids"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.ApplicationID != "app_1" {
+			t.Errorf("expected the delete to be scoped to app_1, got %+v", body)
+		}
+		n := 0
+		for _, id := range body.IDs {
+			if owned[id] {
+				deleted = append(deleted, id)
+				n++
+			}
+		}
+		fmt.Fprintf(w, `{"success":true,"deleted":%d}`, n)
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	result, err := client.Subscriptions.BulkDelete(context.Background(), "app_1", []string{"sub_1", "sub_2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Success || result.Deleted != 2 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	var validationErr *ValidationError
+	if _, err := client.Subscriptions.BulkDelete(context.Background(), "", []string{"sub_1"}); !errors.As(err, &validationErr) {
+		t.Errorf("expected ValidationError for missing application ID, got %v", err)
+	}
+
+	deleted = nil
+	result, err = client.Subscriptions.BulkDelete(context.Background(), "app_1", []string{"sub_1", "sub_other"})
+	if !errors.As(err, &validationErr) || !strings.Contains(validationErr.FirstError(), "1 of 2") {
+		t.Errorf("expected ValidationError reporting one foreign subscription, got %v", err)
+	}
+	if result == nil || result.Deleted != 1 || !reflect.DeepEqual(deleted, []string{"sub_1"}) {
+		t.Errorf("expected only sub_1 to be deleted, got %+v and %v", result, deleted)
+	}
+}
+
+func TestSubscriptionsGetByEventType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/api/webhook-subscriptions" || q.Get("applicationId") != "app_1" || q.Get("eventTypeId") != "et_1" {
+			t.Errorf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		offset, _ := strconv.Atoi(q.Get("offset"))
+		hasMore := offset == 0
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data":       []map[string]interface{}{{"id": "sub_" + strconv.Itoa(offset), "endpointId": "ep_" + strconv.Itoa(offset), "eventTypeId": "et_1"}},
+			"pagination": map[string]interface{}{"hasMore": hasMore},
+		})
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	subs, err := client.Subscriptions.GetByEventType(context.Background(), "app_1", "et_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(subs) != 2 || subs[0].ID != "sub_0" || subs[1].ID != "sub_1" {
+		t.Errorf("unexpected subscriptions: %+v", subs)
+	}
+}
+
+func TestDLQRetryAll(t *testing.T) {
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/outbound-messages/dlq/retry-all" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		w.Write([]byte(`{"data":{"total":250,"retried":248,"failed":2}}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	result, err := client.DLQ.RetryAll(context.Background(), &ListDLQParams{EndpointID: Ptr("ep_1"), Limit: Ptr(10)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Total != 250 || result.Retried != 248 || result.Failed != 2 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if _, err := client.DLQ.RetryAll(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(bodies[0]) != 1 || bodies[0]["endpointId"] != "ep_1" {
+		t.Errorf("expected only the endpoint filter in body, got %v", bodies[0])
+	}
+	if len(bodies[1]) != 0 {
+		t.Errorf("expected empty filter body, got %v", bodies[1])
+	}
+}
+
+func TestTunnelsUpdateAndRotateToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "PATCH" && r.URL.Path == "/api/tunnels/tun_1":
+			var body UpdateTunnelParams
+			json.NewDecoder(r.Body).Decode(&body)
+			switch {
+			case body.Subdomain == nil:
+				w.Write([]byte(`{"tunnel":{"id":"tun_1","name":"dev","localPort":4000}}`))
+			case *body.Subdomain == "taken":
+				w.WriteHeader(409)
+				w.Write([]byte(`{"error":{"message":"Subdomain is already in use","code":"subdomain_taken"}}`))
+			default:
+				w.WriteHeader(422)
+				w.Write([]byte(`{"error":{"message":"Invalid subdomain","code":"validation_error","validationErrors":{"subdomain":["must be lowercase"]}}}`))
+			}
+		case r.Method == "POST" && r.URL.Path == "/api/tunnels/tun_1/regenerate-token":
+			w.Write([]byte(`{"authToken":"tok_new"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	tunnel, err := client.Tunnels.Update(ctx, "tun_1", &UpdateTunnelParams{LocalPort: Ptr(4000)})
+	if err != nil || tunnel.LocalPort != 4000 {
+		t.Fatalf("Update: %+v, %v", tunnel, err)
+	}
+
+	for _, subdomain := range []string{"taken", "BAD"} {
+		_, err = client.Tunnels.Update(ctx, "tun_1", &UpdateTunnelParams{Subdomain: Ptr(subdomain)})
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("%s: expected ValidationError, got %v", subdomain, err)
+		}
+		if validationErr.Details["subdomain"] != subdomain {
+			t.Errorf("%s: expected subdomain in details, got %v", subdomain, validationErr.Details)
+		}
+		if len(validationErr.ValidationErrors["subdomain"]) == 0 {
+			t.Errorf("%s: expected subdomain validation error, got %v", subdomain, validationErr.ValidationErrors)
+		}
+	}
+
+	token, err := client.Tunnels.RotateToken(ctx, "tun_1")
+	if err != nil || token != "tok_new" {
+		t.Fatalf("RotateToken: %q, %v", token, err)
+	}
+}
+
+func TestDLQDeleteAll(t *testing.T) {
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Path != "/api/outbound-messages/dlq/bulk-all" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		w.Write([]byte(`{"data":{"total":12,"deleted":12}}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	var validationErr *ValidationError
+	if _, err := client.DLQ.DeleteAll(ctx, nil); !errors.As(err, &validationErr) {
+		t.Errorf("expected ValidationError for nil filter, got %v", err)
+	}
+	if _, err := client.DLQ.DeleteAll(ctx, &ListDLQParams{Limit: Ptr(10)}); !errors.As(err, &validationErr) {
+		t.Errorf("expected ValidationError for empty filter, got %v", err)
+	}
+	if len(bodies) != 0 {
+		t.Fatalf("expected no requests, got %d", len(bodies))
+	}
+
+	result, err := client.DLQ.DeleteAll(ctx, &ListDLQParams{EventType: Ptr("order.created")})
+	if err != nil || result.Deleted != 12 {
+		t.Fatalf("DeleteAll with filter: %+v, %v", result, err)
+	}
+	if _, err := client.DLQ.DeleteAll(ctx, &ListDLQParams{Force: Ptr(true)}); err != nil {
+		t.Fatalf("DeleteAll with force: %v", err)
+	}
+	if bodies[0]["eventType"] != "order.created" || bodies[0]["force"] != nil {
+		t.Errorf("unexpected filtered body: %v", bodies[0])
+	}
+	if bodies[1]["force"] != true {
+		t.Errorf("expected force in body, got %v", bodies[1])
+	}
+}
+
+func TestTunnelsRequestLog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/tunnels/tun_1/requests":
+			q := r.URL.Query()
+			if q.Get("cursor") == "" {
+				if q.Get("method") != "POST" || q.Get("limit") != "1" {
+					t.Errorf("unexpected query: %s", r.URL.RawQuery)
+				}
+				w.Write([]byte(`{"data":[{"id":"req_2","method":"POST","path":"/webhooks","headers":{"content-type":"application/json"},"bodyExcerpt":"{\"a\":","responseStatus":200,"durationMs":12,"receivedAt":"2024-01-01T00:00:02Z"}],"pagination":{"hasMore":true,"nextCursor":"c1"}}`))
+				return
+			}
+			if q.Get("cursor") != "c1" {
+				t.Errorf("unexpected cursor: %s", q.Get("cursor"))
+			}
+			w.Write([]byte(`{"data":[{"id":"req_1","method":"POST","path":"/webhooks","receivedAt":"2024-01-01T00:00:01Z"}],"pagination":{"hasMore":false}}`))
+		case r.Method == "GET" && r.URL.Path == "/api/tunnels/tun_1/requests/req_2":
+			w.Write([]byte(`{"request":{"id":"req_2","method":"POST","path":"/webhooks","body":"{\"a\":1}","responseStatus":200,"responseBody":"ok","responseHeaders":{"x-test":"1"}}}`))
+		case r.Method == "POST" && r.URL.Path == "/api/tunnels/tun_1/requests/req_2/replay":
+			w.Write([]byte(`{"request":{"id":"req_3","method":"POST","path":"/webhooks","body":"{\"a\":1}","responseStatus":500}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	page, err := client.Tunnels.ListRequests(ctx, "tun_1", &ListTunnelRequestsParams{Limit: Ptr(1), Method: Ptr("POST")})
+	if err != nil {
+		t.Fatalf("ListRequests: %v", err)
+	}
+	if len(page.Data) != 1 || !page.HasMore || page.NextCursor == nil {
+		t.Fatalf("unexpected first page: %+v", page)
+	}
+	first := page.Data[0]
+	if first.Headers["content-type"] != "application/json" || first.ResponseStatus == nil || *first.ResponseStatus != 200 || first.DurationMs == nil || *first.DurationMs != 12 {
+		t.Errorf("unexpected request: %+v", first)
+	}
+	page, err = client.Tunnels.ListRequests(ctx, "tun_1", &ListTunnelRequestsParams{Cursor: page.NextCursor})
+	if err != nil || len(page.Data) != 1 || page.HasMore || page.Data[0].ID != "req_1" {
+		t.Fatalf("unexpected second page: %+v, %v", page, err)
+	}
+
+	detail, err := client.Tunnels.GetRequest(ctx, "tun_1", "req_2")
+	if err != nil || detail.Body == nil || *detail.Body != `{"a":1}` || detail.ResponseBody == nil || detail.ResponseHeaders["x-test"] != "1" {
+		t.Fatalf("GetRequest: %+v, %v", detail, err)
+	}
+
+	replay, err := client.Tunnels.ReplayRequest(ctx, "tun_1", "req_2")
+	if err != nil || replay.ID != "req_3" || *replay.ResponseStatus != 500 {
+		t.Fatalf("ReplayRequest: %+v, %v", replay, err)
+	}
+}
+
+func TestDLQExport(t *testing.T) {
+	csv := "id,messageId,eventType\ndlq_1,msg_1,order.created\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/api/outbound-messages/dlq/export" || q.Get("format") != "csv" || q.Get("endpointId") != "ep_1" || q.Has("limit") {
+			t.Errorf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte(csv))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	var out strings.Builder
+	if err := client.DLQ.Export(context.Background(), &ListDLQParams{EndpointID: Ptr("ep_1"), Limit: Ptr(5)}, &out, "csv"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.String() != csv {
+		t.Errorf("unexpected export: %q", out.String())
+	}
+
+	var validationErr *ValidationError
+	if err := client.DLQ.Export(context.Background(), nil, &out, "xml"); !errors.As(err, &validationErr) {
+		t.Errorf("expected ValidationError for unsupported format, got %v", err)
+	}
+}
+
+func TestAnalyticsDashboard(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		want := map[string]string{
+			"from":          "2024-01-01T00:00:00Z",
+			"to":            "2024-01-02T00:00:00Z",
+			"granularity":   "hour",
+			"sourceId":      "src_1",
+			"destinationId": "dst_1",
+		}
+		for k, v := range want {
+			if q.Get(k) != v {
+				t.Errorf("query %s = %q, want %q", k, q.Get(k), v)
+			}
+		}
+		if q.Has("range") {
+			t.Errorf("unexpected range in query: %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"data":{"eventsReceived":30,"timeline":[
+			{"timestamp":"2024-01-01T00:00:00Z","eventsReceived":10,"deliveriesSucceeded":9,"deliveriesFailed":1,"avgLatencyMs":120.5},
+			{"timestamp":"2024-01-01 01:00:00","eventsReceived":20},
+			{"timestamp":null}
+		]}}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	data, err := client.Analytics.Dashboard(context.Background(), &DashboardParams{
+		From:          time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:            time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		Granularity:   Ptr(GranularityHour),
+		SourceID:      Ptr("src_1"),
+		DestinationID: Ptr("dst_1"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(data.Timeline) != 3 {
+		t.Fatalf("expected 3 timeline points, got %d", len(data.Timeline))
+	}
+	first := data.Timeline[0]
+	if !first.Timestamp.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) || first.DeliveriesFailed != 1 || first.AvgLatencyMs != 120.5 {
+		t.Errorf("unexpected first point: %+v", first)
+	}
+	second := data.Timeline[1]
+	if !second.Timestamp.Equal(time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)) || second.EventsReceived != 20 || second.DeliveriesSucceeded != 0 || second.AvgLatencyMs != 0 {
+		t.Errorf("unexpected second point: %+v", second)
+	}
+	if !data.Timeline[2].Timestamp.IsZero() {
+		t.Errorf("expected zero timestamp for null, got %v", data.Timeline[2].Timestamp)
+	}
+}
+
+func TestAnalyticsDashboardRange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawQuery != "range=7d" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"data":{"eventsReceived":5}}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	data, err := client.Analytics.DashboardRange(context.Background(), "7d")
+	if err != nil || data.EventsReceived != 5 {
+		t.Fatalf("DashboardRange: %+v, %v", data, err)
+	}
+}
+
+func TestDLQGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/api/outbound-messages/dlq/dlq_1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"data":{
+			"id":"dlq_1","messageId":"msg_1","eventType":"order.created","attempts":2,"maxAttempts":2,
+			"payload":{"orderId":"ord_1"},
+			"requestHeaders":{"content-type":"application/json"},
+			"dlqReason":"max_retries_exceeded","dlqReasonDetail":"2 of 2 attempts failed; last error: connection refused",
+			"attemptHistory":[
+				{"attemptNumber":1,"responseStatus":500,"durationMs":30,"attemptedAt":"2024-01-01T00:00:00Z"},
+				{"attemptNumber":2,"error":"connection refused","attemptedAt":"2024-01-01T00:01:00Z"}
+			]
+		}}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	msg, err := client.DLQ.Get(context.Background(), "dlq_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.ID != "dlq_1" || msg.DLQMessage.Attempts != 2 || string(msg.Payload) != `{"orderId":"ord_1"}` || msg.RequestHeaders["content-type"] != "application/json" {
+		t.Errorf("unexpected message: %+v", msg)
+	}
+	if msg.DLQReason == nil || *msg.DLQReason != ReasonMaxRetriesExceeded {
+		t.Errorf("unexpected reason: %v", msg.DLQReason)
+	}
+	if msg.DLQReasonDetail == nil || !strings.HasPrefix(*msg.DLQReasonDetail, "2 of 2 attempts failed") {
+		t.Errorf("unexpected reason detail: %v", msg.DLQReasonDetail)
+	}
+	if len(msg.Attempts) != 2 || *msg.Attempts[0].ResponseStatus != 500 || *msg.Attempts[1].Error != "connection refused" {
+		t.Errorf("unexpected attempts: %+v", msg.Attempts)
+	}
+}
+
+func TestAnalyticsOutbound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("applicationId") != "app_1" || q.Get("eventType") != "order.created" || q.Get("from") != "2024-01-01T00:00:00Z" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		switch r.URL.Path {
+		case "/api/analytics/outbound/timeseries":
+			if q.Get("granularity") != "day" {
+				t.Errorf("unexpected granularity: %s", q.Get("granularity"))
+			}
+			w.Write([]byte(`{"data":[
+				{"timestamp":"2024-01-01T00:00:00Z","attempted":100,"delivered":97,"failed":3,"avgLatencyMs":85.2,"p95LatencyMs":310},
+				{"timestamp":"2024-01-02T00:00:00Z","attempted":5}
+			]}`))
+		case "/api/analytics/outbound/errors":
+			w.Write([]byte(`{"data":{"total":3,"byStatusClass":{"5xx":2,"network":1},"byCategory":{"server_error":2,"timeout":1}}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	params := &OutboundAnalyticsParams{
+		ApplicationID: Ptr("app_1"),
+		EventType:     Ptr("order.created"),
+		From:          time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Granularity:   Ptr(GranularityDay),
+	}
+	points, err := client.Analytics.OutboundTimeseries(context.Background(), params)
+	if err != nil {
+		t.Fatalf("OutboundTimeseries: %v", err)
+	}
+	if len(points) != 2 || points[0].Delivered != 97 || points[0].P95LatencyMs != 310 || points[1].Failed != 0 {
+		t.Errorf("unexpected points: %+v", points)
+	}
+
+	breakdown, err := client.Analytics.ErrorBreakdown(context.Background(), params)
+	if err != nil {
+		t.Fatalf("ErrorBreakdown: %v", err)
+	}
+	if breakdown.Total != 3 || breakdown.ByStatusClass["5xx"] != 2 || breakdown.ByCategory["timeout"] != 1 {
+		t.Errorf("unexpected breakdown: %+v", breakdown)
+	}
+}
+
+func TestApplicationsGetStats(t *testing.T) {
+	summaries := map[string]string{
+		"":     `{"pending":3,"failed":1,"exhausted":1,"dlq":2,"total":20}`,
+		"ep_1": `{"pending":2,"failed":1,"dlq":1}`,
+		"ep_2": `{"exhausted":1,"dlq":1}`,
+		"ep_3": `{"pending":1}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("applicationId") != "app_1" {
+			t.Errorf("missing applicationId: %s %s", r.URL.Path, r.URL.RawQuery)
+		}
+		switch r.URL.Path {
+		case "/api/webhook-endpoints":
+			if q.Get("offset") == "0" {
+				w.Write([]byte(`{"data":[{"id":"ep_1","url":"https://a.example.com","circuitState":"open"},{"id":"ep_2","url":"https://b.example.com","isDisabled":1,"circuitState":"closed"}],"pagination":{"hasMore":true}}`))
+				return
+			}
+			w.Write([]byte(`{"data":[{"id":"ep_3","url":"https://c.example.com","circuitState":"closed"}],"pagination":{"hasMore":false}}`))
+		case "/api/outbound-messages/stats/summary":
+			summary, ok := summaries[q.Get("endpointId")]
+			if !ok {
+				t.Errorf("unexpected summary query: %s", r.URL.RawQuery)
+			}
+			fmt.Fprintf(w, `{"data":%s}`, summary)
+		case "/api/outbound-messages":
+			if q.Get("status") != "success" || q.Get("limit") != "1" {
+				t.Errorf("expected only the latest successful message to be listed, got %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"data":[{"id":"m6","endpointId":"ep_3","deliveredAt":"2024-01-01T12:00:00Z"}],"pagination":{"hasMore":true,"nextCursor":"c9"}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	stats, err := client.Applications.GetStats(context.Background(), "app_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.EndpointCount != 3 || stats.DisabledEndpointCount != 1 || stats.OpenCircuitCount != 1 {
+		t.Errorf("unexpected endpoint counts: %+v", stats)
+	}
+	if stats.PendingMessages != 3 || stats.FailedMessages != 2 || stats.DLQDepth != 2 {
+		t.Errorf("unexpected message counts: %+v", stats)
+	}
+	if stats.LastDeliveryAt == nil || *stats.LastDeliveryAt != "2024-01-01T12:00:00Z" {
+		t.Errorf("unexpected last delivery: %v", stats.LastDeliveryAt)
+	}
+	ep1 := stats.Endpoints[0]
+	if ep1.EndpointID != "ep_1" || ep1.PendingMessages != 2 || ep1.FailedMessages != 1 || ep1.DLQDepth != 1 || ep1.CircuitState != EndpointCircuitOpen {
+		t.Errorf("unexpected ep_1 breakdown: %+v", ep1)
+	}
+	ep2 := stats.Endpoints[1]
+	if !ep2.IsDisabled || ep2.FailedMessages != 1 || ep2.DLQDepth != 1 {
+		t.Errorf("unexpected ep_2 breakdown: %+v", ep2)
+	}
+	if ep3 := stats.Endpoints[2]; ep3.EndpointID != "ep_3" || ep3.PendingMessages != 1 {
+		t.Errorf("unexpected ep_3 breakdown: %+v", ep3)
+	}
+}
+
+func TestApplicationsGetStatsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/outbound-messages/stats/summary" && r.URL.Query().Get("endpointId") == "ep_2":
+			w.WriteHeader(403)
+			w.Write([]byte(`{"error":{"message":"Missing scope messages:read","code":"forbidden"}}`))
+		case r.URL.Path == "/api/outbound-messages/stats/summary":
+			w.Write([]byte(`{"data":{}}`))
+		case r.URL.Path == "/api/webhook-endpoints":
+			w.Write([]byte(`{"data":[{"id":"ep_1"},{"id":"ep_2"}],"pagination":{"hasMore":false}}`))
+		default:
+			w.Write([]byte(`{"data":[],"pagination":{"hasMore":false}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	_, err := client.Applications.GetStats(context.Background(), "app_1")
+	var forbidden *ForbiddenError
+	if !errors.As(err, &forbidden) {
+		t.Fatalf("expected ForbiddenError, got %v", err)
+	}
+}
+
+func TestListDLQParamsDateRange(t *testing.T) {
+	q := (&ListDLQParams{DLQMovedAfter: Ptr("2024-01-01"), DLQMovedBefore: Ptr("2024-01-31")}).toQuery()
+	if q.Get("dlqMovedAfter") != "2024-01-01" || q.Get("dlqMovedBefore") != "2024-01-31" {
+		t.Errorf("unexpected query: %s", q.Encode())
+	}
+
+	q = (&ListDLQParams{StartDate: Ptr("2024-02-01"), EndDate: Ptr("2024-02-29")}).toQuery()
+	if q.Get("dlqMovedAfter") != "2024-02-01" || q.Get("dlqMovedBefore") != "2024-02-29" || q.Has("startDate") {
+		t.Errorf("expected aliases to map to dlqMoved filters, got %s", q.Encode())
+	}
+
+	q = (&ListDLQParams{DLQMovedAfter: Ptr("2024-03-01"), StartDate: Ptr("2024-02-01")}).toQuery()
+	if q.Get("dlqMovedAfter") != "2024-03-01" {
+		t.Errorf("expected DLQMovedAfter to take precedence, got %s", q.Encode())
+	}
+
+	body := (&ListDLQParams{StartDate: Ptr("2024-02-01")}).filterBody()
+	if body["dlqMovedAfter"] != "2024-02-01" {
+		t.Errorf("expected date filter in body, got %v", body)
+	}
+}
+
+func TestAPIKeysListAvailableScopes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/api/api-keys/scopes" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"data":[{"name":"sources:read","description":"Read sources","category":"Inbound"},{"name":"messages:send","description":"Send outbound messages","category":"Outbound"}]}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	scopes, err := client.APIKeys.ListAvailableScopes(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(scopes) != 2 || scopes[1].Name != ScopeMessagesSend || scopes[1].Category != "Outbound" {
+		t.Errorf("unexpected scopes: %+v", scopes)
+	}
+}
+
+func TestEndpointsRecover(t *testing.T) {
+	since := time.Now().Add(-2 * time.Hour).UTC().Truncate(time.Second)
+	async := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/webhook-endpoints/ep_1/recover":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["since"] != since.Format(time.RFC3339) {
+				t.Errorf("unexpected since: %q", body["since"])
+			}
+			if async {
+				w.WriteHeader(202)
+				w.Write([]byte(`{"data":{"taskId":"task_1","status":"running","requeued":0}}`))
+				return
+			}
+			w.Write([]byte(`{"data":{"status":"completed","requeued":12}}`))
+		case r.Method == "GET" && r.URL.Path == "/api/webhook-endpoints/ep_1/recover/task_1":
+			w.Write([]byte(`{"data":{"taskId":"task_1","status":"completed","requeued":4500}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	result, err := client.Endpoints.Recover(ctx, "app_1", "ep_1", since)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != "completed" || result.Requeued != 12 || result.TaskID != nil {
+		t.Errorf("unexpected sync result: %+v", result)
+	}
+
+	async = true
+	result, err = client.Endpoints.Recover(ctx, "app_1", "ep_1", since)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != "running" || result.TaskID == nil {
+		t.Fatalf("unexpected async result: %+v", result)
+	}
+	result, err = client.Endpoints.GetRecoverStatus(ctx, "app_1", "ep_1", *result.TaskID)
+	if err != nil || result.Requeued != 4500 {
+		t.Fatalf("GetRecoverStatus: %+v, %v", result, err)
+	}
+
+	var validationErr *ValidationError
+	if _, err := client.Endpoints.Recover(ctx, "app_1", "ep_1", time.Now().Add(time.Hour)); !errors.As(err, &validationErr) {
+		t.Errorf("expected ValidationError for future since, got %v", err)
+	}
+	if _, err := client.Endpoints.Recover(ctx, "app_1", "ep_1", time.Now().Add(-RecoverWindow-time.Hour)); !errors.As(err, &validationErr) {
+		t.Errorf("expected ValidationError for since outside retention, got %v", err)
+	}
+}
+
+func TestAPIKeysBulkRevoke(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/api/api-keys/bulk" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			IDs        []string `json:"ids"`
+			IsDisabled *bool    `json:"isDisabled"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body.IDs) != 2 || body.IsDisabled == nil || !*body.IsDisabled {
+			t.Errorf("unexpected body: %+v", body)
+		}
+		w.Write([]byte(`{"success":true,"updated":2}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	result, err := client.APIKeys.BulkRevoke(context.Background(), []string{"key_1", "key_2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Success || result.Updated != 2 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestEndpointsTestWithEvent(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/webhook-endpoints/ep_1/test" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.Write([]byte(`{"data":{"success":false,"statusCode":422,"durationMs":87,"responseBody":"schema mismatch",
+			"signatureHeadersSent":{"webhook-id":"msg_test","webhook-timestamp":"1700000000","webhook-signature":"v1,abc"}}}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	result, err := client.Endpoints.TestWithEvent(context.Background(), "app_1", "ep_1", &EndpointTestParams{
+		EventType: "order.created",
+		Payload:   map[string]interface{}{"orderId": "ord_1"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Success || result.StatusCode == nil || *result.StatusCode != 422 || result.DurationMs != 87 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if result.ResponseBody == nil || *result.ResponseBody != "schema mismatch" || result.SignatureHeadersSent["webhook-signature"] != "v1,abc" {
+		t.Errorf("unexpected response details: %+v", result)
+	}
+
+	if _, err := client.Endpoints.Test(context.Background(), "app_1", "ep_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{`{"eventType":"order.created","payload":{"orderId":"ord_1"}}`, ``}
+	if !reflect.DeepEqual(bodies, want) {
+		t.Errorf("unexpected bodies: %q", bodies)
+	}
+
+	var validationErr *ValidationError
+	if _, err := client.Endpoints.TestWithEvent(context.Background(), "app_1", "ep_1", &EndpointTestParams{}); !errors.As(err, &validationErr) {
+		t.Errorf("expected ValidationError for missing event type, got %v", err)
+	}
+
+	// The deprecated TestWithPayload keeps accepting a payload without an event type.
+	bodies = nil
+	if _, err := client.Endpoints.TestWithPayload(context.Background(), "app_1", "ep_1", &TestEndpointParams{
+		Payload: map[string]interface{}{"orderId": "ord_2"},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{`{"eventType":"","payload":{"orderId":"ord_2"}}`}; !reflect.DeepEqual(bodies, want) {
+		t.Errorf("unexpected bodies: %q", bodies)
+	}
+}
+
+func TestAPIKeysListFilters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("isDisabled") != "false" || q.Get("expiringWithinDays") != "14" || q.Get("search") != "ci" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"data":[{"id":"key_1","name":"ci-deploy","expiresAt":"2024-01-10T00:00:00Z"}]}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	keys, err := client.APIKeys.List(context.Background(), &ListAPIKeysParams{
+		IsDisabled:         Ptr(false),
+		ExpiringWithinDays: Ptr(14),
+		Search:             Ptr("ci"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(keys) != 1 || keys[0].ExpiresAt == nil {
+		t.Errorf("unexpected keys: %+v", keys)
+	}
+}
+
+func TestEndpointsSecretRotation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/webhook-endpoints/ep_1/rotate-secret":
+			var body map[string]int
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["gracePeriodSeconds"] != 3600 {
+				t.Errorf("unexpected body: %v", body)
+			}
+			w.Write([]byte(`{"secret":"whsec_new"}`))
+		case r.Method == "GET" && r.URL.Path == "/api/webhook-endpoints/ep_1/secrets":
+			w.Write([]byte(`{"data":[
+				{"id":"sec_2","secret":"whsec_new","prefix":"whsec_ne","createdAt":"2024-01-01T01:00:00Z"},
+				{"id":"sec_1","prefix":"whsec_ol","createdAt":"2023-06-01T00:00:00Z","expiresAt":"2024-01-01T02:00:00Z"}
+			]}`))
+		case r.Method == "POST" && r.URL.Path == "/api/webhook-endpoints/ep_1/secrets/sec_1/expire":
+			w.WriteHeader(204)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	secret, err := client.Endpoints.RotateSecretWithGrace(ctx, "app_1", "ep_1", 3600)
+	if err != nil || secret != "whsec_new" {
+		t.Fatalf("RotateSecretWithGrace: %q, %v", secret, err)
+	}
+
+	secrets, err := client.Endpoints.ListSecrets(ctx, "app_1", "ep_1")
+	if err != nil {
+		t.Fatalf("ListSecrets: %v", err)
+	}
+	if len(secrets) != 2 || secrets[0].Secret == nil || secrets[1].Secret != nil || secrets[1].ExpiresAt == nil {
+		t.Errorf("unexpected secrets: %+v", secrets)
+	}
+
+	if err := client.Endpoints.ExpireSecret(ctx, "app_1", "ep_1", "sec_1"); err != nil {
+		t.Fatalf("ExpireSecret: %v", err)
+	}
+}
+
+func TestEndpointsFilterTypesRetryOnConflict(t *testing.T) {
+	version := 1
+	filterTypes := []string{"order.created"}
+	gets, patches := 0, 0
+	sendETag := true
+	var orgs, ifMatch []string
+	etag := func() string { return `"v` + strconv.Itoa(version) + `"` }
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		orgs = append(orgs, r.Header.Get("X-Organization-ID"))
+		switch r.Method {
+		case "GET":
+			gets++
+			if sendETag {
+				w.Header().Set("ETag", etag())
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{"id": "ep_1", "filterTypes": filterTypes, "updatedAt": "2026-01-01T00:00:00Z"},
+			})
+			if gets == 1 {
+				// Another writer updates the endpoint between our read and write.
+				filterTypes = append(filterTypes, "order.paid")
+				version++
+			}
+		case "PATCH":
+			patches++
+			ifMatch = append(ifMatch, r.Header.Get("If-Match"))
+			if sendETag && r.Header.Get("If-Match") != etag() {
+				w.WriteHeader(412)
+				w.Write([]byte(`{"error":{"message":"Endpoint was modified","code":"precondition_failed"}}`))
+				return
+			}
+			var body struct {
+				FilterTypes []string `json:"filterTypes"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			filterTypes = body.FilterTypes
+			version++
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{"id": "ep_1", "filterTypes": filterTypes, "updatedAt": "2026-01-01T00:00:00Z"},
+			})
+		}
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	ep, err := client.Endpoints.AddFilterTypes(context.Background(), "app_1", "ep_1", []string{"order.shipped", "order.created", "order.shipped"},
+		WithRequestOrganizationID("org_2"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gets != 2 || patches != 2 {
+		t.Errorf("expected one retry, got %d gets and %d patches", gets, patches)
+	}
+	if want := []string{`"v1"`, `"v2"`}; !reflect.DeepEqual(ifMatch, want) {
+		t.Errorf("expected If-Match from the ETag of each read, got %q", ifMatch)
+	}
+	if want := []string{"org_2", "org_2", "org_2", "org_2"}; !reflect.DeepEqual(orgs, want) {
+		t.Errorf("expected request options on every read and write, got %q", orgs)
+	}
+	if got := strings.Join(ep.FilterTypes, ","); got != "order.created,order.paid,order.shipped" {
+		t.Errorf("concurrent change lost or duplicates kept: %s", got)
+	}
+
+	ep, err = client.Endpoints.RemoveFilterTypes(context.Background(), "app_1", "ep_1", []string{"order.created", "order.paid", "order.shipped"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ep.FilterTypes == nil || len(ep.FilterTypes) != 0 {
+		t.Errorf("expected empty filter types, got %v", ep.FilterTypes)
+	}
+
+	patches = 0
+	if _, err := client.Endpoints.RemoveFilterTypes(context.Background(), "app_1", "ep_1", []string{"missing"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if patches != 0 {
+		t.Errorf("expected no write when nothing changes, got %d", patches)
+	}
+
+	// Without an ETag the write is sent without a precondition.
+	sendETag, ifMatch = false, nil
+	ep, err = client.Endpoints.AddFilterTypes(context.Background(), "app_1", "ep_1", []string{"order.refunded"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(ifMatch, []string{""}) || !reflect.DeepEqual(ep.FilterTypes, []string{"order.refunded"}) {
+		t.Errorf("expected one unconditional write, got If-Match %q and %v", ifMatch, ep.FilterTypes)
+	}
+}
+
+func TestEndpointsSetFilterTypesValidation(t *testing.T) {
+	var patched []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/event-types":
+			w.Write([]byte(`{"data":[{"name":"order.created"},{"name":"order.paid"}],"pagination":{"hasMore":false}}`))
+		case r.Method == "PATCH" && r.URL.Path == "/api/webhook-endpoints/ep_1":
+			var body struct {
+				FilterTypes []string `json:"filterTypes"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			patched = body.FilterTypes
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"id": "ep_1", "filterTypes": body.FilterTypes}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	_, err := client.Endpoints.SetFilterTypes(context.Background(), "app_1", "ep_1", []string{"order.created", "order.craeted"}, true)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || !strings.Contains(validationErr.Error(), "order.craeted") {
+		t.Fatalf("expected ValidationError naming the typo, got %v", err)
+	}
+	if patched != nil {
+		t.Fatalf("expected no update after failed validation")
+	}
+
+	if _, err := client.Endpoints.SetFilterTypes(context.Background(), "app_1", "ep_1", []string{"order.paid", "order.created", "order.paid"}, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(patched, ",") != "order.paid,order.created" {
+		t.Errorf("unexpected filter types: %v", patched)
+	}
+}
+
+func TestPortalTokensValidate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/portal/tokens/validate" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["token"] != "pt_abc" {
+			t.Errorf("unexpected body: %v", body)
+		}
+		w.Write([]byte(`{"data":{"valid":false,"expired":true,"revoked":false,"scopes":["read"],"expiresAt":"2024-01-01T00:00:00Z"}}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	v, err := client.PortalTokens.Validate(context.Background(), "app_1", "pt_abc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v.Valid || !v.Expired || v.Revoked || len(v.Scopes) != 1 || v.ExpiresAt == "" {
+		t.Errorf("unexpected validation: %+v", v)
+	}
+}
+
+func TestEndpointsPauseUntilMetadataFallback(t *testing.T) {
+	until := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+	var patched map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`{"data":{"id":"ep_1","isDisabled":false,"metadata":{"team":"billing"}}}`))
+		case "PATCH":
+			json.NewDecoder(r.Body).Decode(&patched)
+			// The server ignores pausedUntil and only persists metadata.
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{"id": "ep_1", "isDisabled": patched["isDisabled"], "metadata": patched["metadata"]},
+			})
+		}
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	ep, err := client.Endpoints.PauseUntil(context.Background(), "app_1", "ep_1", until)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if patched["isDisabled"] != true {
+		t.Errorf("expected isDisabled=true, got %v", patched["isDisabled"])
+	}
+	want := until.UTC().Format(time.RFC3339)
+	if patched["pausedUntil"] != want {
+		t.Errorf("expected pausedUntil %s, got %v", want, patched["pausedUntil"])
+	}
+	if ep.Metadata["team"] != "billing" || ep.Metadata[PausedUntilMetadataKey] != want {
+		t.Errorf("unexpected metadata: %v", ep.Metadata)
+	}
+	got, ok := ep.pausedUntil()
+	if !ok || !got.Equal(until) {
+		t.Errorf("expected resume time %s from metadata, got %s (%v)", until, got, ok)
+	}
+
+	if _, err := client.Endpoints.PauseUntil(context.Background(), "app_1", "ep_1", time.Now().Add(-time.Minute)); err == nil {
+		t.Error("expected error for a time in the past")
+	}
+}
+
+func TestEndpointsResumeIfDue(t *testing.T) {
+	past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	patches := map[string]map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			if r.URL.Query().Get("isDisabled") != "true" {
+				t.Errorf("expected isDisabled filter, got %s", r.URL.RawQuery)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": []map[string]interface{}{
+					{"id": "ep_api", "isDisabled": true, "pausedUntil": past},
+					{"id": "ep_meta", "isDisabled": true, "metadata": map[string]interface{}{PausedUntilMetadataKey: past, "team": "ops"}},
+					{"id": "ep_later", "isDisabled": true, "pausedUntil": future},
+					{"id": "ep_manual", "isDisabled": true},
+				},
+				"pagination": map[string]interface{}{"hasMore": false},
+			})
+		case "PATCH":
+			id := strings.TrimPrefix(r.URL.Path, "/api/webhook-endpoints/")
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			patches[id] = body
+			w.Write([]byte(`{"data":{"id":"` + id + `","isDisabled":false}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	resumed, err := client.Endpoints.ResumeIfDue(context.Background(), "app_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resumed) != 2 || len(patches) != 2 {
+		t.Fatalf("expected 2 endpoints resumed, got %d (patched %v)", len(resumed), patches)
+	}
+	if body := patches["ep_api"]; body["isDisabled"] != false || body["pausedUntil"] != nil {
+		t.Errorf("unexpected body for ep_api: %v", body)
+	}
+	if _, ok := patches["ep_api"]["metadata"]; ok {
+		t.Error("metadata should not be sent when it has no pause key")
+	}
+	meta, _ := patches["ep_meta"]["metadata"].(map[string]interface{})
+	if _, ok := meta[PausedUntilMetadataKey]; ok || meta["team"] != "ops" {
+		t.Errorf("expected pause key removed and other metadata kept, got %v", meta)
+	}
+}
+
+func TestPortalTokensGetEmbedURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/portal/webhook-applications/app_1/tokens" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"data":{"id":"pt_1","applicationId":"app_1","token":"whpt_a+b/c","scopes":["read"]}}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	got, err := client.PortalTokens.GetEmbedURL(context.Background(), "app_1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "https://portal.hookbase.app/embed?token=whpt_a%2Bb%2Fc"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	client = New("test_key", WithBaseURL(server.URL), WithPortalBaseURL("https://webhooks.example.com/"))
+	got, err = client.PortalTokens.GetEmbedURL(context.Background(), "app_1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "https://webhooks.example.com/embed?token=whpt_a%2Bb%2Fc"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestPortalTokensRefresh(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/portal/tokens/pt_1/refresh" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]int
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["extendByDays"] != 30 {
+			t.Errorf("expected extendByDays=30, got %v", body)
+		}
+		w.Write([]byte(`{"data":{"id":"pt_1","applicationId":"app_1","scopes":["read"],"expiresAt":"2026-12-01T00:00:00Z"}}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	token, err := client.PortalTokens.Refresh(context.Background(), "app_1", "pt_1", 30)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.ID != "pt_1" || token.ExpiresAt != "2026-12-01T00:00:00Z" {
+		t.Errorf("unexpected token: %+v", token)
+	}
+
+	// Like the other methods' argument checks, this one does not depend on
+	// WithClientValidation.
+	client = New("test_key", WithBaseURL(server.URL), WithClientValidation(false))
+	if _, err := client.PortalTokens.Refresh(context.Background(), "app_1", "pt_1", 0); !IsErrorCode(err, CodeClientValidation) {
+		t.Errorf("expected client validation error for non-positive extendByDays, got %v", err)
+	}
+}
+
+func TestPortalTokensExtend(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/portal/tokens/pt_1/refresh" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]int
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["extendByDays"] != 7 {
+			t.Errorf("expected extendByDays=7, got %v", body)
+		}
+		w.Write([]byte(`{"data":{"id":"pt_1","applicationId":"app_1","scopes":["messages:read"],"expiresAt":"2026-12-08T00:00:00Z"}}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	token, err := client.PortalTokens.Extend(context.Background(), "app_1", "pt_1", 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.ExpiresAt != "2026-12-08T00:00:00Z" {
+		t.Errorf("unexpected token: %+v", token)
+	}
+
+	if _, err := client.PortalTokens.Extend(context.Background(), "app_1", "pt_1", 0); err == nil {
+		t.Error("expected validation error for non-positive additionalDays")
+	}
+}
+
+func TestPortalURL(t *testing.T) {
+	got, err := PortalURL("https://portal.example.com/hooks/", "whpt_a+b/c",
+		WithPortalTheme("dark"), WithPortalLocale("pt-BR"), WithPortalSection("endpoints & more"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "https://portal.example.com/hooks/embed?locale=pt-BR&section=endpoints+%26+more&theme=dark&token=whpt_a%2Bb%2Fc"
+	if got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	if _, err := PortalURL("https://portal.example.com", ""); err == nil {
+		t.Error("expected error for empty token")
+	}
+	if _, err := PortalURL("portal.example.com", "whpt_1"); err == nil {
+		t.Error("expected error for base URL without scheme")
+	}
+}
+
+func TestPortalTokensIntrospect(t *testing.T) {
+	responses := map[string]string{
+		"whpt_valid":   `{"data":{"tokenId":"pt_1","applicationId":"app_1","scopes":["messages:read"],"allowedIps":["10.0.0.1"],"expiresAt":"2026-12-01T00:00:00Z","isExpired":false,"isRevoked":false}}`,
+		"whpt_expired": `{"data":{"tokenId":"pt_2","applicationId":"app_1","scopes":["messages:read"],"expiresAt":"2026-01-01T00:00:00Z","isExpired":true,"isRevoked":false}}`,
+		"whpt_revoked": `{"data":{"tokenId":"pt_3","applicationId":"app_1","scopes":["messages:read"],"expiresAt":"2026-12-01T00:00:00Z","isExpired":false,"isRevoked":true}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/portal/tokens/introspect" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if r.Header.Get("Authorization") != "Bearer test_key" {
+			w.WriteHeader(401)
+			w.Write([]byte(`{"error":{"message":"Invalid API key","code":"invalid_api_key"}}`))
+			return
+		}
+		resp, ok := responses[body["token"]]
+		if !ok {
+			w.WriteHeader(401)
+			w.Write([]byte(`{"error":{"message":"Invalid portal token","code":"invalid_token"}}`))
+			return
+		}
+		w.Write([]byte(resp))
+	}))
+	defer server.Close()
+
+	var logs strings.Builder
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	client := New("test_key", WithBaseURL(server.URL), WithLogger(logger), WithDebug(true))
+	ctx := context.Background()
+
+	info, err := client.PortalTokens.Introspect(ctx, "whpt_valid")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !info.Active() || info.ApplicationID != "app_1" || !info.HasScope(ScopePortalReadMessages) ||
+		info.HasScope(ScopePortalManageEndpoints) || !reflect.DeepEqual(info.AllowedIPs, []string{"10.0.0.1"}) {
+		t.Errorf("unexpected info: %+v", info)
+	}
+
+	info, err = client.PortalTokens.Introspect(ctx, "whpt_expired")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Active() || !info.IsExpired {
+		t.Errorf("expected expired token, got %+v", info)
+	}
+
+	info, err = client.PortalTokens.Introspect(ctx, "whpt_revoked")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Active() || !info.IsRevoked {
+		t.Errorf("expected revoked token, got %+v", info)
+	}
+
+	_, err = client.PortalTokens.Introspect(ctx, "whpt_unknown")
+	if _, ok := err.(*InvalidPortalTokenError); !ok {
+		t.Errorf("expected InvalidPortalTokenError, got %T %v", err, err)
+	}
+
+	badKey := New("wrong_key", WithBaseURL(server.URL))
+	_, err = badKey.PortalTokens.Introspect(ctx, "whpt_valid")
+	if _, ok := err.(*AuthenticationError); !ok || !IsErrorCode(err, CodeInvalidAPIKey) {
+		t.Errorf("expected AuthenticationError for an invalid API key, got %T %v", err, err)
+	}
+
+	if strings.Contains(logs.String(), "whpt_") {
+		t.Errorf("expected portal tokens to be redacted from debug logs, got %s", logs.String())
+	}
+	if !strings.Contains(logs.String(), "[REDACTED]") {
+		t.Errorf("expected redaction marker in debug logs, got %s", logs.String())
+	}
+}
+
+func TestEndpointsGetStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/webhook-endpoints/ep_1/stats" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("startDate") != "2026-01-01" || r.URL.Query().Get("endDate") != "2026-01-31" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"data":{"totalMessages":10,"totalSuccesses":9,"totalFailures":1,"successRate":90,"averageLatency":120.5,"p50Latency":100,"p95Latency":300,"p99Latency":450,"recentFailures":1,"timeline":[{"timestamp":"2026-01-01T00:00:00Z","total":10,"successes":9,"failures":1,"averageLatency":120.5}]}}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	stats, err := client.Endpoints.GetStats(context.Background(), "app_1", "ep_1", &EndpointStatsParams{StartDate: Ptr("2026-01-01"), EndDate: Ptr("2026-01-31")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Source != EndpointStatsSourceServer {
+		t.Errorf("expected source %q, got %q", EndpointStatsSourceServer, stats.Source)
+	}
+	if stats.AverageLatency != 120.5 || stats.P95Latency != 300 || stats.RecentFailures != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	if len(stats.Timeline) != 1 || stats.Timeline[0].Failures != 1 || stats.Timeline[0].Timestamp.IsZero() {
+		t.Errorf("unexpected timeline: %+v", stats.Timeline)
+	}
+}
+
+func TestEndpointsGetStatsComputedFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/stats") {
+			w.WriteHeader(404)
+			w.Write([]byte(`{"error":{"message":"Not found","code":"not_found"}}`))
+			return
+		}
+		w.Write([]byte(`{"data":{"id":"ep_1","totalMessages":4,"totalSuccesses":3,"totalFailures":1}}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	stats, err := client.Endpoints.GetStats(context.Background(), "app_1", "ep_1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Source != EndpointStatsSourceComputed {
+		t.Errorf("expected source %q, got %q", EndpointStatsSourceComputed, stats.Source)
+	}
+	if stats.TotalMessages != 4 || stats.SuccessRate != 75 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestPortalTokensBulkRevoke(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Path != "/api/portal/webhook-applications/app_1/tokens" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"success":true,"updated":3}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	result, err := client.PortalTokens.BulkRevoke(context.Background(), "app_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Success || result.Updated != 3 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestMessagesSendRawPayload(t *testing.T) {
+	raw := json.RawMessage(`{"z":1,"a":[12345678901234567890,"<b>&"],"m":null}`)
+	var payload json.RawMessage
+	var deliverAt string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Payload   json.RawMessage `json:"payload"`
+			DeliverAt string          `json:"deliverAt"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		payload, deliverAt = body.Payload, body.DeliverAt
+		w.Write([]byte(`{"data":{"eventId":"evt_1","messagesQueued":2,"endpoints":[{"id":"ep_1"},{"id":"ep_2"}]}}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	resp, err := client.Messages.Send(context.Background(), "app_1", &SendMessageParams{EventType: "order.created", RawPayload: raw})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(payload) != string(raw) {
+		t.Errorf("payload changed in transit:\n got %s\nwant %s", payload, raw)
+	}
+	if deliverAt != "" {
+		t.Errorf("expected no deliverAt, got %q", deliverAt)
+	}
+	if resp.MessagesQueued != 2 {
+		t.Errorf("expected 2 messages queued, got %d", resp.MessagesQueued)
+	}
+
+	_, err = client.Messages.Send(context.Background(), "app_1", &SendMessageParams{EventType: "order.created", RawPayload: json.RawMessage(`[1,2]`)})
+	if err != nil || string(payload) != "[1,2]" {
+		t.Errorf("expected top-level array payload, got %s (%v)", payload, err)
+	}
+
+	at := time.Date(2026, 11, 1, 9, 30, 0, 0, time.FixedZone("CET", 3600))
+	if _, err := client.Messages.Send(context.Background(), "app_1", &SendMessageParams{EventType: "order.created", Payload: map[string]interface{}{}, DeliverAt: &at}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deliverAt != "2026-11-01T08:30:00Z" {
+		t.Errorf("expected deliverAt in UTC, got %q", deliverAt)
+	}
+}
+
+func TestMessagesSendRawPayloadValidation(t *testing.T) {
+	client := New("test_key", WithBaseURL("http://127.0.0.1:0"))
+	cases := []*SendMessageParams{
+		{EventType: "order.created", Payload: map[string]interface{}{"a": 1}, RawPayload: json.RawMessage(`{}`)},
+		{EventType: "order.created", RawPayload: json.RawMessage(`{"a":`)},
+	}
+	for _, params := range cases {
+		_, err := client.Messages.Send(context.Background(), "app_1", params)
+		var verr *ValidationError
+		if !errors.As(err, &verr) || verr.Code != "client_validation_error" {
+			t.Errorf("expected client validation error, got %v", err)
+		}
+	}
+}
+
+func TestTunnelsGetStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/tunnels/tun_1/status" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"status":{"connected":true,"connectedAt":"2026-01-01T00:00:00Z","requestsProxied":42,"bytesTransferred":5000000000,"lastActivityAt":"2026-01-01T01:00:00Z","error":null}}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	status, err := client.Tunnels.GetStatus(context.Background(), "tun_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !status.Connected || status.RequestsProxied != 42 || status.BytesTransferred != 5000000000 || status.Error != nil {
+		t.Errorf("unexpected status: %+v", status)
+	}
+}
+
+func TestTunnelsBulkDelete(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("expected DELETE, got %s", r.Method)
+		}
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path == "/api/tunnels/bulk" {
+			var body struct {
+				IDs []string `json:"ids"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			if len(body.IDs) != 2 {
+				t.Errorf("expected 2 ids, got %v", body.IDs)
+			}
+		}
+		w.Write([]byte(`{"success":true,"deleted":2}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	result, err := client.Tunnels.BulkDelete(context.Background(), []string{"tun_1", "tun_2"})
+	if err != nil || result.Deleted != 2 {
+		t.Fatalf("unexpected result %+v, error %v", result, err)
+	}
+	if _, err := client.Tunnels.DeleteAll(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(paths, ",") != "/api/tunnels/bulk,/api/tunnels" {
+		t.Errorf("unexpected paths: %v", paths)
+	}
+	if _, err := client.Tunnels.BulkDelete(context.Background(), nil); err == nil {
+		t.Error("expected error for empty ids")
+	}
+}
+
+// instantWait makes wait helpers poll without sleeping and records each interval.
+func instantWait(intervals *[]time.Duration) WaitOption {
+	return func(c *waitConfig) {
+		c.after = func(d time.Duration) <-chan time.Time {
+			*intervals = append(*intervals, d)
+			ch := make(chan time.Time, 1)
+			ch <- time.Time{}
+			return ch
+		}
+	}
+}
+
+func TestMessagesWaitForDelivery(t *testing.T) {
+	for _, final := range []MessageStatus{MessageSuccess, MessageFailed, MessageExhausted} {
+		t.Run(string(final), func(t *testing.T) {
+			polls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				polls++
+				status := MessagePending
+				if polls == 5 {
+					status = final
+				}
+				json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"id": "om_1", "status": status}})
+			}))
+			defer server.Close()
+
+			var intervals []time.Duration
+			client := New("test_key", WithBaseURL(server.URL))
+			msg, err := client.Messages.WaitForDelivery(context.Background(), "app_1", "om_1",
+				WithWaitInterval(100*time.Millisecond, 500*time.Millisecond), instantWait(&intervals))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if msg.Status != final || polls != 5 {
+				t.Errorf("expected %s after 5 polls, got %s after %d", final, msg.Status, polls)
+			}
+			want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 500 * time.Millisecond}
+			if !reflect.DeepEqual(intervals, want) {
+				t.Errorf("expected backoff %v, got %v", want, intervals)
+			}
+		})
+	}
+}
+
+func TestMessagesWaitForDeliveryTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"id":"om_1","status":"pending","attempts":2}}`))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	polls := 0
+	stopAfterTwo := func(c *waitConfig) {
+		c.after = func(time.Duration) <-chan time.Time {
+			if polls++; polls == 2 {
+				cancel()
+				return nil
+			}
+			ch := make(chan time.Time, 1)
+			ch <- time.Time{}
+			return ch
+		}
+	}
+	client := New("test_key", WithBaseURL(server.URL))
+	_, err := client.Messages.WaitForDelivery(ctx, "app_1", "om_1", stopAfterTwo)
+	var werr *DeliveryWaitError
+	if !errors.As(err, &werr) {
+		t.Fatalf("expected DeliveryWaitError, got %v", err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected error to wrap context.Canceled, got %v", werr.Err)
+	}
+	if werr.Message == nil || werr.Message.Attempts != 2 || len(werr.Messages) != 1 {
+		t.Errorf("expected last observed message, got %+v", werr)
+	}
+}
+
+func TestMessagesWaitForMessage(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("messageId") != "msg_1" {
+			t.Errorf("expected messageId filter, got %s", r.URL.RawQuery)
+		}
+		polls++
+		var data []map[string]interface{}
+		switch polls {
+		case 1:
+			// Not fanned out yet.
+		case 2:
+			data = []map[string]interface{}{{"id": "om_1", "status": "success"}, {"id": "om_2", "status": "pending"}}
+		default:
+			data = []map[string]interface{}{{"id": "om_1", "status": "success"}, {"id": "om_2", "status": "exhausted"}}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": data, "pagination": map[string]interface{}{"hasMore": false}})
+	}))
+	defer server.Close()
+
+	var intervals []time.Duration
+	client := New("test_key", WithBaseURL(server.URL))
+	msgs, err := client.Messages.WaitForMessage(context.Background(), "app_1", "msg_1", instantWait(&intervals))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if polls != 3 || len(msgs) != 2 || msgs[1].Status != MessageExhausted {
+		t.Errorf("unexpected result after %d polls: %+v", polls, msgs)
+	}
+}
+
+func TestMessagesGetContent(t *testing.T) {
+	payload := `{"z":1,"a":12345678901234567890}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/outbound-messages/om_1/content":
+			w.Write([]byte(`{"data":{"payload":` + payload + `,"headers":{"webhook-id":"msg_1"}}}`))
+		case "/api/outbound-messages/om_big/content":
+			w.Write([]byte(`{"data":{"payload":"` + strings.Repeat("x", MaxMessageContentSize) + `"}}`))
+		default:
+			w.WriteHeader(404)
+			w.Write([]byte(`{"error":{"message":"Message not found","code":"not_found"}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	got, headers, err := client.Messages.GetContent(context.Background(), "app_1", "om_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != payload {
+		t.Errorf("payload changed: got %s, want %s", got, payload)
+	}
+	if headers["webhook-id"] != "msg_1" {
+		t.Errorf("unexpected headers: %v", headers)
+	}
+
+	_, _, err = client.Messages.GetContent(context.Background(), "app_1", "om_missing")
+	var nf *NotFoundError
+	if !errors.As(err, &nf) {
+		t.Errorf("expected NotFoundError, got %v", err)
+	}
+
+	if _, _, err = client.Messages.GetContent(context.Background(), "app_1", "om_big"); err == nil || !strings.Contains(err.Error(), "limit") {
+		t.Errorf("expected size limit error, got %v", err)
+	}
+}
+
+func TestMessagesExpediteAndCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/outbound-messages/om_1/expedite":
+			w.Write([]byte(`{"data":{"id":"om_1","status":"pending","nextAttemptAt":null}}`))
+		case "/api/outbound-messages/om_1/cancel":
+			w.WriteHeader(409)
+			w.Write([]byte(`{"error":{"message":"Message already delivered","code":"message_terminal"}}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	msg, err := client.Messages.Expedite(context.Background(), "app_1", "om_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.Status != MessagePending || msg.NextAttemptAt != nil {
+		t.Errorf("unexpected message: %+v", msg)
+	}
+
+	_, err = client.Messages.Cancel(context.Background(), "app_1", "om_1")
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) || conflict.Code != "message_terminal" {
+		t.Errorf("expected ConflictError, got %v", err)
+	}
+}
+
+func TestMessagesBulkCancelChunks(t *testing.T) {
+	var sizes []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/outbound-messages/bulk-cancel" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		var body struct {
+			MessageIDs []string `json:"messageIds"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		sizes = append(sizes, len(body.MessageIDs))
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"total": len(body.MessageIDs), "succeeded": len(body.MessageIDs) - 1, "failed": 1,
+				"results": []map[string]interface{}{{"messageId": body.MessageIDs[0], "status": "success", "error": "already delivered"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	ids := make([]string, 250)
+	for i := range ids {
+		ids[i] = "om_" + strconv.Itoa(i)
+	}
+	client := New("test_key", WithBaseURL(server.URL))
+	result, err := client.Messages.BulkCancel(context.Background(), "app_1", ids)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(sizes, []int{100, 100, 50}) {
+		t.Errorf("expected chunks of 100, 100, 50, got %v", sizes)
+	}
+	if result.Total != 250 || result.Failed != 3 || result.Succeeded != 247 || len(result.Results) != 3 {
+		t.Errorf("unexpected merged result: %+v", result)
+	}
+}
+
+func TestMessagesGetStatsSummaryFiltered(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(`{"data":{"pending":1,"success":8,"failed":1,"total":10,"byEventType":{"order.created":{"success":5,"total":5},"order.paid":{"pending":1,"success":3,"failed":1,"total":5}}}}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	summary, err := client.Messages.GetStatsSummary(context.Background(), &OutboundStatsParams{
+		ApplicationID: Ptr("app_1"),
+		EventType:     Ptr("order.paid"),
+		StartDate:     Ptr("2026-01-01"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if query.Get("applicationId") != "app_1" || query.Get("eventType") != "order.paid" || query.Get("startDate") != "2026-01-01" || query.Has("endDate") {
+		t.Errorf("unexpected query: %v", query)
+	}
+	if summary.Total != 10 || summary.ByEventType["order.paid"].Failed != 1 || summary.ByEventType["order.created"].Total != 5 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+
+	if _, err := client.Messages.GetStatsSummary(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(query) != 0 {
+		t.Errorf("expected no query for nil params, got %v", query)
+	}
+}
+
+func TestEventTypesGetByName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("search") == "" {
+			t.Errorf("expected search filter, got %s", r.URL.RawQuery)
+		}
+		// Search is a substring match, so similar names come back too.
+		w.Write([]byte(`{"data":[{"id":"et_1","name":"order.created.v2"},{"id":"et_2","name":"order.created"}],"pagination":{"hasMore":false}}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	et, err := client.EventTypes.GetByName(context.Background(), "order.created")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if et.ID != "et_2" {
+		t.Errorf("expected exact match et_2, got %s", et.ID)
+	}
+
+	_, err = client.EventTypes.GetByName(context.Background(), "order")
+	var nf *NotFoundError
+	if !errors.As(err, &nf) {
+		t.Errorf("expected NotFoundError, got %v", err)
+	}
+}
+
+func TestValidateEventTypeName(t *testing.T) {
+	valid := []string{"order.created", "user", "invoice.payment_failed", "a1.b-2"}
+	for _, name := range valid {
+		if err := ValidateEventTypeName(name); err != nil {
+			t.Errorf("expected %q to be valid, got %v", name, err)
+		}
+	}
+	invalid := []string{"", "Order.Created", "order created", "order..created", ".order", "order.", "1order", strings.Repeat("a", MaxEventTypeNameLength+1)}
+	for _, name := range invalid {
+		if err := ValidateEventTypeName(name); err == nil {
+			t.Errorf("expected %q to be rejected", name)
+		}
+	}
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"data":{"id":"et_1","name":"Order Created"}}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	_, err := client.EventTypes.Create(context.Background(), &CreateEventTypeParams{Name: "Order Created"})
+	var verr *ValidationError
+	if !errors.As(err, &verr) || requests != 0 {
+		t.Errorf("expected client-side rejection, got %v after %d requests", err, requests)
+	}
+	client = New("test_key", WithBaseURL(server.URL), WithClientValidation(false))
+	if _, err := client.EventTypes.Create(context.Background(), &CreateEventTypeParams{Name: "Order Created"}); err != nil || requests != 1 {
+		t.Errorf("expected request with validation disabled, got %v", err)
+	}
+}
+
+// fakeEventTypeServer serves an in-memory event type catalog and counts writes.
+func fakeEventTypeServer(t *testing.T, types []map[string]interface{}) (*httptest.Server, *int) {
+	writes := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/event-types":
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+			end := offset + 2 // small pages to exercise pagination
+			if end > len(types) {
+				end = len(types)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data":       types[offset:end],
+				"pagination": map[string]interface{}{"hasMore": end < len(types)},
+			})
+		case r.Method == "POST":
+			writes++
+			var et map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&et)
+			et["id"] = "et_" + et["name"].(string)
+			et["isEnabled"] = true
+			types = append(types, et)
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": et})
+		case r.Method == "PATCH":
+			writes++
+			id := strings.TrimPrefix(r.URL.Path, "/api/event-types/")
+			var patch map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&patch)
+			for _, et := range types {
+				if et["id"] == id {
+					for k, v := range patch {
+						et[k] = v
+					}
+					json.NewEncoder(w).Encode(map[string]interface{}{"data": et})
+					return
+				}
+			}
+			w.WriteHeader(404)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	return server, &writes
+}
+
+func TestEventTypesSync(t *testing.T) {
+	catalog := func() []CreateEventTypeParams {
+		return []CreateEventTypeParams{
+			{Name: "order.created", Description: Ptr("An order was created"), Schema: map[string]interface{}{"type": "object", "minProperties": 1}},
+			{Name: "order.paid", Description: Ptr("An order was paid")},
+		}
+	}
+	initial := func() []map[string]interface{} {
+		return []map[string]interface{}{
+			{"id": "et_order.created", "name": "order.created", "description": "An order was created", "schema": map[string]interface{}{"type": "object", "minProperties": 1}, "isEnabled": true},
+			{"id": "et_order.paid", "name": "order.paid", "description": "Old text", "isEnabled": true},
+			{"id": "et_user.deleted", "name": "user.deleted", "isEnabled": true},
+		}
+	}
+
+	t.Run("converges then no-op", func(t *testing.T) {
+		server, writes := fakeEventTypeServer(t, initial())
+		defer server.Close()
+		client := New("test_key", WithBaseURL(server.URL))
+
+		result, err := client.EventTypes.Sync(context.Background(), catalog(), nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(result.Updated, []string{"order.paid"}) || !reflect.DeepEqual(result.Unchanged, []string{"order.created"}) || len(result.Created)+len(result.Archived) != 0 {
+			t.Errorf("unexpected first sync: %+v", result)
+		}
+
+		*writes = 0
+		result, err = client.EventTypes.Sync(context.Background(), catalog(), nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if *writes != 0 || len(result.Unchanged) != 2 {
+			t.Errorf("expected no-op second sync, got %d writes: %+v", *writes, result)
+		}
+	})
+
+	t.Run("additive keeps extras", func(t *testing.T) {
+		server, writes := fakeEventTypeServer(t, initial())
+		defer server.Close()
+		client := New("test_key", WithBaseURL(server.URL))
+
+		desired := append(catalog(), CreateEventTypeParams{Name: "invoice.sent"})
+		result, err := client.EventTypes.Sync(context.Background(), desired, &SyncOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(result.Created, []string{"invoice.sent"}) || len(result.Archived) != 0 || *writes != 2 {
+			t.Errorf("unexpected additive sync (%d writes): %+v", *writes, result)
+		}
+	})
+
+	t.Run("destructive with ArchiveExtra", func(t *testing.T) {
+		server, _ := fakeEventTypeServer(t, initial())
+		defer server.Close()
+		client := New("test_key", WithBaseURL(server.URL))
+
+		result, err := client.EventTypes.Sync(context.Background(), catalog(), &SyncOptions{ArchiveExtra: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(result.Archived, []string{"user.deleted"}) {
+			t.Errorf("expected user.deleted archived, got %+v", result)
+		}
+		result, _ = client.EventTypes.Sync(context.Background(), catalog(), &SyncOptions{ArchiveExtra: true})
+		if len(result.Archived) != 0 {
+			t.Errorf("expected already-archived types to be left alone, got %v", result.Archived)
+		}
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		server, writes := fakeEventTypeServer(t, initial())
+		defer server.Close()
+		client := New("test_key", WithBaseURL(server.URL))
+
+		desired := append(catalog(), CreateEventTypeParams{Name: "invoice.sent"})
+		result, err := client.EventTypes.Sync(context.Background(), desired, &SyncOptions{ArchiveExtra: true, DryRun: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if *writes != 0 {
+			t.Errorf("dry run made %d writes", *writes)
+		}
+		if len(result.Created) != 1 || len(result.Updated) != 1 || len(result.Archived) != 1 || len(result.Unchanged) != 1 {
+			t.Errorf("unexpected plan: %+v", result)
+		}
+	})
+
+	t.Run("duplicate names", func(t *testing.T) {
+		client := New("test_key", WithBaseURL("http://127.0.0.1:0"))
+		_, err := client.EventTypes.Sync(context.Background(), []CreateEventTypeParams{{Name: "a.b"}, {Name: "a.b"}}, nil)
+		if err == nil {
+			t.Error("expected error for duplicate names")
+		}
+	})
+}
+
+func TestOutboundSchemaValidation(t *testing.T) {
+	lookups, sends := 0, 0
+	schema := map[string]interface{}{
+		"type":       "object",
+		"required":   []string{"orderId"},
+		"properties": map[string]interface{}{"orderId": map[string]interface{}{"type": "string"}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/event-types":
+			lookups++
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data":       []map[string]interface{}{{"id": "et_1", "name": "order.created", "schema": schema, "isEnabled": true}},
+				"pagination": map[string]interface{}{"hasMore": false},
+			})
+		case r.Method == "PATCH":
+			w.Write([]byte(`{"data":{"id":"et_1","name":"order.created"}}`))
+		case r.URL.Path == "/api/send-event":
+			sends++
+			w.Write([]byte(`{"data":{"eventId":"evt_1","messagesQueued":1}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	ctx := context.Background()
+	valid := &SendMessageParams{EventType: "order.created", Payload: map[string]interface{}{"orderId": "o_1"}}
+	invalid := &SendMessageParams{EventType: "order.created", RawPayload: json.RawMessage(`{"orderId":42}`)}
+
+	// Disabled by default: nothing is fetched and the payload is sent as-is.
+	client := New("test_key", WithBaseURL(server.URL))
+	if _, err := client.Messages.Send(ctx, "app_1", invalid); err != nil || lookups != 0 || sends != 1 {
+		t.Fatalf("expected unvalidated send, got err=%v lookups=%d sends=%d", err, lookups, sends)
+	}
+
+	client = New("test_key", WithBaseURL(server.URL), WithOutboundSchemaValidation(true))
+	now := time.Now()
+	client.transport.schemaCache.now = func() time.Time { return now }
+
+	if _, err := client.Messages.Send(ctx, "app_1", valid); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err := client.Messages.Send(ctx, "app_1", invalid)
+	var verr *ValidationError
+	if !errors.As(err, &verr) || verr.Code != "schema_validation_error" {
+		t.Fatalf("expected schema ValidationError, got %v", err)
+	}
+	if len(verr.ValidationErrors["/orderId"]) != 1 {
+		t.Errorf("expected error at /orderId, got %v", verr.ValidationErrors)
+	}
+	if lookups != 1 || sends != 2 {
+		t.Errorf("expected one cached lookup and no send for the invalid payload, got lookups=%d sends=%d", lookups, sends)
+	}
+
+	now = now.Add(OutboundSchemaCacheTTL)
+	client.Messages.Send(ctx, "app_1", valid)
+	if lookups != 2 {
+		t.Errorf("expected refetch after TTL, got %d lookups", lookups)
+	}
+
+	if _, err := client.EventTypes.Update(ctx, "et_1", &UpdateEventTypeParams{Description: Ptr("x")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result, err := client.EventTypes.ValidatePayload(ctx, "order.created", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lookups != 3 {
+		t.Errorf("expected refetch after Update, got %d lookups", lookups)
+	}
+	if result.Valid || len(result.Errors) != 1 {
+		t.Errorf("expected missing orderId to fail validation, got %+v", result)
+	}
+}
+
+func TestEventTypesArchiveAndEnableFields(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body = nil
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"data":{"id":"et_1","name":"order.created"}}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	ctx := context.Background()
+	cases := []struct {
+		name string
+		call func() (*EventType, error)
+		want map[string]interface{}
+	}{
+		{"Archive", func() (*EventType, error) { return client.EventTypes.Archive(ctx, "et_1") }, map[string]interface{}{"isArchived": true}},
+		{"Unarchive", func() (*EventType, error) { return client.EventTypes.Unarchive(ctx, "et_1") }, map[string]interface{}{"isArchived": false}},
+		{"Enable", func() (*EventType, error) { return client.EventTypes.Enable(ctx, "et_1") }, map[string]interface{}{"isEnabled": true}},
+		{"Disable", func() (*EventType, error) { return client.EventTypes.Disable(ctx, "et_1") }, map[string]interface{}{"isEnabled": false}},
+	}
+	for _, tc := range cases {
+		if _, err := tc.call(); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if !reflect.DeepEqual(body, tc.want) {
+			t.Errorf("%s: expected body %v, got %v", tc.name, tc.want, body)
+		}
+	}
+}
+
+func TestListEventTypesIncludeArchived(t *testing.T) {
+	q := (&ListEventTypesParams{IncludeArchived: Ptr(true)}).toQuery()
+	if q.Get("includeArchived") != "true" {
+		t.Errorf("expected includeArchived=true, got %v", q)
+	}
+	if q := (&ListEventTypesParams{}).toQuery(); q.Has("includeArchived") {
+		t.Errorf("expected includeArchived to be omitted, got %v", q)
+	}
+}
+
+func TestSubscriptionsSync(t *testing.T) {
+	existing := []map[string]interface{}{
+		{"id": "sub_1", "endpointId": "ep_1", "eventTypeId": "et_keep", "isEnabled": true},
+		{"id": "sub_2", "endpointId": "ep_1", "eventTypeId": "et_disabled", "isEnabled": false},
+		{"id": "sub_3", "endpointId": "ep_1", "eventTypeId": "et_extra", "isEnabled": true},
+		{"id": "sub_4", "endpointId": "ep_1", "eventTypeId": "et_keep", "isEnabled": true},
+	}
+	var subscribed, deleted []string
+	var enabled []string
+	listCalls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET":
+			listCalls++
+			if r.URL.Query().Get("endpointId") != "ep_1" {
+				t.Errorf("expected endpointId filter, got %s", r.URL.RawQuery)
+			}
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+			end := offset + 3
+			if end > len(existing) {
+				end = len(existing)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data":       existing[offset:end],
+				"pagination": map[string]interface{}{"hasMore": end < len(existing)},
+			})
+		case r.Method == "POST" && r.URL.Path == "/api/webhook-subscriptions/bulk":
+			var body struct {
+				EventTypeIDs []string `json:"eventTypeIds"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			subscribed = body.EventTypeIDs
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"created":       len(body.EventTypeIDs),
+				"subscriptions": []map[string]interface{}{{"id": "sub_new", "eventTypeId": body.EventTypeIDs[0], "isEnabled": true}},
+			})
+		case r.Method == "PATCH":
+			enabled = append(enabled, strings.TrimPrefix(r.URL.Path, "/api/webhook-subscriptions/"))
+			w.Write([]byte(`{"data":{"id":"sub_2","eventTypeId":"et_disabled","isEnabled":true}}`))
+		case r.Method == "DELETE":
+			var body struct {
+				IDs []string `json:"ids"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			deleted = body.IDs
+			json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "deleted": len(body.IDs)})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	desired := []string{"et_keep", "et_disabled", "et_new", "et_new"}
+
+	plan, err := client.Subscriptions.Sync(context.Background(), "app_1", "ep_1", desired, &SubscriptionSyncOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if subscribed != nil || enabled != nil || deleted != nil {
+		t.Fatal("dry run made changes")
+	}
+	if listCalls != 2 {
+		t.Errorf("expected existing subscriptions to be paginated, got %d list calls", listCalls)
+	}
+	if plan.Created != 1 || plan.Enabled != 1 || plan.Deleted != 2 || plan.Unchanged != 1 {
+		t.Errorf("unexpected plan: %+v", plan)
+	}
+
+	result, err := client.Subscriptions.Sync(context.Background(), "app_1", "ep_1", desired, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(subscribed, []string{"et_new"}) {
+		t.Errorf("expected et_new subscribed once, got %v", subscribed)
+	}
+	if !reflect.DeepEqual(enabled, []string{"sub_2"}) {
+		t.Errorf("expected sub_2 enabled, got %v", enabled)
+	}
+	if !reflect.DeepEqual(deleted, []string{"sub_3", "sub_4"}) {
+		t.Errorf("expected extra and duplicate subscriptions deleted, got %v", deleted)
+	}
+	if result.Created != 1 || result.Enabled != 1 || result.Deleted != 2 || result.Unchanged != 1 || len(result.Subscriptions) != 3 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestSubscriptionsBulkUnsubscribe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/webhook-subscriptions/bulk-unsubscribe" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			EndpointID   string   `json:"endpointId"`
+			EventTypeIDs []string `json:"eventTypeIds"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.EndpointID != "ep_1" || len(body.EventTypeIDs) != 2 {
+			t.Errorf("unexpected body: %+v", body)
+		}
+		w.Write([]byte(`{"deleted":1,"notFound":1,"results":[{"eventTypeId":"et_1","status":"deleted"},{"eventTypeId":"et_2","status":"not_found"}]}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	result, err := client.Subscriptions.BulkUnsubscribe(context.Background(), "ep_1", []string{"et_1", "et_2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Deleted != 1 || result.NotFound != 1 || result.Results[1].Status != UnsubscribeNotFound {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if _, err := client.Subscriptions.BulkUnsubscribe(context.Background(), "ep_1", nil); err == nil {
+		t.Error("expected error for empty event type list")
+	}
+}
+
+func TestSubscriptionsFind(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("endpointId") != "ep_1" {
+			t.Errorf("expected endpointId filter, got %s", r.URL.RawQuery)
+		}
+		data := []map[string]interface{}{}
+		if q.Get("eventTypeId") == "et_1" {
+			data = append(data, map[string]interface{}{"id": "sub_1", "endpointId": "ep_1", "eventTypeId": "et_1"})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": data, "pagination": map[string]interface{}{"hasMore": false}})
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	sub, err := client.Subscriptions.Find(context.Background(), "app_1", "ep_1", "et_1")
+	if err != nil || sub.ID != "sub_1" {
+		t.Fatalf("expected sub_1, got %+v (%v)", sub, err)
+	}
+	_, err = client.Subscriptions.Find(context.Background(), "app_1", "ep_1", "et_2")
+	var nf *NotFoundError
+	if !errors.As(err, &nf) {
+		t.Errorf("expected NotFoundError, got %v", err)
+	}
+}
+
+func TestWithLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req_123")
+		w.Write([]byte(`{"data":{"id":"app_1"}}`))
+	}))
+	defer server.Close()
+
+	var out strings.Builder
+	logger := slog.New(slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := New("test_key", WithBaseURL(server.URL), WithLogger(logger))
+	if _, err := client.Applications.Get(context.Background(), "app_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(out.String()), &entry); err != nil {
+		t.Fatalf("expected one JSON log line, got %q", out.String())
+	}
+	if entry["level"] != "DEBUG" || entry["method"] != "GET" || entry["path"] != "/api/webhook-applications/app_1" ||
+		entry["status_code"] != float64(200) || entry["request_id"] != "req_123" {
+		t.Errorf("unexpected log entry: %v", entry)
+	}
+	if _, ok := entry["duration_ms"]; !ok {
+		t.Error("expected duration_ms attribute")
+	}
+	if _, ok := entry["response_body"]; ok {
+		t.Error("bodies should only be logged in debug mode")
+	}
+
+	out.Reset()
+	client = New("test_key", WithBaseURL(server.URL), WithLogger(logger), WithDebug(true))
+	client.Applications.Get(context.Background(), "app_1")
+	if !strings.Contains(out.String(), `"level":"INFO"`) || !strings.Contains(out.String(), `"response_body"`) {
+		t.Errorf("expected info entry with bodies in debug mode, got %q", out.String())
+	}
+}
+
+func TestInterceptors(t *testing.T) {
+	var tenant string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant = r.Header.Get("X-Tenant")
+		w.Write([]byte(`{"data":{"id":"app_1"}}`))
+	}))
+	defer server.Close()
+
+	var calls []string
+	var seenBody string
+	client := New("test_key", WithBaseURL(server.URL),
+		WithRequestInterceptor(func(req *http.Request) error {
+			calls = append(calls, "req1")
+			req.Header.Set("X-Tenant", "acme-1")
+			return nil
+		}),
+		WithRequestInterceptor(func(req *http.Request) error {
+			calls = append(calls, "req2")
+			req.Header.Set("X-Tenant", "acme-2")
+			return nil
+		}),
+		WithResponseInterceptor(func(resp *http.Response, body []byte) error {
+			calls = append(calls, "resp")
+			seenBody = string(body)
+			return nil
+		}),
+	)
+	if _, err := client.Applications.Get(context.Background(), "app_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tenant != "acme-2" {
+		t.Errorf("expected X-Tenant=acme-2, got %q", tenant)
+	}
+	if want := []string{"req1", "req2", "resp"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("expected %v, got %v", want, calls)
+	}
+	if seenBody != `{"data":{"id":"app_1"}}` {
+		t.Errorf("unexpected body %q", seenBody)
+	}
+
+	errStop := errors.New("stop")
+	client = New("test_key", WithBaseURL(server.URL),
+		WithResponseInterceptor(func(*http.Response, []byte) error { return errStop }))
+	if _, err := client.Applications.Get(context.Background(), "app_1"); err != errStop {
+		t.Errorf("expected response interceptor error, got %v", err)
+	}
+
+	requests := 0
+	client = New("test_key", WithBaseURL(server.URL),
+		WithRequestInterceptor(func(*http.Request) error { requests++; return errStop }))
+	if _, err := client.Applications.Get(context.Background(), "app_1"); err != errStop || requests != 1 {
+		t.Errorf("expected request interceptor error without retries, got %v after %d calls", err, requests)
+	}
+}
+
+func TestDLQRetryByFilter(t *testing.T) {
+	pages := map[string]struct {
+		size int
+		next string
+	}{
+		"":   {100, "c2"},
+		"c2": {100, "c3"},
+		"c3": {30, ""},
+	}
+	var retried []string
+	failOn := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/outbound-messages/dlq/messages":
+			if r.URL.Query().Get("endpointId") != "ep_1" || r.URL.Query().Get("limit") != "100" {
+				t.Errorf("unexpected query %s", r.URL.RawQuery)
+			}
+			cursor := r.URL.Query().Get("cursor")
+			page := pages[cursor]
+			data := make([]map[string]string, page.size)
+			for i := range data {
+				data[i] = map[string]string{"id": cursor + "_dlq_" + strconv.Itoa(i)}
+			}
+			resp := map[string]interface{}{"data": data, "pagination": map[string]interface{}{"hasMore": page.next != "", "nextCursor": page.next}}
+			json.NewEncoder(w).Encode(resp)
+		case r.Method == "POST" && r.URL.Path == "/api/outbound-messages/dlq/retry-bulk":
+			var body struct {
+				MessageIDs []string `json:"messageIds"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			if len(body.MessageIDs) > MaxDLQBulkIDs {
+				t.Errorf("expected at most %d IDs per call, got %d", MaxDLQBulkIDs, len(body.MessageIDs))
+			}
+			if len(body.MessageIDs) > 0 && body.MessageIDs[0] == failOn {
+				w.WriteHeader(500)
+				w.Write([]byte(`{"error":{"message":"boom"}}`))
+				return
+			}
+			retried = append(retried, body.MessageIDs...)
+			fmt.Fprintf(w, `{"data":{"total":%d,"retried":%d,"failed":1}}`, len(body.MessageIDs), len(body.MessageIDs)-1)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL), WithMaxRetries(0))
+	ctx := context.Background()
+	// A Limit above MaxDLQBulkIDs is lowered to it.
+	params := &ListDLQParams{EndpointID: Ptr("ep_1"), Limit: Ptr(150)}
+
+	var progress [][2]int
+	result, err := client.DLQ.RetryByFilter(ctx, params, func(done, total int) {
+		progress = append(progress, [2]int{done, total})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := [][2]int{{100, 100}, {200, 200}, {230, 230}}; !reflect.DeepEqual(progress, want) {
+		t.Errorf("expected progress %v, got %v", want, progress)
+	}
+	if result.Processed != 230 || result.Succeeded != 227 || result.Failed != 3 || result.LastCursor != nil || len(retried) != 230 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if params.Cursor != nil || *params.Limit != 150 {
+		t.Error("params should not be modified")
+	}
+
+	// Fail on the second page, then resume from LastCursor: every message is retried
+	// exactly once across both runs.
+	retried, failOn = nil, "c2_dlq_0"
+	result, err = client.DLQ.RetryByFilter(ctx, params, nil)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if result.Processed != 100 || result.LastCursor == nil || *result.LastCursor != "c2" {
+		t.Fatalf("unexpected partial result: %+v", result)
+	}
+
+	failOn = ""
+	resume := *params
+	resume.Cursor = result.LastCursor
+	result, err = client.DLQ.RetryByFilter(ctx, &resume, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Processed != 130 || result.LastCursor != nil {
+		t.Errorf("unexpected resumed result: %+v", result)
+	}
+	seen := make(map[string]int)
+	for _, id := range retried {
+		seen[id]++
+	}
+	if len(retried) != 230 || len(seen) != 230 {
+		t.Errorf("expected each of 230 messages retried once, got %d retries of %d messages", len(retried), len(seen))
+	}
+}
+
+func TestDLQByFilterOffsetCursorWithRemoval(t *testing.T) {
+	// The fake pages with offset cursors and, like the API, removes retried and deleted
+	// messages from the DLQ. Every fiftieth retry fails and the message stays.
+	var dlq []string
+	reset := func() {
+		dlq = dlq[:0]
+		for i := 0; i < 250; i++ {
+			dlq = append(dlq, "dlq_"+strconv.Itoa(i))
+		}
+	}
+	remove := func(ids []string, keep func(id string) bool) int {
+		drop := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			drop[id] = !keep(id)
+		}
+		kept, removed := dlq[:0], 0
+		for _, id := range dlq {
+			if drop[id] {
+				removed++
+			} else {
+				kept = append(kept, id)
+			}
+		}
+		dlq = kept
+		return removed
+	}
+	failing := func(id string) bool {
+		n, _ := strconv.Atoi(strings.TrimPrefix(id, "dlq_"))
+		return n%50 == 0
+	}
+	calls := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			MessageIDs []string `json:"messageIds"`
+		}
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/outbound-messages/dlq/messages":
+			offset, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+			end := offset + limit
+			if end > len(dlq) {
+				end = len(dlq)
+			}
+			data := []map[string]string{}
+			for _, id := range dlq[min(offset, end):end] {
+				data = append(data, map[string]string{"id": id})
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": data, "pagination": map[string]interface{}{"hasMore": end < len(dlq), "nextCursor": strconv.Itoa(end)}})
+		case r.Method == "POST" && r.URL.Path == "/api/outbound-messages/dlq/retry-bulk":
+			json.NewDecoder(r.Body).Decode(&body)
+			for _, id := range body.MessageIDs {
+				calls[id]++
+			}
+			retried := remove(body.MessageIDs, failing)
+			fmt.Fprintf(w, `{"data":{"total":%d,"retried":%d,"failed":%d}}`, len(body.MessageIDs), retried, len(body.MessageIDs)-retried)
+		case r.Method == "DELETE" && r.URL.Path == "/api/outbound-messages/dlq/bulk":
+			json.NewDecoder(r.Body).Decode(&body)
+			for _, id := range body.MessageIDs {
+				calls[id]++
+			}
+			fmt.Fprintf(w, `{"data":{"total":%d,"deleted":%d}}`, len(body.MessageIDs), remove(body.MessageIDs, func(string) bool { return false }))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	reset()
+	result, err := client.DLQ.RetryByFilter(ctx, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Processed != 250 || result.Succeeded != 245 || result.Failed != 5 || result.LastCursor != nil {
+		t.Errorf("unexpected retry result: %+v", result)
+	}
+	if len(calls) != 250 {
+		t.Errorf("expected all 250 messages retried, got %d", len(calls))
+	}
+	for id, n := range calls {
+		if n != 1 {
+			t.Errorf("expected %s retried once, got %d", id, n)
+		}
+	}
+	if want := []string{"dlq_0", "dlq_50", "dlq_100", "dlq_150", "dlq_200"}; !reflect.DeepEqual(dlq, want) {
+		t.Errorf("expected only failed retries left in the DLQ, got %v", dlq)
+	}
+
+	reset()
+	calls = make(map[string]int)
+	result, err = client.DLQ.PurgeByFilter(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Processed != 250 || result.Succeeded != 250 || len(dlq) != 0 || len(calls) != 250 {
+		t.Errorf("unexpected purge result %+v, %d messages left", result, len(dlq))
+	}
+}
+
+func TestDLQPurgeOlderThan(t *testing.T) {
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/outbound-messages/dlq/messages":
+			if got := r.URL.Query().Get("dlqMovedBefore"); got != "2026-01-01T00:00:00Z" {
+				t.Errorf("unexpected dlqMovedBefore %q", got)
+			}
+			w.Write([]byte(`{"data":[{"id":"dlq_1"},{"id":"dlq_2"}],"pagination":{"hasMore":false}}`))
+		case r.Method == "DELETE" && r.URL.Path == "/api/outbound-messages/dlq/bulk":
+			var body struct {
+				MessageIDs []string `json:"messageIds"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			deleted = append(deleted, body.MessageIDs...)
+			w.Write([]byte(`{"data":{"total":2,"deleted":2}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	cutoff := time.Date(2026, 1, 1, 1, 0, 0, 0, time.FixedZone("CET", 3600))
+	result, err := client.DLQ.PurgeOlderThan(context.Background(), cutoff)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Succeeded != 2 || result.Failed != 0 || !reflect.DeepEqual(deleted, []string{"dlq_1", "dlq_2"}) {
+		t.Errorf("unexpected result %+v, deleted %v", result, deleted)
+	}
+}
+
+func TestWithOrganizationID(t *testing.T) {
+	var orgs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		orgs = append(orgs, r.Header.Get("X-Organization-ID"))
+		w.Write([]byte(`{"data":{"id":"app_1"}}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := New("test_key", WithBaseURL(server.URL), WithOrganizationID("org_1"))
+	client.Applications.Get(ctx, "app_1")
+	client.Applications.Get(ctx, "app_1", WithRequestOrganizationID("org_2"))
+	New("test_key", WithBaseURL(server.URL)).Applications.Get(ctx, "app_1")
+
+	if want := []string{"org_1", "org_2", ""}; !reflect.DeepEqual(orgs, want) {
+		t.Errorf("expected %v, got %v", want, orgs)
+	}
+}
+
+func TestWithResourceTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte(`{"data":{},"source":{"id":"src_1"}}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := New("test_key",
+		WithBaseURL(server.URL),
+		WithMaxRetries(0),
+		WithTimeout(20*time.Millisecond),
+		WithResourceTimeout("analytics", time.Second),
+	)
+
+	if _, err := client.Analytics.Dashboard(ctx, &DashboardParams{}); err != nil {
+		t.Errorf("expected analytics to use its resource timeout, got %v", err)
+	}
+	if _, err := client.Sources.Get(ctx, "src_1"); err == nil {
+		t.Error("expected sources to use the client timeout")
+	} else if _, ok := err.(*NetworkError); !ok {
+		t.Errorf("expected NetworkError, got %T", err)
+	}
+	if _, err := client.Sources.Get(ctx, "src_1", WithRequestTimeout(time.Second)); err != nil {
+		t.Errorf("expected request timeout to override the client timeout, got %v", err)
+	}
+	if _, err := client.Analytics.Dashboard(ctx, &DashboardParams{}, WithRequestTimeout(20*time.Millisecond)); err == nil {
+		t.Error("expected request timeout to override the resource timeout")
+	}
+}
+
+func TestResourceTimeoutNames(t *testing.T) {
+	client := New("test_key",
+		WithTimeout(time.Second),
+		WithResourceTimeout("Messages", 2*time.Second),
+		WithResourceTimeout("dlq", 3*time.Second),
+		WithResourceTimeout("apiKeys", 4*time.Second),
+	)
+	tests := []struct {
+		path string
+		want time.Duration
+	}{
+		{"/api/send-event", 2 * time.Second},
+		{"/api/send-events-batch", 2 * time.Second},
+		{"/api/outbound-messages/msg_1", 2 * time.Second},
+		{"/api/scheduled-messages/msg_1", 2 * time.Second},
+		{"/api/outbound-messages/dlq/messages", 3 * time.Second},
+		{"/api/outbound-messages/dlqueue", 2 * time.Second},
+		{"/api/api-keys/key_1", 4 * time.Second},
+		{"/api/sources", time.Second},
+	}
+	for _, tt := range tests {
+		if got := client.transport.timeoutFor(tt.path); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.path, tt.want, got)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "webhook-endpoints") {
+			t.Errorf("expected a panic for a path segment, got %v", r)
+		}
+	}()
+	WithResourceTimeout("webhook-endpoints", time.Second)
+}
+
+func TestWithRequestBaseURL(t *testing.T) {
+	var hits []string
+	handler := func(name string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits = append(hits, name+" "+r.URL.Path)
+			w.Write([]byte(`{"data":{"id":"app_1"}}`))
+		})
+	}
+	primary := httptest.NewServer(handler("primary"))
+	defer primary.Close()
+	regional := httptest.NewServer(handler("regional"))
+	defer regional.Close()
+
+	ctx := context.Background()
+	client := New("test_key", WithBaseURL(primary.URL))
+	client.Applications.Get(ctx, "app_1", WithRequestBaseURL(regional.URL))
+	client.Applications.Get(ctx, "app_1")
+
+	want := []string{"regional /api/webhook-applications/app_1", "primary /api/webhook-applications/app_1"}
+	if !reflect.DeepEqual(hits, want) {
+		t.Errorf("expected %v, got %v", want, hits)
+	}
+}
+
+func TestDLQGetStatsReasons(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/outbound-messages/dlq/stats" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"data":{
+			"total":42,
+			"byReason":{"max_retries_exceeded":30,"circuit_open":5,"quota_exceeded":4,"rate_limited":3},
+			"topFailingEndpoints":[{"endpointId":"ep_1","endpointUrl":"https://example.com/hook","count":30}]
+		}}`))
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	stats, err := client.DLQ.GetStats(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[DLQReason]int{ReasonMaxRetriesExceeded: 30, ReasonCircuitOpen: 5, ReasonOther: 7}
+	if got := stats.ByReasonTyped(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if stats.ByReason["quota_exceeded"] != 4 {
+		t.Errorf("expected raw reasons to be kept, got %v", stats.ByReason)
+	}
+	want2 := []DLQEndpointCount{{EndpointID: "ep_1", EndpointURL: "https://example.com/hook", Count: 30}}
+	if !reflect.DeepEqual(stats.TopFailingEndpoints, want2) {
+		t.Errorf("unexpected top failing endpoints: %+v", stats.TopFailingEndpoints)
+	}
+}
+
+func TestWithGzipCompression(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			w.Write([]byte(`{"data":{"id":"app_plain"}}`))
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		zw.Write([]byte(`{"data":{"id":"app_gzip"}}`))
+		zw.Close()
+	}))
+	defer server.Close()
+
+	// Disable net/http's own compression so the server only sees the header the SDK sets.
+	httpClient := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+	ctx := context.Background()
+	app, err := New("test_key", WithBaseURL(server.URL), WithHTTPClient(httpClient), WithGzipCompression(true)).Applications.Get(ctx, "app_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if app.ID != "app_gzip" {
+		t.Errorf("expected decompressed gzip response, got %q", app.ID)
+	}
+
+	app, err = New("test_key", WithBaseURL(server.URL), WithHTTPClient(httpClient)).Applications.Get(ctx, "app_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if app.ID != "app_plain" {
+		t.Errorf("expected uncompressed response by default, got %q", app.ID)
+	}
+}
+
+func TestPing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/health" || r.Method != "GET" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer hb_live_good" {
+			w.WriteHeader(401)
+			w.Write([]byte(`{"error":{"message":"Invalid API key","code":"invalid_api_key"}}`))
+			return
+		}
+		w.Write([]byte(`{"status":"ok","version":"1.42.0","apiVersion":"2024-06-01","organizationId":"org_1","keyPrefix":"hb_live_","scopes":["sources:read","messages:send"]}`))
+	}))
+	defer server.Close()
+
+	result, err := New("hb_live_good", WithBaseURL(server.URL)).Ping(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &PingResult{
+		Status:         "ok",
+		Version:        "1.42.0",
+		APIVersion:     "2024-06-01",
+		OrganizationID: "org_1",
+		KeyPrefix:      "hb_live_",
+		Scopes:         []string{ScopeSourcesRead, ScopeMessagesSend},
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("expected %+v, got %+v", want, result)
+	}
+	if err := result.RequireScopes(ScopeSourcesRead, ScopeMessagesSend); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err = result.RequireScopes(ScopeSourcesRead, ScopeDLQRead, ScopeCronWrite)
+	if err == nil || err.Error() != "hookbase: API key hb_live_... is missing scopes: dlq:read, cron:write" {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	_, err = New("hb_live_bad", WithBaseURL(server.URL)).Ping(context.Background())
+	authErr, ok := err.(*AuthenticationError)
+	if !ok {
+		t.Fatalf("expected AuthenticationError, got %T: %v", err, err)
+	}
+	if authErr.Status != 401 || authErr.Code != "invalid_api_key" {
+		t.Errorf("unexpected error: %+v", authErr)
+	}
+}
+
+func TestOrganizationGetAndUpdate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/organization" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		org := map[string]interface{}{
+			"id": "org_1", "name": "Acme", "slug": "acme", "plan": "pro",
+			"limits": map[string]interface{}{"eventsPerMonth": 1000000, "endpoints": nil, "members": 10, "retentionDays": 30},
+		}
+		if r.Method == "PATCH" {
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if len(body) != 2 || body["name"] != "Acme Inc" || body["notificationEmail"] != "ops@acme.test" {
+				t.Errorf("unexpected body: %v", body)
+			}
+			org["name"] = body["name"]
+			org["notificationEmail"] = body["notificationEmail"]
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": org})
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := New("test_key", WithBaseURL(server.URL))
+	org, err := client.Organization.Get(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if org.Plan != "pro" || *org.Limits.EventsPerMonth != 1000000 || org.Limits.Endpoints != nil || org.Limits.RetentionDays != 30 {
+		t.Errorf("unexpected organization: %+v", org)
+	}
+
+	org, err = client.Organization.Update(ctx, &UpdateOrganizationParams{Name: Ptr("Acme Inc"), NotificationEmail: Ptr("ops@acme.test")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if org.Name != "Acme Inc" || *org.NotificationEmail != "ops@acme.test" {
+		t.Errorf("unexpected organization: %+v", org)
+	}
+}
+
+func TestOrganizationMembers(t *testing.T) {
+	var removed []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/organization/members":
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": []map[string]interface{}{
+				{"id": "mem_1", "userId": "usr_1", "email": "owner@acme.test", "role": "owner", "status": "active", "joinedAt": "2024-01-01"},
+				{"id": "mem_2", "email": "new@acme.test", "role": "viewer", "status": "invited", "invitedAt": "2024-02-01"},
+			}})
+		case r.Method == "POST" && r.URL.Path == "/api/organization/members":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["email"] == "taken@acme.test" {
+				w.WriteHeader(409)
+				w.Write([]byte(`{"error":{"message":"already a member","code":"conflict","conflictingResourceId":"mem_9"}}`))
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"id": "mem_3", "email": body["email"], "role": body["role"], "status": "invited",
+			}})
+		case r.Method == "DELETE" && strings.HasPrefix(r.URL.Path, "/api/organization/members/"):
+			removed = append(removed, strings.TrimPrefix(r.URL.Path, "/api/organization/members/"))
+			w.WriteHeader(204)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := New("test_key", WithBaseURL(server.URL), WithMaxRetries(0))
+	members, err := client.Organization.ListMembers(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(members) != 2 || members[0].Role != RoleOwner || *members[0].UserID != "usr_1" || members[1].UserID != nil || members[1].Status != "invited" {
+		t.Errorf("unexpected members: %+v", members)
+	}
+
+	member, err := client.Organization.InviteMember(ctx, "dev@acme.test", RoleAdmin)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if member.ID != "mem_3" || member.Role != RoleAdmin || member.Email != "dev@acme.test" {
+		t.Errorf("unexpected member: %+v", member)
+	}
+	_, err = client.Organization.InviteMember(ctx, "taken@acme.test", RoleMember)
+	if conflict, ok := err.(*ConflictError); !ok || conflict.ConflictingResourceID != "mem_9" {
+		t.Errorf("expected ConflictError, got %v", err)
+	}
+	if _, err := client.Organization.InviteMember(ctx, "not-an-email", RoleMember); err == nil {
+		t.Error("expected validation error for invalid email")
+	} else if verr, ok := err.(*ValidationError); !ok || verr.Status != 0 {
+		t.Errorf("expected client-side ValidationError, got %v", err)
+	}
+
+	if err := client.Organization.RemoveMember(ctx, "mem_2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(removed, []string{"mem_2"}) {
+		t.Errorf("unexpected removals: %v", removed)
+	}
+}
+
+func TestOrganizationGetUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/organization/usage" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+			"plan": "starter", "periodStart": "2024-03-01", "periodEnd": "2024-03-31",
+			"events":        map[string]interface{}{"used": 10000, "limit": 10000},
+			"endpoints":     map[string]interface{}{"used": 4, "limit": nil},
+			"members":       map[string]interface{}{"used": 2, "limit": 3},
+			"retentionDays": 7,
+		}})
+	}))
+	defer server.Close()
+
+	usage, err := New("test_key", WithBaseURL(server.URL)).Organization.GetUsage(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if usage.Plan != "starter" || usage.RetentionDays != 7 || usage.Events.Used != 10000 || usage.Endpoints.Limit != nil {
+		t.Errorf("unexpected usage: %+v", usage)
+	}
+	if !usage.Events.Exceeded() || usage.Endpoints.Exceeded() || usage.Members.Exceeded() {
+		t.Errorf("unexpected Exceeded results: %+v", usage)
+	}
+}
+
+func TestUsageGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/usage" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+			"plan": "starter", "periodStart": "2024-03-01T00:00:00Z", "periodEnd": "2024-04-01T00:00:00Z",
+			"events":           map[string]interface{}{"used": 9000, "limit": 10000, "resetsAt": "2024-04-01T00:00:00Z"},
+			"outboundMessages": map[string]interface{}{"used": 100, "limit": 10000, "resetsAt": "2024-04-01T00:00:00Z"},
+			"dlqMessages":      map[string]interface{}{"used": 1000, "limit": 1000},
+			"endpoints":        map[string]interface{}{"used": 40, "limit": nil},
+			"sources":          map[string]interface{}{"used": 4, "limit": 5},
+		}})
+	}))
+	defer server.Close()
+
+	usage, err := New("test_key", WithBaseURL(server.URL)).Usage.Get(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if usage.Plan != "starter" || usage.Events.Used != 9000 || *usage.Events.Limit != 10000 || *usage.Events.ResetsAt != "2024-04-01T00:00:00Z" {
+		t.Errorf("unexpected usage: %+v", usage)
+	}
+	if usage.Endpoints.Limit != nil || usage.DLQMessages.ResetsAt != nil {
+		t.Errorf("expected unlimited endpoints and non-resetting DLQ quota: %+v", usage)
+	}
+
+	warnings := usage.NearingLimit(0.8)
+	var dims []string
+	for _, w := range warnings {
+		dims = append(dims, w.Dimension)
+	}
+	if !reflect.DeepEqual(dims, []string{"events", "dlqMessages", "sources"}) {
+		t.Errorf("unexpected warnings: %+v", warnings)
+	}
+	if w := warnings[0]; w.Used != 9000 || w.Limit != 10000 || w.Fraction != 0.9 || *w.ResetsAt != "2024-04-01T00:00:00Z" {
+		t.Errorf("unexpected events warning: %+v", w)
+	}
+	if warnings := usage.NearingLimit(1); len(warnings) != 1 || warnings[0].Dimension != "dlqMessages" {
+		t.Errorf("expected only the full DLQ at 100%%, got %+v", warnings)
+	}
+}
+
+func TestWithResponseMeta(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req_9")
+		w.Header().Set("X-Quota-Events-Used", "8123")
+		w.Header().Set("X-Quota-Events-Limit", "10000")
+		w.Header().Set("X-Quota-Events-Reset", "2024-04-01T00:00:00Z")
+		w.Header().Set("X-Quota-Dlq-Messages-Used", "12")
+		w.Header().Set("X-Quota-Bogus-Limit", "lots")
+		if r.URL.Path == "/api/sources/src_missing" {
+			w.WriteHeader(404)
+			w.Write([]byte(`{"error":{"message":"not found"}}`))
+			return
+		}
+		w.Write([]byte(`{"source":{"id":"src_1"}}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := New("test_key", WithBaseURL(server.URL))
+	var meta ResponseMeta
+	if _, err := client.Sources.Get(ctx, "src_1", WithResponseMeta(&meta)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if meta.StatusCode != 200 || meta.RequestID != "req_9" || meta.Header.Get("X-Quota-Events-Used") != "8123" {
+		t.Errorf("unexpected meta: %+v", meta)
+	}
+	events := meta.Quotas["events"]
+	if events.Used != 8123 || *events.Limit != 10000 || *events.ResetsAt != "2024-04-01T00:00:00Z" {
+		t.Errorf("unexpected events quota: %+v", events)
+	}
+	if dlq := meta.Quotas["dlq-messages"]; dlq.Used != 12 || dlq.Limit != nil {
+		t.Errorf("unexpected dlq quota: %+v", dlq)
+	}
+	if _, ok := meta.Quotas["bogus"]; ok {
+		t.Errorf("expected non-numeric quota header to be ignored: %+v", meta.Quotas)
+	}
+
+	var errMeta ResponseMeta
+	if _, err := client.Sources.Get(ctx, "src_missing", WithResponseMeta(&errMeta)); err == nil {
+		t.Fatal("expected error")
+	}
+	if errMeta.StatusCode != 404 || errMeta.Quotas["events"].Used != 8123 {
+		t.Errorf("expected meta for the error response, got %+v", errMeta)
+	}
+}
+
+func TestAuditLogsList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		want := "action=route.deleted&actor=usr_1&actorType=user&endDate=2024-03-31&limit=2&resourceId=rte_1&resourceType=route&startDate=2024-03-01"
+		if r.URL.Path != "/api/audit-logs" || r.URL.RawQuery != want {
+			t.Errorf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{{
+				"id": "aud_1", "actor": "usr_1", "actorType": "user", "action": "route.deleted",
+				"resourceType": "route", "resourceId": "rte_1", "ip": "203.0.113.7",
+				"metadata": map[string]interface{}{"name": "orders"}, "occurredAt": "2024-03-02T10:00:00Z",
+			}},
+			"pagination": map[string]interface{}{"hasMore": false},
+		})
+	}))
+	defer server.Close()
+
+	page, err := New("test_key", WithBaseURL(server.URL)).AuditLogs.List(context.Background(), &ListAuditLogsParams{
+		Limit:        Ptr(2),
+		Actor:        Ptr("usr_1"),
+		ActorType:    Ptr(ActorUser),
+		Action:       Ptr("route.deleted"),
+		ResourceType: Ptr("route"),
+		ResourceID:   Ptr("rte_1"),
+		StartDate:    Ptr("2024-03-01"),
+		EndDate:      Ptr("2024-03-31"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Data) != 1 || page.HasMore {
+		t.Fatalf("unexpected page: %+v", page)
+	}
+	e := page.Data[0]
+	if e.ActorType != ActorUser || e.ResourceID != "rte_1" || *e.IP != "203.0.113.7" || e.Metadata["name"] != "orders" || e.OccurredAt != "2024-03-02T10:00:00Z" {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+}
+
+func TestAuditLogsListAll(t *testing.T) {
+	var cursors []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		cursors = append(cursors, q.Get("cursor"))
+		if q.Get("limit") != "100" || q.Get("action") != "source.secret_rotated" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		page := map[string]interface{}{"hasMore": true, "nextCursor": "c2"}
+		ids := []string{"aud_1", "aud_2"}
+		if q.Get("cursor") == "c2" {
+			page = map[string]interface{}{"hasMore": false, "nextCursor": nil}
+			ids = []string{"aud_3"}
+		}
+		var data []map[string]interface{}
+		for _, id := range ids {
+			data = append(data, map[string]interface{}{"id": id, "action": "source.secret_rotated"})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": data, "pagination": page})
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	params := &ListAuditLogsParams{Action: Ptr("source.secret_rotated")}
+	var ids []string
+	err := client.AuditLogs.ListAll(context.Background(), params, func(e AuditLogEntry) error {
+		ids = append(ids, e.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(ids, []string{"aud_1", "aud_2", "aud_3"}) || !reflect.DeepEqual(cursors, []string{"", "c2"}) {
+		t.Errorf("unexpected ids %v or cursors %v", ids, cursors)
+	}
+	if params.Cursor != nil || params.Limit != nil {
+		t.Errorf("expected params to be left unchanged, got %+v", params)
+	}
+
+	stop := errors.New("stop")
+	cursors = nil
+	err = client.AuditLogs.ListAll(context.Background(), params, func(e AuditLogEntry) error { return stop })
+	if err != stop || len(cursors) != 1 {
+		t.Errorf("expected ListAll to stop on the callback error, got %v after %d pages", err, len(cursors))
+	}
+}
+
+func TestAuditLogsExport(t *testing.T) {
+	csv := "id,actor,action\naud_1,usr_1,route.deleted\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/api/audit-logs/export" || q.Get("format") != "csv" || q.Get("resourceType") != "route" || q.Has("cursor") || q.Has("limit") {
+			t.Errorf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte(csv))
+	}))
+	defer server.Close()
+
+	var buf strings.Builder
+	err := New("test_key", WithBaseURL(server.URL)).AuditLogs.Export(context.Background(),
+		&ListAuditLogsParams{ResourceType: Ptr("route"), Cursor: Ptr("c1"), Limit: Ptr(5)}, &buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != csv {
+		t.Errorf("unexpected export: %q", buf.String())
+	}
+}
+
+func TestNotificationsCreateChannel(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     NotificationChannelConfig
+		wantConfig string
+		secrets    []string
+	}{
+		{
+			name:       "email",
+			config:     &EmailChannelConfig{Recipients: []string{"ops@acme.test"}},
+			wantConfig: `{"recipients":["ops@acme.test"]}`,
+		},
+		{
+			name:       "slack",
+			config:     &SlackChannelConfig{WebhookURL: "https://hooks.slack.test/T000/B000/xyzzy", Channel: Ptr("#alerts")},
+			wantConfig: `{"webhookUrl":"https://hooks.slack.test/T000/B000/xyzzy","channel":"#alerts"}`,
+			secrets:    []string{"xyzzy"},
+		},
+		{
+			name:       "webhook",
+			config:     &WebhookChannelConfig{URL: "https://alerts.acme.test", Secret: Ptr("whsec_plugh"), Headers: map[string]string{"Authorization": "Bearer thud"}},
+			wantConfig: `{"url":"https://alerts.acme.test","secret":"whsec_plugh","headers":{"Authorization":"Bearer thud"}}`,
+			secrets:    []string{"whsec_plugh", "thud"},
+		},
+		{
+			name:       "pagerduty",
+			config:     &PagerDutyChannelConfig{RoutingKey: "R0UTINGKEY", Severity: Ptr("critical")},
+			wantConfig: `{"routingKey":"R0UTINGKEY","severity":"critical"}`,
+			secrets:    []string{"R0UTINGKEY"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "POST" || r.URL.Path != "/api/notifications/channels" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				var body map[string]json.RawMessage
+				json.NewDecoder(r.Body).Decode(&body)
+				if string(body["type"]) != strconv.Quote(tt.name) || string(body["config"]) != tt.wantConfig {
+					t.Errorf("unexpected body: type=%s config=%s", body["type"], body["config"])
+				}
+				fmt.Fprintf(w, `{"data":{"id":"nch_1","name":"Alerts","type":%s,"config":%s,"isActive":true}}`, body["type"], body["config"])
+			}))
+			defer server.Close()
+
+			var logs strings.Builder
+			logger := slog.New(slog.NewTextHandler(&logs, nil))
+			client := New("test_key", WithBaseURL(server.URL), WithLogger(logger), WithDebug(true))
+			channel, err := client.Notifications.CreateChannel(context.Background(), &CreateNotificationChannelParams{Name: "Alerts", Config: tt.config})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if channel.Type != NotificationChannelType(tt.name) || !reflect.DeepEqual(channel.Config, tt.config) {
+				t.Errorf("unexpected channel: %+v (config %+v)", channel, channel.Config)
+			}
+			for _, secret := range tt.secrets {
+				if strings.Contains(logs.String(), secret) {
+					t.Errorf("expected %s secret %q to be redacted from debug logs, got %s", tt.name, secret, logs.String())
+				}
+			}
+		})
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"api key", `{"data":{"id":"key_1","key":"hb_live_abc"}}`, `{"data":{"id":"key_1","key":"[REDACTED]"}}`},
+		{"signing secret", `{"source":{"id":"src_1","signingSecret":"whsec_abc"}}`, `{"source":{"id":"src_1","signingSecret":"[REDACTED]"}}`},
+		{"tunnel auth token", `{"data":{"tunnel":{"id":"tun_1"},"authToken":"tok_abc"}}`, `{"data":{"authToken":"[REDACTED]","tunnel":{"id":"tun_1"}}}`},
+		{"endpoint headers", `{"data":[{"id":"ep_1","headers":{"Authorization":"Bearer abc"}}]}`, `{"data":[{"headers":"[REDACTED]","id":"ep_1"}]}`},
+		{"password", `{"config":{"username":"ops","password":"hunter2"}}`, `{"config":{"password":"[REDACTED]","username":"ops"}}`},
+		{"not JSON", `key=abc`, `key=abc`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(redactBody([]byte(tt.body))); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNotificationsChannels(t *testing.T) {
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/notifications/channels":
+			w.Write([]byte(`{"data":[
+				{"id":"nch_1","name":"Ops email","type":"email","config":{"recipients":["ops@acme.test"]},"events":["route.failed"],"isActive":true},
+				{"id":"nch_2","name":"Pager","type":"pagerduty","config":{"routingKey":"[REDACTED]"},"isActive":false},
+				{"id":"nch_3","name":"Teams","type":"msteams","config":{"url":"https://teams.test"},"isActive":true}
+			]}`))
+		case r.Method == "PATCH" && r.URL.Path == "/api/notifications/channels/nch_1":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["type"] != "email" || body["isActive"] != false || body["name"] != nil {
+				t.Errorf("unexpected body: %v", body)
+			}
+			w.Write([]byte(`{"data":{"id":"nch_1","name":"Ops email","type":"email","config":{"recipients":["oncall@acme.test"]},"isActive":false}}`))
+		case r.Method == "DELETE" && r.URL.Path == "/api/notifications/channels/nch_2":
+			deleted = append(deleted, "nch_2")
+			w.WriteHeader(204)
+		case r.Method == "POST" && r.URL.Path == "/api/notifications/channels/nch_1/test":
+			w.Write([]byte(`{"data":{"success":false,"statusCode":550,"error":"mailbox unavailable","durationMs":120}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := New("test_key", WithBaseURL(server.URL))
+	channels, err := client.Notifications.ListChannels(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(channels) != 3 {
+		t.Fatalf("expected 3 channels, got %d", len(channels))
+	}
+	if email, ok := channels[0].Config.(*EmailChannelConfig); !ok || email.Recipients[0] != "ops@acme.test" || channels[0].Events[0] != "route.failed" {
+		t.Errorf("unexpected email channel: %+v", channels[0])
+	}
+	if pd, ok := channels[1].Config.(*PagerDutyChannelConfig); !ok || bool(channels[1].IsActive) || pd.RoutingKey != "[REDACTED]" {
+		t.Errorf("unexpected pagerduty channel: %+v", channels[1])
+	}
+	if channels[2].Type != "msteams" || channels[2].Config != nil {
+		t.Errorf("expected unknown channel type to have nil config, got %+v", channels[2])
+	}
+
+	updated, err := client.Notifications.UpdateChannel(ctx, "nch_1", &UpdateNotificationChannelParams{
+		Config:   &EmailChannelConfig{Recipients: []string{"oncall@acme.test"}},
+		IsActive: Ptr(false),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Config.(*EmailChannelConfig).Recipients[0] != "oncall@acme.test" {
+		t.Errorf("unexpected channel: %+v", updated)
+	}
+
+	result, err := client.Notifications.TestChannel(ctx, "nch_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Success || *result.StatusCode != 550 || *result.Error != "mailbox unavailable" || result.DurationMs != 120 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	if err := client.Notifications.DeleteChannel(ctx, "nch_2"); err != nil || len(deleted) != 1 {
+		t.Errorf("unexpected delete result: %v %v", err, deleted)
+	}
+
+	if _, err := client.Notifications.CreateChannel(ctx, &CreateNotificationChannelParams{Name: "Empty"}); err == nil {
+		t.Error("expected validation error for missing config")
+	}
+}
+
+func TestIngest(t *testing.T) {
+	const secret = "whsec_dGVzdF9pbmdlc3Rfc2VjcmV0"
+	payload := []byte(`{"type":"order.created","id":"ord_1"}`)
+	var reveals int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/sources/src_1/reveal-secret":
+			reveals++
+			if r.Header.Get("Authorization") != "Bearer test_key" {
+				t.Errorf("expected API key on reveal-secret, got %q", r.Header.Get("Authorization"))
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"signingSecret": secret})
+		case "/ingest/org/orders":
+			if auth := r.Header.Get("Authorization"); auth != "" {
+				t.Errorf("expected no Authorization header on ingest, got %q", auth)
+			}
+			body, _ := io.ReadAll(r.Body)
+			if !bytes.Equal(body, payload) {
+				t.Errorf("unexpected body: %s", body)
+			}
+			if r.Header.Get("X-Signed") == "true" {
+				if err := NewWebhook(secret).VerifyWithHTTPHeader(body, r.Header); err != nil {
+					t.Errorf("expected valid signature: %v", err)
+				}
+			} else if r.Header.Get("webhook-signature") != "" {
+				t.Error("expected unsigned request")
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "eventId": "evt_" + r.Header.Get("X-Case")})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := New("test_key", WithBaseURL(server.URL))
+	source := &Source{ID: "src_1", IngestURL: Ptr(server.URL + "/ingest/org/orders")}
+
+	t.Run("unsigned", func(t *testing.T) {
+		id, err := client.Ingest(ctx, source, payload, WithIngestHeader("X-Case", "plain"))
+		if err != nil || id != "evt_plain" {
+			t.Errorf("unexpected result: %q, %v", id, err)
+		}
+	})
+
+	t.Run("signed with revealed secret", func(t *testing.T) {
+		id, err := client.Ingest(ctx, source, payload, WithIngestSigning(),
+			WithIngestHeader("X-Signed", "true"), WithIngestHeader("X-Case", "revealed"))
+		if err != nil || id != "evt_revealed" || reveals != 1 {
+			t.Errorf("unexpected result: %q, %v, %d reveals", id, err, reveals)
+		}
+	})
+
+	t.Run("signed with given secret", func(t *testing.T) {
+		id, err := client.Ingest(ctx, source, payload, WithIngestSecret(secret),
+			WithIngestHeader("X-Signed", "true"), WithIngestHeader("X-Case", "given"))
+		if err != nil || id != "evt_given" || reveals != 1 {
+			t.Errorf("unexpected result: %q, %v, %d reveals", id, err, reveals)
+		}
+	})
+
+	t.Run("missing ingest URL", func(t *testing.T) {
+		_, err := client.Ingest(ctx, &Source{ID: "src_2"}, payload)
+		if !IsErrorCode(err, CodeClientValidation) {
+			t.Errorf("expected client validation error, got %v", err)
+		}
+	})
+}
+
+func TestIngestHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/x-www-form-urlencoded" {
+			t.Errorf("unexpected Content-Type: %q", ct)
+		}
+		if r.Header.Get("X-GitHub-Event") != "push" || r.Header.Get("X-GitHub-Delivery") != "d-1" {
+			t.Errorf("missing custom headers: %v", r.Header)
+		}
+		if r.Header.Get("webhook-id") != "msg_fixed" {
+			t.Errorf("expected custom webhook-id to be signed, got %q", r.Header.Get("webhook-id"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		if err := NewWebhook("whsec_c2VjcmV0").VerifyWithHTTPHeader(body, r.Header); err != nil {
+			t.Errorf("expected valid signature: %v", err)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "eventId": "evt_1"})
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL("http://unused.invalid"))
+	source := &Source{ID: "src_1", IngestURL: Ptr(server.URL + "/ingest/org/gh"), SigningSecret: Ptr("whsec_c2VjcmV0")}
+	id, err := client.Ingest(context.Background(), source, []byte("payload=%7B%7D"),
+		WithIngestContentType("application/x-www-form-urlencoded"),
+		WithIngestHeader("X-GitHub-Event", "push"),
+		WithIngestHeader("X-GitHub-Delivery", "d-1"),
+		WithIngestHeader("webhook-id", "msg_fixed"),
+		WithIngestSigning(),
+	)
+	if err != nil || id != "evt_1" {
+		t.Errorf("unexpected result: %q, %v", id, err)
+	}
+}
+
+func TestIngestRateLimited(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(429)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]string{"message": "Source rate limit exceeded", "code": "rate_limit_exceeded"},
+		})
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	source := &Source{ID: "src_1", IngestURL: Ptr(server.URL + "/ingest/org/orders")}
+	_, err := client.Ingest(context.Background(), source, []byte(`{}`))
+	var rateErr *RateLimitError
+	if !errors.As(err, &rateErr) || rateErr.RetryAfter != 7 || !errors.Is(err, ErrRateLimit) {
+		t.Fatalf("expected RateLimitError with RetryAfter 7, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected ingest not to be retried, got %d calls", calls)
+	}
+}
@@ -0,0 +1,20 @@
+module github.com/HookbaseApp/hookbase-go/hookbaseotel
+
+go 1.21
+
+require (
+	github.com/HookbaseApp/hookbase-go v0.2.0
+	go.opentelemetry.io/otel v1.28.0
+	go.opentelemetry.io/otel/trace v1.28.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	go.opentelemetry.io/otel/metric v1.28.0 // indirect
+)
+
+// hookbase-go v0.2.0, the first release with the Tracer interface, is not tagged yet.
+// Until it is, this module builds only inside the repository. Remove this directive
+// before tagging hookbaseotel/v0.1.0.
+replace github.com/HookbaseApp/hookbase-go => ../
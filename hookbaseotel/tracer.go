@@ -0,0 +1,71 @@
+// Package hookbaseotel traces Hookbase API calls with OpenTelemetry. It is a separate
+// module so that the hookbase package does not depend on OpenTelemetry:
+//
+//	client := hookbase.New(apiKey, hookbase.WithTracing(hookbaseotel.NewTracer(otel.Tracer("my-service"))))
+//
+// It requires hookbase-go v0.2.0 or later, the first release with hookbase.Tracer.
+// Until that release and hookbaseotel/v0.1.0 are tagged, the module builds only
+// inside the hookbase-go repository.
+package hookbaseotel
+
+import (
+	"context"
+	"net/http"
+
+	hookbase "github.com/HookbaseApp/hookbase-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewTracer returns a hookbase.Tracer that wraps every API call, including its
+// retries, in a client span named "hookbase.{method} {path}" and propagates the trace
+// context to the API using the global propagator. Spans carry http.method, http.url,
+// http.status_code, hookbase.request_id, and hookbase.resource attributes, and failed
+// calls record the error.
+func NewTracer(tracer trace.Tracer) hookbase.Tracer {
+	return &otelTracer{tracer: tracer}
+}
+
+type otelTracer struct {
+	tracer trace.Tracer
+}
+
+func (t *otelTracer) StartCall(ctx context.Context, call hookbase.TracedCall) (context.Context, hookbase.CallSpan) {
+	ctx, span := t.tracer.Start(ctx, "hookbase."+call.Method+" "+call.Path,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.method", call.Method),
+			attribute.String("http.url", call.URL),
+			attribute.String("hookbase.resource", call.Resource),
+		),
+	)
+	return ctx, &callSpan{ctx: ctx, span: span}
+}
+
+// callSpan keeps the context holding span, which the propagator reads from.
+type callSpan struct {
+	ctx  context.Context
+	span trace.Span
+}
+
+func (s *callSpan) InjectHeaders(header http.Header) {
+	otel.GetTextMapPropagator().Inject(s.ctx, propagation.HeaderCarrier(header))
+}
+
+func (s *callSpan) RecordResponse(status int, requestID string) {
+	s.span.SetAttributes(
+		attribute.Int("http.status_code", status),
+		attribute.String("hookbase.request_id", requestID),
+	)
+}
+
+func (s *callSpan) End(err error) {
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+	s.span.End()
+}
@@ -0,0 +1,96 @@
+package hookbaseotel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	hookbase "github.com/HookbaseApp/hookbase-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+type recordingTracer struct {
+	noop.Tracer
+	spans []*recordingSpan
+}
+
+func (tr *recordingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	cfg := trace.NewSpanStartConfig(opts...)
+	span := &recordingSpan{name: name, attrs: map[attribute.Key]attribute.Value{}}
+	for _, kv := range cfg.Attributes() {
+		span.attrs[kv.Key] = kv.Value
+	}
+	tr.spans = append(tr.spans, span)
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+type recordingSpan struct {
+	noop.Span
+	name   string
+	attrs  map[attribute.Key]attribute.Value
+	errs   []error
+	status codes.Code
+	ended  bool
+}
+
+func (s *recordingSpan) SetAttributes(kv ...attribute.KeyValue) {
+	for _, a := range kv {
+		s.attrs[a.Key] = a.Value
+	}
+}
+
+func (s *recordingSpan) RecordError(err error, _ ...trace.EventOption) { s.errs = append(s.errs, err) }
+func (s *recordingSpan) SetStatus(code codes.Code, _ string)           { s.status = code }
+func (s *recordingSpan) End(...trace.SpanEndOption)                    { s.ended = true }
+
+func TestNewTracer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req_123")
+		if r.URL.Path == "/api/webhook-endpoints/ep_missing" {
+			w.WriteHeader(404)
+			w.Write([]byte(`{"error":{"message":"not found"}}`))
+			return
+		}
+		w.Write([]byte(`{"data":{"id":"app_1"}}`))
+	}))
+	defer server.Close()
+
+	tracer := &recordingTracer{}
+	client := hookbase.New("test_key", hookbase.WithBaseURL(server.URL), hookbase.WithTracing(NewTracer(tracer)))
+	if _, err := client.Applications.Get(context.Background(), "app_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Endpoints.Get(context.Background(), "app_1", "ep_missing"); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if len(tracer.spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(tracer.spans))
+	}
+	ok := tracer.spans[0]
+	if ok.name != "hookbase.GET /api/webhook-applications/app_1" {
+		t.Errorf("unexpected span name %q", ok.name)
+	}
+	if ok.attrs["http.method"].AsString() != "GET" ||
+		ok.attrs["http.url"].AsString() != server.URL+"/api/webhook-applications/app_1" ||
+		ok.attrs["http.status_code"].AsInt64() != 200 ||
+		ok.attrs["hookbase.request_id"].AsString() != "req_123" ||
+		ok.attrs["hookbase.resource"].AsString() != "webhook-applications" {
+		t.Errorf("unexpected attributes: %v", ok.attrs)
+	}
+	if !ok.ended || len(ok.errs) != 0 || ok.status != codes.Unset {
+		t.Errorf("expected successful ended span, got %+v", ok)
+	}
+
+	failed := tracer.spans[1]
+	if failed.attrs["http.status_code"].AsInt64() != 404 || failed.attrs["hookbase.resource"].AsString() != "webhook-endpoints" {
+		t.Errorf("unexpected attributes: %v", failed.attrs)
+	}
+	if _, notFound := failed.errs[0].(*hookbase.NotFoundError); !notFound || failed.status != codes.Error || !failed.ended {
+		t.Errorf("expected recorded NotFoundError, got %+v", failed)
+	}
+}
@@ -0,0 +1,49 @@
+package hookbasetest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/HookbaseApp/hookbase-go"
+)
+
+// FakeMessages is a hookbase.MessagesAPI whose Send, Get, and List call the matching
+// func field. It records the parameters of every Send. Calling a method whose func
+// field is nil, or any other MessagesAPI method, panics.
+type FakeMessages struct {
+	hookbase.MessagesAPI
+
+	SendFunc func(ctx context.Context, applicationID string, params *hookbase.SendMessageParams) (*hookbase.SendMessageResponse, error)
+	GetFunc  func(ctx context.Context, applicationID, messageID string) (*hookbase.OutboundMessage, error)
+	ListFunc func(ctx context.Context, applicationID string, params *hookbase.ListOutboundMessagesParams) (*hookbase.CursorResponse[hookbase.OutboundMessage], error)
+
+	mu   sync.Mutex
+	sent []*hookbase.SendMessageParams
+}
+
+var _ hookbase.MessagesAPI = (*FakeMessages)(nil)
+
+// Send records params and calls SendFunc.
+func (f *FakeMessages) Send(ctx context.Context, applicationID string, params *hookbase.SendMessageParams, opts ...hookbase.RequestOption) (*hookbase.SendMessageResponse, error) {
+	f.mu.Lock()
+	f.sent = append(f.sent, params)
+	f.mu.Unlock()
+	return f.SendFunc(ctx, applicationID, params)
+}
+
+// Get calls GetFunc.
+func (f *FakeMessages) Get(ctx context.Context, applicationID, messageID string, opts ...hookbase.RequestOption) (*hookbase.OutboundMessage, error) {
+	return f.GetFunc(ctx, applicationID, messageID)
+}
+
+// List calls ListFunc.
+func (f *FakeMessages) List(ctx context.Context, applicationID string, params *hookbase.ListOutboundMessagesParams, opts ...hookbase.RequestOption) (*hookbase.CursorResponse[hookbase.OutboundMessage], error) {
+	return f.ListFunc(ctx, applicationID, params)
+}
+
+// Sent returns the parameters of every Send call, in order.
+func (f *FakeMessages) Sent() []*hookbase.SendMessageParams {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*hookbase.SendMessageParams(nil), f.sent...)
+}
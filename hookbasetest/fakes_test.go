@@ -0,0 +1,58 @@
+package hookbasetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/HookbaseApp/hookbase-go"
+)
+
+// notifyOrderShipped stands in for application code that depends on the interface.
+func notifyOrderShipped(ctx context.Context, api *hookbase.Interfaces, appID, orderID string) (string, error) {
+	resp, err := api.Messages.Send(ctx, appID, &hookbase.SendMessageParams{
+		EventType: "order.shipped",
+		Payload:   map[string]interface{}{"orderId": orderID},
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.MessageID, nil
+}
+
+func TestFakeMessages(t *testing.T) {
+	fake := &FakeMessages{
+		SendFunc: func(ctx context.Context, applicationID string, params *hookbase.SendMessageParams) (*hookbase.SendMessageResponse, error) {
+			if applicationID != "app_1" {
+				t.Errorf("unexpected application %q", applicationID)
+			}
+			return &hookbase.SendMessageResponse{MessageID: "msg_1", MessagesQueued: 1}, nil
+		},
+	}
+
+	id, err := notifyOrderShipped(context.Background(), &hookbase.Interfaces{Messages: fake}, "app_1", "ord_9")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != "msg_1" {
+		t.Errorf("expected msg_1, got %q", id)
+	}
+	sent := fake.Sent()
+	if len(sent) != 1 || sent[0].EventType != "order.shipped" || sent[0].Payload["orderId"] != "ord_9" {
+		t.Errorf("unexpected sent params: %+v", sent)
+	}
+}
+
+func TestClientInterfaces(t *testing.T) {
+	srv := NewServer(t)
+	srv.SeedApplication(hookbase.Application{ID: "app_1", Name: "Acme"})
+	srv.SeedEndpoint(hookbase.Endpoint{ID: "ep_1", ApplicationID: "app_1", URL: "https://example.com/hook"})
+
+	api := srv.Client().Interfaces()
+	id, err := notifyOrderShipped(context.Background(), api, "app_1", "ord_9")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msgs := srv.OutboundMessages("app_1"); len(msgs) != 1 || msgs[0].MessageID != id {
+		t.Errorf("unexpected outbound messages: %+v", msgs)
+	}
+}
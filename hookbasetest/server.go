@@ -0,0 +1,632 @@
+package hookbasetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/HookbaseApp/hookbase-go"
+)
+
+// DefaultAPIKey is the API key a Server accepts unless Server.APIKey is changed.
+const DefaultAPIKey = "test_key"
+
+const (
+	defaultPageSize = 20
+	defaultLimit    = 50
+)
+
+// resourceDef describes how a collection is routed and enveloped.
+type resourceDef struct {
+	name     string
+	path     string
+	listKey  string
+	itemKey  string
+	idPrefix string
+	// cursor selects {"data", "pagination": {hasMore, nextCursor}} list envelopes
+	// instead of {listKey, "pagination": {total, page, pageSize}}.
+	cursor   bool
+	readOnly bool
+	defaults func(item map[string]interface{})
+}
+
+var (
+	sourcesDef = &resourceDef{
+		name: "source", path: "/api/sources", listKey: "sources", itemKey: "source", idPrefix: "src",
+		defaults: func(item map[string]interface{}) {
+			setDefault(item, "slug", slugify(item["name"]))
+			setDefault(item, "provider", "generic")
+			setDefault(item, "isActive", true)
+			setDefault(item, "verifySignature", false)
+			setDefault(item, "dedupStrategy", "none")
+			setDefault(item, "ipFilterMode", "none")
+			setDefault(item, "signingSecret", "whsec_"+item["id"].(string))
+			setDefault(item, "eventCount", 0)
+		},
+	}
+	destinationsDef = &resourceDef{
+		name: "destination", path: "/api/destinations", listKey: "destinations", itemKey: "destination", idPrefix: "dst",
+		defaults: func(item map[string]interface{}) {
+			setDefault(item, "slug", slugify(item["name"]))
+			setDefault(item, "method", "POST")
+			setDefault(item, "authType", "none")
+			setDefault(item, "timeout", 30)
+			setDefault(item, "retryCount", 3)
+			setDefault(item, "retryInterval", 60)
+			setDefault(item, "isActive", true)
+			setDefault(item, "deliveryCount", 0)
+		},
+	}
+	routesDef = &resourceDef{
+		name: "route", path: "/api/routes", listKey: "routes", itemKey: "route", idPrefix: "rte",
+		defaults: func(item map[string]interface{}) {
+			setDefault(item, "priority", 0)
+			setDefault(item, "isActive", true)
+		},
+	}
+	applicationsDef = &resourceDef{
+		name: "application", path: "/api/webhook-applications", listKey: "data", itemKey: "data", idPrefix: "app", cursor: true,
+		defaults: func(item map[string]interface{}) {
+			if uid, ok := item["externalId"]; ok {
+				item["uid"] = uid
+				delete(item, "externalId")
+			}
+			setDefault(item, "organizationId", "org_test")
+		},
+	}
+	endpointsDef = &resourceDef{
+		name: "endpoint", path: "/api/webhook-endpoints", listKey: "data", itemKey: "data", idPrefix: "ep", cursor: true,
+		defaults: func(item map[string]interface{}) {
+			setDefault(item, "isDisabled", false)
+			setDefault(item, "circuitState", "closed")
+			setDefault(item, "secret", "whsec_"+item["id"].(string))
+			setDefault(item, "totalMessages", 0)
+			setDefault(item, "totalSuccesses", 0)
+			setDefault(item, "totalFailures", 0)
+		},
+	}
+	messagesDef = &resourceDef{
+		name: "outbound message", path: "/api/outbound-messages", listKey: "data", itemKey: "data", idPrefix: "msg", cursor: true, readOnly: true,
+	}
+	resourceDefs = []*resourceDef{sourcesDef, destinationsDef, routesDef, applicationsDef, endpointsDef, messagesDef}
+)
+
+// Server is an in-memory fake of the Hookbase API for tests. It serves sources,
+// destinations, routes, applications, endpoints, and outbound messages with the same
+// response envelopes and pagination as the real API, so a *hookbase.Client pointed at
+// it behaves as it would in production. Client.Ping reports organization "org_test".
+//
+// Every request must carry "Authorization: Bearer " + APIKey; other requests get a
+// 401. Writes that carry an Idempotency-Key are recorded, and repeating the same
+// request with the same key replays the original response without applying it again.
+// Requests the Server does not implement fail the test.
+type Server struct {
+	// URL is the base URL of the server, suitable for hookbase.WithBaseURL.
+	URL string
+	// APIKey is the API key requests must present. It defaults to DefaultAPIKey.
+	APIKey string
+	// Scopes are the API key scopes reported by Client.Ping. They are not enforced.
+	Scopes []string
+
+	t      *testing.T
+	server *httptest.Server
+
+	// idemMu serializes writes that carry an Idempotency-Key, so that checking for a
+	// recorded response and recording a new one happen as one step. It is taken before
+	// mu, which the handlers take themselves.
+	idemMu      sync.Mutex
+	mu          sync.Mutex
+	seq         int
+	items       map[*resourceDef][]map[string]interface{}
+	idempotency map[string]recordedResponse
+}
+
+type recordedResponse struct {
+	request string
+	status  int
+	body    []byte
+}
+
+// NewServer starts a Server that is closed when the test ends.
+func NewServer(t *testing.T) *Server {
+	t.Helper()
+	s := &Server{
+		APIKey:      DefaultAPIKey,
+		t:           t,
+		items:       map[*resourceDef][]map[string]interface{}{},
+		idempotency: map[string]recordedResponse{},
+	}
+	s.server = httptest.NewServer(s)
+	s.URL = s.server.URL
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Close shuts down the server. It is called automatically when the test ends.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns a client configured for the server, with retries disabled. opts are
+// applied after the defaults and may override them.
+func (s *Server) Client(opts ...hookbase.ClientOption) *hookbase.Client {
+	opts = append([]hookbase.ClientOption{hookbase.WithBaseURL(s.URL), hookbase.WithMaxRetries(0)}, opts...)
+	return hookbase.New(s.APIKey, opts...)
+}
+
+// SeedSource stores a source and returns it as stored. An empty ID is assigned.
+func (s *Server) SeedSource(src hookbase.Source) hookbase.Source {
+	return seed(s, sourcesDef, src, nil)
+}
+
+// SeedDestination stores a destination and returns it as stored. An empty ID is assigned.
+func (s *Server) SeedDestination(dst hookbase.Destination) hookbase.Destination {
+	return seed(s, destinationsDef, dst, nil)
+}
+
+// SeedRoute stores a route and returns it as stored. An empty ID is assigned.
+func (s *Server) SeedRoute(route hookbase.Route) hookbase.Route {
+	return seed(s, routesDef, route, nil)
+}
+
+// SeedApplication stores an application and returns it as stored. An empty ID is assigned.
+func (s *Server) SeedApplication(app hookbase.Application) hookbase.Application {
+	return seed(s, applicationsDef, app, nil)
+}
+
+// SeedEndpoint stores an endpoint and returns it as stored. An empty ID is assigned.
+func (s *Server) SeedEndpoint(ep hookbase.Endpoint) hookbase.Endpoint {
+	return seed(s, endpointsDef, ep, nil)
+}
+
+// SeedOutboundMessage stores an outbound message for an application and returns it
+// as stored. An empty ID is assigned.
+func (s *Server) SeedOutboundMessage(applicationID string, msg hookbase.OutboundMessage) hookbase.OutboundMessage {
+	return seed(s, messagesDef, msg, map[string]interface{}{"applicationId": applicationID})
+}
+
+// OutboundMessages returns the outbound messages stored for an application, including
+// those created by Messages.Send, in creation order.
+func (s *Server) OutboundMessages(applicationID string) []hookbase.OutboundMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []hookbase.OutboundMessage
+	for _, item := range s.items[messagesDef] {
+		if item["applicationId"] == applicationID {
+			var msg hookbase.OutboundMessage
+			convert(item, &msg)
+			out = append(out, msg)
+		}
+	}
+	return out
+}
+
+// SetMessageStatus changes the status of a stored outbound message, for example to
+// simulate delivery. It fails the test if the message does not exist.
+func (s *Server) SetMessageStatus(id string, status hookbase.MessageStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item := s.find(messagesDef, id)
+	if item == nil {
+		s.t.Errorf("hookbasetest: no outbound message %q", id)
+		return
+	}
+	item["status"] = string(status)
+	item["updatedAt"] = now()
+}
+
+func seed[T any](s *Server, def *resourceDef, v T, extra map[string]interface{}) T {
+	s.t.Helper()
+	var item map[string]interface{}
+	if err := convert(v, &item); err != nil {
+		s.t.Fatalf("hookbasetest: seed %s: %v", def.listKey, err)
+	}
+	for k, val := range extra {
+		item[k] = val
+	}
+	s.mu.Lock()
+	if id, _ := item["id"].(string); id == "" {
+		item["id"] = s.nextID(def.idPrefix)
+	}
+	if ts, _ := item["createdAt"].(string); ts == "" {
+		item["createdAt"] = now()
+		item["updatedAt"] = item["createdAt"]
+	}
+	s.items[def] = append(s.items[def], item)
+	s.mu.Unlock()
+
+	var out T
+	convert(item, &out)
+	return out
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+s.APIKey {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "invalid API key")
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+
+	key := r.Header.Get("Idempotency-Key")
+	if key == "" || r.Method == http.MethodGet {
+		s.route(w, r, body)
+		return
+	}
+	request := r.Method + " " + r.URL.Path + " " + string(body)
+	s.idemMu.Lock()
+	defer s.idemMu.Unlock()
+	s.mu.Lock()
+	prev, seen := s.idempotency[key]
+	s.mu.Unlock()
+	if seen {
+		if prev.request != request {
+			writeError(w, http.StatusConflict, "idempotency_key_reused", "idempotency key was used for a different request")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Idempotent-Replayed", "true")
+		w.WriteHeader(prev.status)
+		w.Write(prev.body)
+		return
+	}
+	rec := httptest.NewRecorder()
+	s.route(rec, r, body)
+	if rec.Code < 500 {
+		s.mu.Lock()
+		s.idempotency[key] = recordedResponse{request: request, status: rec.Code, body: rec.Body.Bytes()}
+		s.mu.Unlock()
+	}
+	for k, v := range rec.Header() {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(rec.Code)
+	w.Write(rec.Body.Bytes())
+}
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request, body []byte) {
+	if r.URL.Path == "/api/health" && r.Method == http.MethodGet {
+		prefix := s.APIKey
+		if len(prefix) > 8 {
+			prefix = prefix[:8]
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"status":         "ok",
+			"version":        "hookbasetest",
+			"apiVersion":     "hookbasetest",
+			"organizationId": "org_test",
+			"keyPrefix":      prefix,
+			"scopes":         s.Scopes,
+		})
+		return
+	}
+	if r.URL.Path == "/api/send-event" && r.Method == http.MethodPost {
+		s.sendEvent(w, body)
+		return
+	}
+	for _, def := range resourceDefs {
+		switch {
+		case r.URL.Path == def.path:
+			switch {
+			case r.Method == http.MethodGet:
+				s.list(w, r, def)
+				return
+			case r.Method == http.MethodPost && !def.readOnly:
+				s.create(w, def, body)
+				return
+			}
+		case strings.HasPrefix(r.URL.Path, def.path+"/") && !strings.Contains(r.URL.Path[len(def.path)+1:], "/"):
+			id := r.URL.Path[len(def.path)+1:]
+			switch {
+			case r.Method == http.MethodGet:
+				s.get(w, def, id)
+				return
+			case r.Method == http.MethodPatch && !def.readOnly:
+				s.update(w, def, id, body)
+				return
+			case r.Method == http.MethodDelete && !def.readOnly:
+				s.delete(w, def, id)
+				return
+			}
+		}
+	}
+	s.t.Errorf("hookbasetest: unhandled request %s %s", r.Method, r.URL.Path)
+	writeError(w, http.StatusNotImplemented, "not_implemented", "hookbasetest does not implement "+r.Method+" "+r.URL.Path)
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request, def *resourceDef) {
+	q := r.URL.Query()
+	s.mu.Lock()
+	var matched []map[string]interface{}
+	for _, item := range s.items[def] {
+		if matches(item, q) {
+			matched = append(matched, item)
+		}
+	}
+	s.mu.Unlock()
+
+	if !def.cursor {
+		page, pageSize := intParam(q, "page", 1, 1), intParam(q, "pageSize", defaultPageSize, 1)
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			def.listKey: window(matched, (page-1)*pageSize, pageSize),
+			"pagination": map[string]interface{}{
+				"total":    len(matched),
+				"page":     page,
+				"pageSize": pageSize,
+			},
+		})
+		return
+	}
+
+	// Cursors are opaque to clients; here they encode the offset of the next page.
+	limit, offset := intParam(q, "limit", defaultLimit, 1), intParam(q, "offset", 0, 0)
+	if c := q.Get("cursor"); c != "" {
+		offset, _ = strconv.Atoi(c)
+	}
+	var next interface{}
+	hasMore := offset+limit < len(matched)
+	if hasMore {
+		next = strconv.Itoa(offset + limit)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data":       window(matched, offset, limit),
+		"pagination": map[string]interface{}{"hasMore": hasMore, "nextCursor": next},
+	})
+}
+
+func (s *Server) get(w http.ResponseWriter, def *resourceDef, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item := s.find(def, id)
+	if item == nil {
+		writeNotFound(w, def, id)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{def.itemKey: item})
+}
+
+func (s *Server) create(w http.ResponseWriter, def *resourceDef, body []byte) {
+	var item map[string]interface{}
+	if err := json.Unmarshal(body, &item); err != nil || item == nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "request body must be a JSON object")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if def == endpointsDef && s.find(applicationsDef, fmt.Sprint(item["applicationId"])) == nil {
+		writeError(w, http.StatusNotFound, "not_found", "application not found")
+		return
+	}
+	item["id"] = s.nextID(def.idPrefix)
+	item["createdAt"] = now()
+	item["updatedAt"] = item["createdAt"]
+	if def.defaults != nil {
+		def.defaults(item)
+	}
+	s.items[def] = append(s.items[def], item)
+	writeJSON(w, http.StatusCreated, map[string]interface{}{def.itemKey: item})
+}
+
+func (s *Server) update(w http.ResponseWriter, def *resourceDef, id string, body []byte) {
+	var patch map[string]interface{}
+	if err := json.Unmarshal(body, &patch); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "request body must be a JSON object")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item := s.find(def, id)
+	if item == nil {
+		writeNotFound(w, def, id)
+		return
+	}
+	for k, v := range patch {
+		if k != "id" {
+			item[k] = v
+		}
+	}
+	item["updatedAt"] = now()
+	writeJSON(w, http.StatusOK, map[string]interface{}{def.itemKey: item})
+}
+
+func (s *Server) delete(w http.ResponseWriter, def *resourceDef, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, item := range s.items[def] {
+		if item["id"] == id {
+			s.items[def] = append(s.items[def][:i:i], s.items[def][i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	writeNotFound(w, def, id)
+}
+
+// sendEvent fans a message out to the application's enabled endpoints that accept
+// its event type, creating one pending outbound message per endpoint.
+func (s *Server) sendEvent(w http.ResponseWriter, body []byte) {
+	var req struct {
+		ApplicationID string          `json:"applicationId"`
+		EventType     string          `json:"eventType"`
+		EventID       string          `json:"eventId"`
+		Payload       json.RawMessage `json:"payload"`
+		EndpointIDs   []string        `json:"endpointIds"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "request body must be a JSON object")
+		return
+	}
+	if req.EventType == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{"error": map[string]interface{}{
+			"message": "eventType is required", "code": "validation_error",
+			"validationErrors": map[string][]string{"eventType": {"is required"}},
+		}})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.find(applicationsDef, req.ApplicationID) == nil {
+		writeError(w, http.StatusNotFound, "not_found", "application not found")
+		return
+	}
+	if req.EventID == "" {
+		req.EventID = s.nextID("evt")
+	}
+	var endpoints []map[string]interface{}
+	for _, ep := range s.items[endpointsDef] {
+		if ep["applicationId"] != req.ApplicationID || ep["isDisabled"] == true ||
+			!acceptsEventType(ep, req.EventType) || (req.EndpointIDs != nil && !contains(req.EndpointIDs, ep["id"])) {
+			continue
+		}
+		ts := now()
+		s.items[messagesDef] = append(s.items[messagesDef], map[string]interface{}{
+			"id":            s.nextID(messagesDef.idPrefix),
+			"applicationId": req.ApplicationID,
+			"messageId":     req.EventID,
+			"endpointId":    ep["id"],
+			"endpointUrl":   ep["url"],
+			"eventType":     req.EventType,
+			"payload":       req.Payload,
+			"status":        string(hookbase.MessagePending),
+			"attempts":      0,
+			"maxAttempts":   5,
+			"createdAt":     ts,
+			"updatedAt":     ts,
+		})
+		endpoints = append(endpoints, map[string]interface{}{"id": ep["id"], "url": ep["url"]})
+	}
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{"data": map[string]interface{}{
+		"eventId":        req.EventID,
+		"messagesQueued": len(endpoints),
+		"endpoints":      endpoints,
+	}})
+}
+
+// find returns the item with the given ID, or slug for sources and destinations.
+// s.mu must be held.
+func (s *Server) find(def *resourceDef, id string) map[string]interface{} {
+	for _, item := range s.items[def] {
+		if item["id"] == id || (item["slug"] == id && id != "") {
+			return item
+		}
+	}
+	return nil
+}
+
+// nextID returns a new unique ID. s.mu must be held.
+func (s *Server) nextID(prefix string) string {
+	s.seq++
+	return fmt.Sprintf("%s_%d", prefix, s.seq)
+}
+
+var paginationParams = map[string]bool{"page": true, "pageSize": true, "limit": true, "offset": true, "cursor": true}
+
+// matches reports whether item satisfies the list filters in q. "search" matches the
+// name case-insensitively; other parameters must equal the item's field of the same
+// name. Parameters naming fields the item does not have are ignored.
+func matches(item map[string]interface{}, q url.Values) bool {
+	for k, vs := range q {
+		if paginationParams[k] || len(vs) == 0 {
+			continue
+		}
+		if k == "search" {
+			name, _ := item["name"].(string)
+			if !strings.Contains(strings.ToLower(name), strings.ToLower(vs[0])) {
+				return false
+			}
+			continue
+		}
+		v, ok := item[k]
+		if ok && fmt.Sprint(v) != vs[0] {
+			return false
+		}
+	}
+	return true
+}
+
+func acceptsEventType(ep map[string]interface{}, eventType string) bool {
+	types, _ := ep["filterTypes"].([]interface{})
+	return len(types) == 0 || contains(types, eventType)
+}
+
+func contains[T any](list []T, v interface{}) bool {
+	for _, item := range list {
+		if fmt.Sprint(item) == fmt.Sprint(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func window(items []map[string]interface{}, offset, n int) []map[string]interface{} {
+	out := []map[string]interface{}{}
+	if offset < 0 || offset >= len(items) || n <= 0 {
+		return out
+	}
+	end := offset + n
+	if end > len(items) {
+		end = len(items)
+	}
+	return append(out, items[offset:end]...)
+}
+
+// intParam returns the integer query parameter name, or def if it is missing, invalid,
+// or below min.
+func intParam(q url.Values, name string, def, min int) int {
+	if n, err := strconv.Atoi(q.Get(name)); err == nil && n >= min {
+		return n
+	}
+	return def
+}
+
+func setDefault(item map[string]interface{}, key string, v interface{}) {
+	if _, ok := item[key]; !ok {
+		item[key] = v
+	}
+}
+
+var nonSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+func slugify(name interface{}) string {
+	s, _ := name.(string)
+	return strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+// convert copies v into out through its JSON encoding.
+func convert(v, out interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, out)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]interface{}{"error": map[string]interface{}{"message": message, "code": code}})
+}
+
+func writeNotFound(w http.ResponseWriter, def *resourceDef, id string) {
+	writeError(w, http.StatusNotFound, "not_found", fmt.Sprintf("%s %q not found", def.name, id))
+}
@@ -0,0 +1,185 @@
+package hookbasetest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/HookbaseApp/hookbase-go"
+)
+
+func TestServerOffsetPagination(t *testing.T) {
+	srv := NewServer(t)
+	for _, name := range []string{"GitHub", "Stripe", "Shopify"} {
+		srv.SeedSource(hookbase.Source{Name: name, Provider: hookbase.SourceProviderGeneric})
+	}
+	client := srv.Client()
+
+	page, err := client.Sources.List(context.Background(), &hookbase.ListSourcesParams{Page: hookbase.Ptr(1), PageSize: hookbase.Ptr(2)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Data) != 2 || page.Total != 3 || !page.HasMore || page.Data[0].Name != "GitHub" {
+		t.Errorf("unexpected first page: %+v", page)
+	}
+	page, err = client.Sources.List(context.Background(), &hookbase.ListSourcesParams{Page: hookbase.Ptr(2), PageSize: hookbase.Ptr(2)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Data) != 1 || page.HasMore || page.Data[0].Name != "Shopify" {
+		t.Errorf("unexpected second page: %+v", page)
+	}
+
+	page, err = client.Sources.List(context.Background(), &hookbase.ListSourcesParams{Search: hookbase.Ptr("stri")})
+	if err != nil || len(page.Data) != 1 || page.Data[0].Name != "Stripe" {
+		t.Errorf("expected search to match Stripe, got %+v, %v", page, err)
+	}
+}
+
+func TestServerCursorPagination(t *testing.T) {
+	srv := NewServer(t)
+	app := srv.SeedApplication(hookbase.Application{Name: "Acme"})
+	for i := 0; i < 5; i++ {
+		srv.SeedOutboundMessage(app.ID, hookbase.OutboundMessage{EventType: "order.created", Status: hookbase.MessagePending})
+	}
+	srv.SeedOutboundMessage("app_other", hookbase.OutboundMessage{EventType: "order.created"})
+
+	var seen []string
+	params := &hookbase.ListOutboundMessagesParams{Limit: hookbase.Ptr(2)}
+	for {
+		page, err := srv.Client().Messages.List(context.Background(), app.ID, params)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, m := range page.Data {
+			seen = append(seen, m.ID)
+		}
+		if !page.HasMore {
+			break
+		}
+		params.Cursor = page.NextCursor
+	}
+	if len(seen) != 5 {
+		t.Errorf("expected 5 messages across pages, got %v", seen)
+	}
+}
+
+func TestServerCRUDAndSend(t *testing.T) {
+	srv := NewServer(t)
+	client := srv.Client()
+	ctx := context.Background()
+
+	app, err := client.Applications.Create(ctx, &hookbase.CreateApplicationParams{Name: "Acme", UID: hookbase.Ptr("acme")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if app.ID == "" || app.UID != "acme" {
+		t.Errorf("unexpected application: %+v", app)
+	}
+	ep, err := client.Endpoints.Create(ctx, app.ID, &hookbase.CreateEndpointParams{URL: "https://example.com/hook", FilterTypes: []string{"order.created"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Endpoints.Create(ctx, app.ID, &hookbase.CreateEndpointParams{URL: "https://example.com/other", FilterTypes: []string{"user.created"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := client.Messages.Send(ctx, app.ID, &hookbase.SendMessageParams{EventType: "order.created", Payload: map[string]interface{}{"id": 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.MessagesQueued != 1 || result.OutboundMessages[0].EndpointID != ep.ID {
+		t.Errorf("expected one message to the order.created endpoint, got %+v", result)
+	}
+	msgs := srv.OutboundMessages(app.ID)
+	if len(msgs) != 1 || msgs[0].MessageID != result.MessageID {
+		t.Fatalf("unexpected stored messages: %+v", msgs)
+	}
+	srv.SetMessageStatus(msgs[0].ID, hookbase.MessageSuccess)
+	msg, err := client.Messages.Get(ctx, app.ID, msgs[0].ID)
+	if err != nil || msg.Status != hookbase.MessageSuccess {
+		t.Errorf("expected delivered message, got %+v, %v", msg, err)
+	}
+
+	updated, err := client.Endpoints.Update(ctx, app.ID, ep.ID, &hookbase.UpdateEndpointParams{URL: hookbase.Ptr("https://example.com/v2")})
+	if err != nil || updated.URL != "https://example.com/v2" {
+		t.Errorf("unexpected update result: %+v, %v", updated, err)
+	}
+	if err := client.Endpoints.Delete(ctx, app.ID, ep.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var notFound *hookbase.NotFoundError
+	if _, err := client.Endpoints.Get(ctx, app.ID, ep.ID); !errors.As(err, &notFound) {
+		t.Errorf("expected NotFoundError after delete, got %v", err)
+	}
+}
+
+func TestServerAuthentication(t *testing.T) {
+	srv := NewServer(t)
+	client := hookbase.New("wrong_key", hookbase.WithBaseURL(srv.URL), hookbase.WithMaxRetries(0))
+	var authErr *hookbase.AuthenticationError
+	if _, err := client.Sources.List(context.Background(), nil); !errors.As(err, &authErr) {
+		t.Errorf("expected AuthenticationError, got %v", err)
+	}
+}
+
+func TestServerIdempotencyReplay(t *testing.T) {
+	srv := NewServer(t)
+	client := srv.Client()
+	ctx := context.Background()
+
+	first, err := client.Sources.Create(ctx, &hookbase.CreateSourceParams{Name: "GitHub"}, hookbase.WithIdempotencyKey("key_1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := client.Sources.Create(ctx, &hookbase.CreateSourceParams{Name: "GitHub"}, hookbase.WithIdempotencyKey("key_1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.ID != second.ID {
+		t.Errorf("expected replayed response, got %s and %s", first.ID, second.ID)
+	}
+	page, _ := client.Sources.List(ctx, nil)
+	if page.Total != 1 {
+		t.Errorf("expected the replay not to create another source, got %d", page.Total)
+	}
+
+	var conflict *hookbase.ConflictError
+	_, err = client.Sources.Create(ctx, &hookbase.CreateSourceParams{Name: "Stripe"}, hookbase.WithIdempotencyKey("key_1"))
+	if !errors.As(err, &conflict) {
+		t.Errorf("expected ConflictError for a reused key, got %v", err)
+	}
+}
+
+func TestServerIdempotencyConcurrent(t *testing.T) {
+	srv := NewServer(t)
+	client := srv.Client()
+	ctx := context.Background()
+
+	ids := make([]string, 10)
+	var wg sync.WaitGroup
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			src, err := client.Sources.Create(ctx, &hookbase.CreateSourceParams{Name: "GitHub"}, hookbase.WithIdempotencyKey("key_1"))
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			ids[i] = src.ID
+		}(i)
+	}
+	wg.Wait()
+
+	for _, id := range ids {
+		if id != ids[0] {
+			t.Errorf("expected every request to get the same source, got %v", ids)
+			break
+		}
+	}
+	if page, _ := client.Sources.List(ctx, nil); page.Total != 1 {
+		t.Errorf("expected concurrent requests with one key to create one source, got %d", page.Total)
+	}
+}
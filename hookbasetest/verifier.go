@@ -0,0 +1,41 @@
+// Package hookbasetest provides test doubles for code that uses the hookbase package:
+// an in-memory fake of the Hookbase API (NewServer), a func-field fake of
+// hookbase.MessagesAPI (FakeMessages), and fixed-result webhook verifiers.
+package hookbasetest
+
+import (
+	"net/http"
+
+	"github.com/HookbaseApp/hookbase-go"
+)
+
+// Verifier is a hookbase.WebhookVerifier that returns a fixed result.
+type Verifier struct {
+	err error
+}
+
+var _ hookbase.WebhookVerifier = (*Verifier)(nil)
+
+// NewAlwaysPassVerifier returns a verifier that accepts every webhook.
+func NewAlwaysPassVerifier() *Verifier {
+	return &Verifier{}
+}
+
+// NewAlwaysFailVerifier returns a verifier that rejects every webhook with err. If
+// err is nil, a *hookbase.WebhookVerificationError is used.
+func NewAlwaysFailVerifier(err error) *Verifier {
+	if err == nil {
+		err = &hookbase.WebhookVerificationError{Message: "signature verification failed"}
+	}
+	return &Verifier{err: err}
+}
+
+// Verify returns the verifier's fixed result.
+func (v *Verifier) Verify(payload []byte, headers map[string]string) error {
+	return v.err
+}
+
+// VerifyFromRequest returns the verifier's fixed result without reading r.Body.
+func (v *Verifier) VerifyFromRequest(r *http.Request) error {
+	return v.err
+}
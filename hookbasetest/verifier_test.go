@@ -0,0 +1,32 @@
+package hookbasetest
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/HookbaseApp/hookbase-go"
+)
+
+func TestAlwaysPassVerifier(t *testing.T) {
+	var v hookbase.WebhookVerifier = NewAlwaysPassVerifier()
+	if err := v.Verify([]byte("{}"), nil); err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if err := v.VerifyFromRequest(httptest.NewRequest("POST", "/", nil)); err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+}
+
+func TestAlwaysFailVerifier(t *testing.T) {
+	var verr *hookbase.WebhookVerificationError
+	if err := NewAlwaysFailVerifier(nil).Verify([]byte("{}"), nil); !errors.As(err, &verr) {
+		t.Errorf("expected WebhookVerificationError, got %v", err)
+	}
+
+	custom := errors.New("custom")
+	v := NewAlwaysFailVerifier(custom)
+	if err := v.VerifyFromRequest(httptest.NewRequest("POST", "/", nil)); err != custom {
+		t.Errorf("expected custom error, got %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+package hookbase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/HookbaseApp/hookbase-go"
+	"github.com/HookbaseApp/hookbase-go/hookbasetest"
+)
+
+func TestFakeSourcesCreate(t *testing.T) {
+	srv := hookbasetest.NewServer(t)
+	source, err := srv.Client().Sources.Create(context.Background(), &hookbase.CreateSourceParams{Name: "My Source"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if source.ID == "" || source.Name != "My Source" || source.Slug != "my-source" || !source.IsActive.Bool() {
+		t.Errorf("unexpected source: %+v", source)
+	}
+}
+
+func TestFakeSourcesGet(t *testing.T) {
+	srv := hookbasetest.NewServer(t)
+	srv.SeedSource(hookbase.Source{ID: "src_1", Name: "GitHub", Slug: "github", Provider: hookbase.SourceProviderGitHub})
+
+	client := srv.Client()
+	for _, id := range []string{"src_1", "github"} {
+		source, err := client.Sources.Get(context.Background(), id)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if source.Name != "GitHub" || source.Provider != hookbase.SourceProviderGitHub {
+			t.Errorf("unexpected source: %+v", source)
+		}
+	}
+}
+
+func TestFakeSourcesDelete(t *testing.T) {
+	srv := hookbasetest.NewServer(t)
+	srv.SeedSource(hookbase.Source{ID: "src_1", Name: "GitHub"})
+
+	client := srv.Client()
+	if err := client.Sources.Delete(context.Background(), "src_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var notFound *hookbase.NotFoundError
+	if err := client.Sources.Delete(context.Background(), "src_1"); !errors.As(err, &notFound) {
+		t.Errorf("expected NotFoundError on second delete, got %v", err)
+	}
+}
+
+func TestFakeDestinationsList(t *testing.T) {
+	srv := hookbasetest.NewServer(t)
+	srv.SeedDestination(hookbase.Destination{Name: "My Webhook", URL: "https://example.com/webhook", Method: hookbase.HTTPPost})
+
+	page, err := srv.Client().Destinations.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Data) != 1 || page.Total != 1 {
+		t.Fatalf("expected 1 destination, got %+v", page)
+	}
+	if page.Data[0].URL != "https://example.com/webhook" {
+		t.Errorf("expected url, got %s", page.Data[0].URL)
+	}
+}
+
+func TestFakeApplicationsList(t *testing.T) {
+	srv := hookbasetest.NewServer(t)
+	srv.SeedApplication(hookbase.Application{Name: "Test App", UID: "ext_1"})
+
+	page, err := srv.Client().Applications.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Data) != 1 {
+		t.Fatalf("expected 1 application, got %d", len(page.Data))
+	}
+	if page.Data[0].Name != "Test App" || page.Data[0].UID != "ext_1" {
+		t.Errorf("unexpected application: %+v", page.Data[0])
+	}
+	if page.HasMore || page.NextCursor != nil {
+		t.Error("expected a single page")
+	}
+}
+
+func TestFakeMessagesSend(t *testing.T) {
+	srv := hookbasetest.NewServer(t)
+	app := srv.SeedApplication(hookbase.Application{ID: "app_1", Name: "Test App"})
+	srv.SeedEndpoint(hookbase.Endpoint{ID: "ep_1", ApplicationID: app.ID, URL: "https://a.com"})
+	srv.SeedEndpoint(hookbase.Endpoint{ID: "ep_2", ApplicationID: app.ID, URL: "https://b.com"})
+	srv.SeedEndpoint(hookbase.Endpoint{ID: "ep_3", ApplicationID: app.ID, URL: "https://c.com", IsDisabled: true})
+
+	result, err := srv.Client().Messages.Send(context.Background(), app.ID, &hookbase.SendMessageParams{
+		EventType: "order.created",
+		Payload:   map[string]interface{}{"orderId": "123"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.MessageID == "" {
+		t.Error("expected a message ID")
+	}
+	if len(result.OutboundMessages) != 2 {
+		t.Fatalf("expected 2 outbound messages, got %d", len(result.OutboundMessages))
+	}
+	if msgs := srv.OutboundMessages(app.ID); len(msgs) != 2 || msgs[0].EventType != "order.created" {
+		t.Errorf("unexpected stored messages: %+v", msgs)
+	}
+}
+
+func TestClientPing(t *testing.T) {
+	srv := hookbasetest.NewServer(t)
+	srv.Scopes = []string{hookbase.ScopeSourcesRead}
+	result, err := srv.Client().Ping(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != "ok" || result.Version != "hookbasetest" || result.OrganizationID != "org_test" || result.KeyPrefix != "test_key" {
+		t.Errorf("unexpected ping result: %+v", result)
+	}
+	if err := result.RequireScopes(hookbase.ScopeSourcesRead); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	client := hookbase.New("wrong_key", hookbase.WithBaseURL(srv.URL))
+	var authErr *hookbase.AuthenticationError
+	if _, err := client.Ping(context.Background()); !errors.As(err, &authErr) {
+		t.Errorf("expected AuthenticationError, got %v", err)
+	}
+
+	client = hookbase.New("test_key", hookbase.WithBaseURL("http://127.0.0.1:1"), hookbase.WithMaxRetries(0))
+	var netErr *hookbase.NetworkError
+	if _, err := client.Ping(context.Background()); !errors.As(err, &netErr) {
+		t.Errorf("expected NetworkError, got %v", err)
+	}
+}
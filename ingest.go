@@ -0,0 +1,149 @@
+package hookbase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// IngestOption configures a call to Client.Ingest.
+type IngestOption func(*ingestConfig)
+
+type ingestConfig struct {
+	contentType string
+	sign        bool
+	secret      string
+	headers     map[string]string
+}
+
+// WithIngestContentType sets the Content-Type of the ingested payload. The default is
+// "application/json".
+func WithIngestContentType(contentType string) IngestOption {
+	return func(c *ingestConfig) {
+		c.contentType = contentType
+	}
+}
+
+// WithIngestSigning signs the payload with the source's signing secret, as a source
+// with VerifySignature set expects. The secret is taken from the Source if it was
+// returned with one, and fetched with Sources.RevealSecret otherwise.
+func WithIngestSigning() IngestOption {
+	return func(c *ingestConfig) {
+		c.sign = true
+	}
+}
+
+// WithIngestSecret signs the payload with secret instead of the source's secret
+// fetched from the API, which saves a request when the caller already has it.
+func WithIngestSecret(secret string) IngestOption {
+	return func(c *ingestConfig) {
+		c.sign = true
+		c.secret = secret
+	}
+}
+
+// WithIngestHeader sets a header on the ingest request, such as a provider event
+// header used for deduplication. It may be given more than once.
+func WithIngestHeader(name, value string) IngestOption {
+	return func(c *ingestConfig) {
+		if c.headers == nil {
+			c.headers = make(map[string]string)
+		}
+		c.headers[name] = value
+	}
+}
+
+// Ingest posts payload to source's ingest URL, as a provider sending a webhook would,
+// so events the caller originates go through the source's routes and transforms. It
+// returns the ID of the accepted event.
+//
+// Ingest URLs do not accept the API key, so the request is sent without it and
+// without the client's request hooks. With WithIngestSigning or WithIngestSecret, the
+// webhook-id, webhook-timestamp, and webhook-signature headers are added; a
+// webhook-id set with WithIngestHeader is used as the signed ID.
+//
+// Ingest is not retried. A rejected request returns the usual typed error, such as a
+// *RateLimitError with RetryAfter when the source's rate limit is exceeded.
+func (c *Client) Ingest(ctx context.Context, source *Source, payload []byte, opts ...IngestOption) (string, error) {
+	if source == nil || source.IngestURL == nil || *source.IngestURL == "" {
+		return "", newClientValidationError("ingestUrl", "is required")
+	}
+	cfg := &ingestConfig{contentType: "application/json"}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	t := c.transport
+	if t.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", *source.IngestURL, bytes.NewReader(payload))
+	if err != nil {
+		return "", &NetworkError{Message: "failed to create request", Cause: err}
+	}
+	req.Header.Set("User-Agent", "hookbase-go/"+sdkVersion)
+	req.Header.Set("Content-Type", cfg.contentType)
+	for name, value := range cfg.headers {
+		req.Header.Set(name, value)
+	}
+	if cfg.sign {
+		secret := cfg.secret
+		if secret == "" && source.SigningSecret != nil {
+			secret = *source.SigningSecret
+		}
+		if secret == "" {
+			secret, err = c.Sources.RevealSecret(ctx, source.ID)
+			if err != nil {
+				return "", err
+			}
+		}
+		if secret == "" {
+			return "", &Error{Message: fmt.Sprintf("hookbase: source %s has no signing secret", source.ID)}
+		}
+		for name, value := range NewWebhook(secret).Sign(payload, req.Header.Get("webhook-id")) {
+			req.Header.Set(name, value)
+		}
+	}
+
+	path := *source.IngestURL
+	if u, err := url.Parse(path); err == nil {
+		path = u.Path
+	}
+
+	start := time.Now()
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		t.logRequest(ctx, "POST", path, 0, start, "", payload, nil, err)
+		if ctx.Err() != nil {
+			return "", &TimeoutError{Message: ctx.Err().Error()}
+		}
+		return "", &NetworkError{Message: err.Error(), Cause: err}
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", &NetworkError{Message: "failed to read response body", Cause: err}
+	}
+
+	requestID := resp.Header.Get("X-Request-Id")
+	t.logRequest(ctx, "POST", path, resp.StatusCode, start, requestID, payload, respBody, nil)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", t.mapError(resp.StatusCode, respBody, requestID, resp.Header)
+	}
+
+	var result struct {
+		EventID string `json:"eventId"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", &Error{Message: fmt.Sprintf("failed to unmarshal response: %v", err)}
+	}
+	return result.EventID, nil
+}
@@ -0,0 +1,427 @@
+package hookbase
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// The interfaces in this file describe each resource's methods so code can depend on
+// them instead of the concrete *XResource types and substitute a fake in tests. Methods
+// may be added in minor releases; fakes should embed the interface they implement so
+// they keep compiling and only override the methods a test uses. Client.Interfaces
+// returns a client's resources typed as these interfaces.
+
+// APIKeysAPI is implemented by *APIKeysResource.
+type APIKeysAPI interface {
+	List(ctx context.Context, params *ListAPIKeysParams, opts ...RequestOption) ([]APIKey, error)
+	ListAvailableScopes(ctx context.Context, opts ...RequestOption) ([]APIKeyScope, error)
+	Get(ctx context.Context, id string, opts ...RequestOption) (*APIKey, error)
+	Create(ctx context.Context, params *CreateAPIKeyParams, opts ...RequestOption) (*APIKeyWithSecret, error)
+	Update(ctx context.Context, id string, params *UpdateAPIKeyParams, opts ...RequestOption) (*APIKey, error)
+	Delete(ctx context.Context, id string, opts ...RequestOption) error
+	Rotate(ctx context.Context, id string, opts ...RequestOption) (*APIKeyWithSecret, error)
+	RotateKey(ctx context.Context, id string, opts ...RequestOption) (*APIKeyWithSecret, error)
+	GetUsage(ctx context.Context, id string, params *APIKeyUsageParams, opts ...RequestOption) (*APIKeyUsage, error)
+	ListAuditEvents(ctx context.Context, id string, params *ListAPIKeyAuditEventsParams, opts ...RequestOption) (*CursorResponse[APIKeyAuditEvent], error)
+	BulkRevoke(ctx context.Context, ids []string, opts ...RequestOption) (*BulkUpdateResult, error)
+}
+
+// AnalyticsAPI is implemented by *AnalyticsResource.
+type AnalyticsAPI interface {
+	Dashboard(ctx context.Context, params *DashboardParams, opts ...RequestOption) (*DashboardData, error)
+	DashboardRange(ctx context.Context, rangeStr string, opts ...RequestOption) (*DashboardData, error)
+	OutboundTimeseries(ctx context.Context, params *OutboundAnalyticsParams, opts ...RequestOption) ([]OutboundTimeseriesPoint, error)
+	ErrorBreakdown(ctx context.Context, params *OutboundAnalyticsParams, opts ...RequestOption) (*OutboundErrorBreakdown, error)
+}
+
+// AuditLogsAPI is implemented by *AuditLogsResource.
+type AuditLogsAPI interface {
+	List(ctx context.Context, params *ListAuditLogsParams, opts ...RequestOption) (*CursorResponse[AuditLogEntry], error)
+	ListAll(ctx context.Context, params *ListAuditLogsParams, fn func(AuditLogEntry) error, opts ...RequestOption) error
+	Export(ctx context.Context, params *ListAuditLogsParams, w io.Writer, opts ...RequestOption) error
+}
+
+// ApplicationsAPI is implemented by *ApplicationsResource.
+type ApplicationsAPI interface {
+	List(ctx context.Context, params *ListApplicationsParams, opts ...RequestOption) (*CursorResponse[Application], error)
+	Get(ctx context.Context, id string, opts ...RequestOption) (*Application, error)
+	GetByUID(ctx context.Context, uid string, opts ...RequestOption) (*Application, error)
+	Create(ctx context.Context, params *CreateApplicationParams, opts ...RequestOption) (*Application, error)
+	Update(ctx context.Context, id string, params *UpdateApplicationParams, opts ...RequestOption) (*Application, error)
+	Delete(ctx context.Context, id string, opts ...RequestOption) error
+	GetOrCreate(ctx context.Context, uid string, params *CreateApplicationParams, opts ...RequestOption) (*Application, error)
+	GetStats(ctx context.Context, applicationID string, opts ...RequestOption) (*ApplicationStats, error)
+}
+
+// CronAPI is implemented by *CronResource.
+type CronAPI interface {
+	List(ctx context.Context, opts ...RequestOption) ([]CronJob, error)
+	Get(ctx context.Context, id string, opts ...RequestOption) (*CronJob, error)
+	Create(ctx context.Context, params *CreateCronParams, opts ...RequestOption) (*CronJob, error)
+	Update(ctx context.Context, id string, params *UpdateCronParams, opts ...RequestOption) (*CronJob, error)
+	Delete(ctx context.Context, id string, opts ...RequestOption) error
+	Trigger(ctx context.Context, id string, opts ...RequestOption) (*CronTriggerResult, error)
+	GetExecution(ctx context.Context, id, executionID string, opts ...RequestOption) (*CronTriggerResult, error)
+	TriggerAndWait(ctx context.Context, id string, params *TriggerAndWaitParams, opts ...RequestOption) (*CronTriggerResult, error)
+	Pause(ctx context.Context, id string, opts ...RequestOption) (*CronJob, error)
+	Resume(ctx context.Context, id string, opts ...RequestOption) (*CronJob, error)
+	BulkUpdate(ctx context.Context, ids []string, isActive bool, opts ...RequestOption) (*BulkUpdateResult, error)
+	PauseAll(ctx context.Context, opts ...RequestOption) (*CronPauseSnapshot, error)
+	ResumeAll(ctx context.Context, snapshot *CronPauseSnapshot, opts ...RequestOption) error
+	ListGroups(ctx context.Context, opts ...RequestOption) ([]CronGroup, error)
+	CreateGroup(ctx context.Context, params *CreateCronGroupParams, opts ...RequestOption) (*CronGroup, error)
+	UpdateGroup(ctx context.Context, id string, params *UpdateCronGroupParams, opts ...RequestOption) (*CronGroup, error)
+	DeleteGroup(ctx context.Context, id string, reassignTo *string, opts ...RequestOption) error
+	ReorderGroups(ctx context.Context, orderedIDs []string, opts ...RequestOption) ([]CronGroup, error)
+	ListByGroup(ctx context.Context, groupID string, opts ...RequestOption) ([]CronJob, error)
+}
+
+// DLQAPI is implemented by *DLQResource.
+type DLQAPI interface {
+	List(ctx context.Context, params *ListDLQParams, opts ...RequestOption) (*CursorResponse[DLQMessage], error)
+	Get(ctx context.Context, id string, opts ...RequestOption) (*DLQMessageDetail, error)
+	GetStats(ctx context.Context, opts ...RequestOption) (*DLQStats, error)
+	Retry(ctx context.Context, id string, opts ...RequestOption) (*DLQRetryResult, error)
+	RetryBulk(ctx context.Context, messageIDs []string, opts ...RequestOption) (*DLQBulkRetryResult, error)
+	RetryAll(ctx context.Context, filter *ListDLQParams, opts ...RequestOption) (*DLQBulkRetryResult, error)
+	RetryByFilter(ctx context.Context, params *ListDLQParams, progress func(done, total int), opts ...RequestOption) (*DLQBatchResult, error)
+	PurgeByFilter(ctx context.Context, params *ListDLQParams, opts ...RequestOption) (*DLQBatchResult, error)
+	PurgeOlderThan(ctx context.Context, cutoff time.Time, opts ...RequestOption) (*DLQBatchResult, error)
+	Delete(ctx context.Context, id string, opts ...RequestOption) error
+	DeleteBulk(ctx context.Context, messageIDs []string, opts ...RequestOption) (*DLQBulkDeleteResult, error)
+	DeleteAll(ctx context.Context, filter *ListDLQParams, opts ...RequestOption) (*DLQBulkDeleteResult, error)
+	Export(ctx context.Context, params *ListDLQParams, w io.Writer, format string, opts ...RequestOption) error
+}
+
+// DeliveriesAPI is implemented by *DeliveriesResource.
+type DeliveriesAPI interface {
+	List(ctx context.Context, params *ListDeliveriesParams, opts ...RequestOption) (*PageResponse[Delivery], error)
+	Get(ctx context.Context, deliveryID string, opts ...RequestOption) (*DeliveryDetail, error)
+	Replay(ctx context.Context, deliveryID string, opts ...RequestOption) (*ReplayResult, error)
+	BulkReplay(ctx context.Context, deliveryIDs []string, opts ...RequestOption) (*BulkReplayResult, error)
+	BulkReplayEvents(ctx context.Context, eventIDs []string, opts ...RequestOption) (*BulkReplayResult, error)
+}
+
+// DestinationsAPI is implemented by *DestinationsResource.
+type DestinationsAPI interface {
+	List(ctx context.Context, params *ListDestinationsParams, opts ...RequestOption) (*PageResponse[Destination], error)
+	Get(ctx context.Context, id string, opts ...RequestOption) (*Destination, error)
+	Create(ctx context.Context, params *CreateDestinationParams, opts ...RequestOption) (*Destination, error)
+	Update(ctx context.Context, id string, params *UpdateDestinationParams, opts ...RequestOption) error
+	Delete(ctx context.Context, id string, opts ...RequestOption) error
+	Test(ctx context.Context, id string, opts ...RequestOption) (*DestinationTestResult, error)
+	Export(ctx context.Context, ids []string, opts ...RequestOption) (interface{}, error)
+	Import(ctx context.Context, params *ImportDestinationsParams, opts ...RequestOption) (*ImportResult, error)
+	BulkDelete(ctx context.Context, ids []string, opts ...RequestOption) (*BulkDeleteResult, error)
+}
+
+// EndpointsAPI is implemented by *EndpointsResource.
+type EndpointsAPI interface {
+	List(ctx context.Context, applicationID string, params *ListEndpointsParams, opts ...RequestOption) (*CursorResponse[Endpoint], error)
+	Get(ctx context.Context, applicationID, endpointID string, opts ...RequestOption) (*Endpoint, error)
+	Create(ctx context.Context, applicationID string, params *CreateEndpointParams, opts ...RequestOption) (*Endpoint, error)
+	Update(ctx context.Context, applicationID, endpointID string, params *UpdateEndpointParams, opts ...RequestOption) (*Endpoint, error)
+	Delete(ctx context.Context, applicationID, endpointID string, opts ...RequestOption) error
+	RotateSecret(ctx context.Context, applicationID, endpointID string, opts ...RequestOption) (string, error)
+	RotateSecretWithGrace(ctx context.Context, applicationID, endpointID string, graceSeconds int, opts ...RequestOption) (string, error)
+	ListSecrets(ctx context.Context, applicationID, endpointID string, opts ...RequestOption) ([]EndpointSecret, error)
+	ExpireSecret(ctx context.Context, applicationID, endpointID, secretID string, opts ...RequestOption) error
+	Enable(ctx context.Context, applicationID, endpointID string, opts ...RequestOption) (*Endpoint, error)
+	Disable(ctx context.Context, applicationID, endpointID string, opts ...RequestOption) (*Endpoint, error)
+	GetStats(ctx context.Context, applicationID, endpointID string, params *EndpointStatsParams, opts ...RequestOption) (*EndpointStats, error)
+	RecoverCircuit(ctx context.Context, applicationID, endpointID string, opts ...RequestOption) (*Endpoint, error)
+	Test(ctx context.Context, applicationID, endpointID string, opts ...RequestOption) (*EndpointTestResult, error)
+	TestWithEvent(ctx context.Context, applicationID, endpointID string, params *EndpointTestParams, opts ...RequestOption) (*EndpointTestResult, error)
+	TestWithPayload(ctx context.Context, applicationID, endpointID string, params *TestEndpointParams, opts ...RequestOption) (*EndpointTestResult, error)
+	Clone(ctx context.Context, applicationID, endpointID string, params *CloneEndpointParams, opts ...RequestOption) (*Endpoint, error)
+	Recover(ctx context.Context, applicationID, endpointID string, since time.Time, opts ...RequestOption) (*RecoverResult, error)
+	GetRecoverStatus(ctx context.Context, applicationID, endpointID, taskID string, opts ...RequestOption) (*RecoverResult, error)
+	AddFilterTypes(ctx context.Context, applicationID, endpointID string, types []string, opts ...RequestOption) (*Endpoint, error)
+	RemoveFilterTypes(ctx context.Context, applicationID, endpointID string, types []string, opts ...RequestOption) (*Endpoint, error)
+	SetFilterTypes(ctx context.Context, applicationID, endpointID string, types []string, validate bool, opts ...RequestOption) (*Endpoint, error)
+	PauseUntil(ctx context.Context, applicationID, endpointID string, until time.Time, opts ...RequestOption) (*Endpoint, error)
+	ResumeIfDue(ctx context.Context, applicationID string, opts ...RequestOption) ([]Endpoint, error)
+}
+
+// EventTypesAPI is implemented by *EventTypesResource.
+type EventTypesAPI interface {
+	List(ctx context.Context, params *ListEventTypesParams, opts ...RequestOption) (*CursorResponse[EventType], error)
+	Get(ctx context.Context, id string, opts ...RequestOption) (*EventType, error)
+	GetByName(ctx context.Context, name string, opts ...RequestOption) (*EventType, error)
+	Create(ctx context.Context, params *CreateEventTypeParams, opts ...RequestOption) (*EventType, error)
+	Update(ctx context.Context, id string, params *UpdateEventTypeParams, opts ...RequestOption) (*EventType, error)
+	Delete(ctx context.Context, id string, opts ...RequestOption) error
+	Archive(ctx context.Context, id string, opts ...RequestOption) (*EventType, error)
+	Unarchive(ctx context.Context, id string, opts ...RequestOption) (*EventType, error)
+	Enable(ctx context.Context, id string, opts ...RequestOption) (*EventType, error)
+	Disable(ctx context.Context, id string, opts ...RequestOption) (*EventType, error)
+	Clone(ctx context.Context, id string, newName string, opts ...RequestOption) (*EventType, error)
+	GetStats(ctx context.Context, id string, opts ...RequestOption) (*EventTypeStats, error)
+	BulkCreate(ctx context.Context, params []CreateEventTypeParams, opts ...RequestOption) (*BulkCreateResult[EventType], error)
+	BulkDelete(ctx context.Context, ids []string, opts ...RequestOption) (*BulkDeleteResult, error)
+	Export(ctx context.Context, ids []string, opts ...RequestOption) (interface{}, error)
+	Import(ctx context.Context, params *ImportEventTypesParams, opts ...RequestOption) (*ImportResult, error)
+	Sync(ctx context.Context, desired []CreateEventTypeParams, opts *SyncOptions, reqOpts ...RequestOption) (*SyncResult, error)
+	ValidatePayload(ctx context.Context, nameOrID string, payload interface{}, opts ...RequestOption) (*SchemaValidationResult, error)
+}
+
+// EventsAPI is implemented by *EventsResource.
+type EventsAPI interface {
+	List(ctx context.Context, params *ListEventsParams, opts ...RequestOption) (*PageResponse[InboundEvent], error)
+	Get(ctx context.Context, eventID string, opts ...RequestOption) (*EventDetail, error)
+	Debug(ctx context.Context, eventID string, opts ...RequestOption) (*EventDebugInfo, error)
+	Export(ctx context.Context, params *ExportEventsParams, opts ...RequestOption) (interface{}, error)
+}
+
+// FiltersAPI is implemented by *FiltersResource.
+type FiltersAPI interface {
+	List(ctx context.Context, params *ListFiltersParams, opts ...RequestOption) (*PageResponse[Filter], error)
+	Get(ctx context.Context, id string, opts ...RequestOption) (*Filter, error)
+	Create(ctx context.Context, params *CreateFilterParams, opts ...RequestOption) (*Filter, error)
+	Update(ctx context.Context, id string, params *UpdateFilterParams, opts ...RequestOption) error
+	Delete(ctx context.Context, id string, opts ...RequestOption) error
+	Test(ctx context.Context, params *FilterTestParams, opts ...RequestOption) (*FilterTestResult, error)
+	Export(ctx context.Context, ids []string, opts ...RequestOption) (*FiltersExport, error)
+	Import(ctx context.Context, params *ImportFiltersParams, opts ...RequestOption) (*ImportResult, error)
+}
+
+// MessagesAPI is implemented by *MessagesResource.
+type MessagesAPI interface {
+	Send(ctx context.Context, applicationID string, params *SendMessageParams, opts ...RequestOption) (*SendMessageResponse, error)
+	Schedule(ctx context.Context, applicationID string, params *ScheduleMessageParams, opts ...RequestOption) (*ScheduledMessageResponse, error)
+	CancelScheduled(ctx context.Context, applicationID, messageID string, opts ...RequestOption) error
+	SendBatch(ctx context.Context, applicationID string, batch []SendMessageParams, opts ...RequestOption) ([]*SendMessageResponse, error)
+	List(ctx context.Context, applicationID string, params *ListOutboundMessagesParams, opts ...RequestOption) (*CursorResponse[OutboundMessage], error)
+	Get(ctx context.Context, applicationID, messageID string, opts ...RequestOption) (*OutboundMessage, error)
+	GetByEventID(ctx context.Context, applicationID, eventID string, opts ...RequestOption) (*OutboundMessage, error)
+	GetContent(ctx context.Context, applicationID, outboundMessageID string, opts ...RequestOption) (json.RawMessage, map[string]string, error)
+	ListAttempts(ctx context.Context, applicationID, outboundMessageID string, opts ...RequestOption) ([]MessageAttempt, error)
+	Retry(ctx context.Context, applicationID, outboundMessageID string, opts ...RequestOption) (*OutboundMessage, error)
+	BulkRetry(ctx context.Context, applicationID string, outboundMessageIDs []string, opts ...RequestOption) (*DLQBulkRetryResult, error)
+	Expedite(ctx context.Context, applicationID, outboundMessageID string, opts ...RequestOption) (*OutboundMessage, error)
+	Cancel(ctx context.Context, applicationID, outboundMessageID string, opts ...RequestOption) (*OutboundMessage, error)
+	BulkExpedite(ctx context.Context, applicationID string, outboundMessageIDs []string, opts ...RequestOption) (*BulkMessageActionResult, error)
+	BulkCancel(ctx context.Context, applicationID string, outboundMessageIDs []string, opts ...RequestOption) (*BulkMessageActionResult, error)
+	GetStatsSummary(ctx context.Context, params *OutboundStatsParams, opts ...RequestOption) (*OutboundStatsSummary, error)
+	Export(ctx context.Context, params map[string]interface{}, opts ...RequestOption) (interface{}, error)
+	WaitForDelivery(ctx context.Context, applicationID, outboundMessageID string, opts ...WaitOption) (*OutboundMessage, error)
+	WaitForMessage(ctx context.Context, applicationID, messageID string, opts ...WaitOption) ([]OutboundMessage, error)
+}
+
+// NotificationsAPI is implemented by *NotificationsResource.
+type NotificationsAPI interface {
+	ListChannels(ctx context.Context, opts ...RequestOption) ([]NotificationChannel, error)
+	CreateChannel(ctx context.Context, params *CreateNotificationChannelParams, opts ...RequestOption) (*NotificationChannel, error)
+	UpdateChannel(ctx context.Context, id string, params *UpdateNotificationChannelParams, opts ...RequestOption) (*NotificationChannel, error)
+	DeleteChannel(ctx context.Context, id string, opts ...RequestOption) error
+	TestChannel(ctx context.Context, id string, opts ...RequestOption) (*NotificationTestResult, error)
+}
+
+// OrganizationAPI is implemented by *OrganizationResource.
+type OrganizationAPI interface {
+	Get(ctx context.Context, opts ...RequestOption) (*Organization, error)
+	Update(ctx context.Context, params *UpdateOrganizationParams, opts ...RequestOption) (*Organization, error)
+	ListMembers(ctx context.Context, opts ...RequestOption) ([]OrganizationMember, error)
+	InviteMember(ctx context.Context, email string, role MemberRole, opts ...RequestOption) (*OrganizationMember, error)
+	RemoveMember(ctx context.Context, memberID string, opts ...RequestOption) error
+	GetUsage(ctx context.Context, opts ...RequestOption) (*OrganizationUsage, error)
+}
+
+// PortalTokensAPI is implemented by *PortalTokensResource.
+type PortalTokensAPI interface {
+	Create(ctx context.Context, applicationID string, params *CreatePortalTokenParams, opts ...RequestOption) (*PortalToken, error)
+	GetEmbedURL(ctx context.Context, applicationID string, params *CreatePortalTokenParams, opts ...RequestOption) (string, error)
+	List(ctx context.Context, applicationID string, opts ...RequestOption) ([]PortalToken, error)
+	Revoke(ctx context.Context, applicationID, tokenID string, opts ...RequestOption) error
+	BulkRevoke(ctx context.Context, applicationID string, opts ...RequestOption) (*BulkUpdateResult, error)
+	Validate(ctx context.Context, applicationID string, token string, opts ...RequestOption) (*PortalTokenValidation, error)
+	Introspect(ctx context.Context, token string, opts ...RequestOption) (*PortalTokenInfo, error)
+	Refresh(ctx context.Context, applicationID string, tokenID string, extendByDays int, opts ...RequestOption) (*PortalToken, error)
+	Extend(ctx context.Context, applicationID string, tokenID string, additionalDays int, opts ...RequestOption) (*PortalToken, error)
+}
+
+// RoutesAPI is implemented by *RoutesResource.
+type RoutesAPI interface {
+	List(ctx context.Context, params *ListRoutesParams, opts ...RequestOption) (*PageResponse[Route], error)
+	Get(ctx context.Context, id string, opts ...RequestOption) (*Route, error)
+	Create(ctx context.Context, params *CreateRouteParams, opts ...RequestOption) (*Route, error)
+	Update(ctx context.Context, id string, params *UpdateRouteParams, opts ...RequestOption) error
+	Delete(ctx context.Context, id string, opts ...RequestOption) error
+	BulkDelete(ctx context.Context, ids []string, opts ...RequestOption) (*BulkDeleteResult, error)
+	BulkUpdate(ctx context.Context, ids []string, isActive bool, opts ...RequestOption) (*BulkUpdateResult, error)
+	Export(ctx context.Context, ids []string, opts ...RequestOption) (interface{}, error)
+	Import(ctx context.Context, params *ImportRoutesParams, opts ...RequestOption) (*ImportResult, error)
+	GetCircuitStatus(ctx context.Context, routeID string, opts ...RequestOption) (*CircuitStatusInfo, error)
+	ResetCircuit(ctx context.Context, routeID string, opts ...RequestOption) (*ResetCircuitResult, error)
+	UpdateCircuitConfig(ctx context.Context, routeID string, config *CircuitBreakerConfig, opts ...RequestOption) error
+}
+
+// SchemasAPI is implemented by *SchemasResource.
+type SchemasAPI interface {
+	List(ctx context.Context, params *ListSchemasParams, opts ...RequestOption) (*PageResponse[Schema], error)
+	Get(ctx context.Context, id string, opts ...RequestOption) (*Schema, error)
+	Create(ctx context.Context, params *CreateSchemaParams, opts ...RequestOption) (*Schema, error)
+	Update(ctx context.Context, id string, params *UpdateSchemaParams, opts ...RequestOption) error
+	Delete(ctx context.Context, id string, opts ...RequestOption) error
+	ListVersions(ctx context.Context, id string, opts ...RequestOption) ([]SchemaVersion, error)
+	GetVersion(ctx context.Context, id string, version int, opts ...RequestOption) (*SchemaVersion, error)
+	Rollback(ctx context.Context, id string, version int, opts ...RequestOption) (*Schema, error)
+	Validate(ctx context.Context, id string, payload interface{}, opts ...RequestOption) (*SchemaValidationResult, error)
+	Export(ctx context.Context, ids []string, opts ...RequestOption) (*SchemasExport, error)
+	Import(ctx context.Context, params *ImportSchemasParams, opts ...RequestOption) (*ImportResult, error)
+}
+
+// SourcesAPI is implemented by *SourcesResource.
+type SourcesAPI interface {
+	List(ctx context.Context, params *ListSourcesParams, opts ...RequestOption) (*PageResponse[Source], error)
+	Get(ctx context.Context, id string, opts ...RequestOption) (*Source, error)
+	Create(ctx context.Context, params *CreateSourceParams, opts ...RequestOption) (*Source, error)
+	Update(ctx context.Context, id string, params *UpdateSourceParams, opts ...RequestOption) error
+	Delete(ctx context.Context, id string, opts ...RequestOption) error
+	RotateSecret(ctx context.Context, id string, opts ...RequestOption) (string, error)
+	RevealSecret(ctx context.Context, id string, opts ...RequestOption) (string, error)
+	Export(ctx context.Context, ids []string, opts ...RequestOption) (interface{}, error)
+	Import(ctx context.Context, params *ImportSourcesParams, opts ...RequestOption) (*ImportResult, error)
+	BulkDelete(ctx context.Context, ids []string, opts ...RequestOption) (*BulkDeleteResult, error)
+}
+
+// SubscriptionsAPI is implemented by *SubscriptionsResource.
+type SubscriptionsAPI interface {
+	List(ctx context.Context, applicationID string, params *ListSubscriptionsParams, opts ...RequestOption) (*CursorResponse[Subscription], error)
+	GetByEventType(ctx context.Context, applicationID string, eventTypeID string, opts ...RequestOption) ([]Subscription, error)
+	Get(ctx context.Context, applicationID, subscriptionID string, opts ...RequestOption) (*Subscription, error)
+	Create(ctx context.Context, applicationID string, params *CreateSubscriptionParams, opts ...RequestOption) (*Subscription, error)
+	Update(ctx context.Context, applicationID, subscriptionID string, params *UpdateSubscriptionParams, opts ...RequestOption) (*Subscription, error)
+	Delete(ctx context.Context, applicationID, subscriptionID string, opts ...RequestOption) error
+	Enable(ctx context.Context, applicationID, subscriptionID string, opts ...RequestOption) (*Subscription, error)
+	Disable(ctx context.Context, applicationID, subscriptionID string, opts ...RequestOption) (*Subscription, error)
+	BulkSubscribe(ctx context.Context, endpointID string, eventTypeIDs []string, opts ...RequestOption) (*BulkSubscribeResult, error)
+	BulkUnsubscribe(ctx context.Context, endpointID string, eventTypeIDs []string, opts ...RequestOption) (*BulkUnsubscribeResult, error)
+	Find(ctx context.Context, applicationID, endpointID, eventTypeID string, opts ...RequestOption) (*Subscription, error)
+	BulkCreate(ctx context.Context, applicationID string, params []CreateSubscriptionParams, opts ...RequestOption) (*BulkSubscribeResult, error)
+	BulkDelete(ctx context.Context, applicationID string, ids []string, opts ...RequestOption) (*BulkDeleteResult, error)
+	Sync(ctx context.Context, applicationID, endpointID string, eventTypeIDs []string, opts *SubscriptionSyncOptions, reqOpts ...RequestOption) (*SubscriptionSyncResult, error)
+}
+
+// TransformsAPI is implemented by *TransformsResource.
+type TransformsAPI interface {
+	List(ctx context.Context, params *ListTransformsParams, opts ...RequestOption) (*PageResponse[Transform], error)
+	Get(ctx context.Context, id string, opts ...RequestOption) (*Transform, error)
+	Create(ctx context.Context, params *CreateTransformParams, opts ...RequestOption) (*Transform, error)
+	Update(ctx context.Context, id string, params *UpdateTransformParams, opts ...RequestOption) error
+	Delete(ctx context.Context, id string, opts ...RequestOption) error
+	ListUsage(ctx context.Context, id string, opts ...RequestOption) ([]RouteRef, error)
+	DeleteIfUnused(ctx context.Context, id string, opts ...RequestOption) error
+	Test(ctx context.Context, params *TransformTestParams, opts ...RequestOption) (*TransformTestResult, error)
+	Export(ctx context.Context, ids []string, opts ...RequestOption) (*TransformsExport, error)
+	Import(ctx context.Context, params *ImportTransformsParams, opts ...RequestOption) (*ImportResult, error)
+}
+
+// TunnelsAPI is implemented by *TunnelsResource.
+type TunnelsAPI interface {
+	List(ctx context.Context, opts ...RequestOption) ([]Tunnel, error)
+	Get(ctx context.Context, id string, opts ...RequestOption) (*Tunnel, error)
+	GetStatus(ctx context.Context, id string, opts ...RequestOption) (*TunnelStatus, error)
+	Create(ctx context.Context, params *CreateTunnelParams, opts ...RequestOption) (*Tunnel, error)
+	Update(ctx context.Context, id string, params *UpdateTunnelParams, opts ...RequestOption) (*Tunnel, error)
+	RotateToken(ctx context.Context, id string, opts ...RequestOption) (string, error)
+	Delete(ctx context.Context, id string, opts ...RequestOption) error
+	BulkDelete(ctx context.Context, ids []string, opts ...RequestOption) (*BulkDeleteResult, error)
+	DeleteAll(ctx context.Context, opts ...RequestOption) (*BulkDeleteResult, error)
+	ListRequests(ctx context.Context, id string, params *ListTunnelRequestsParams, opts ...RequestOption) (*CursorResponse[TunnelRequest], error)
+	GetRequest(ctx context.Context, id, requestID string, opts ...RequestOption) (*TunnelRequestDetail, error)
+	ReplayRequest(ctx context.Context, id, requestID string, opts ...RequestOption) (*TunnelRequestDetail, error)
+}
+
+// UsageAPI is implemented by *UsageResource.
+type UsageAPI interface {
+	Get(ctx context.Context, opts ...RequestOption) (*Usage, error)
+}
+
+var (
+	_ APIKeysAPI       = (*APIKeysResource)(nil)
+	_ AnalyticsAPI     = (*AnalyticsResource)(nil)
+	_ ApplicationsAPI  = (*ApplicationsResource)(nil)
+	_ AuditLogsAPI     = (*AuditLogsResource)(nil)
+	_ CronAPI          = (*CronResource)(nil)
+	_ DLQAPI           = (*DLQResource)(nil)
+	_ DeliveriesAPI    = (*DeliveriesResource)(nil)
+	_ DestinationsAPI  = (*DestinationsResource)(nil)
+	_ EndpointsAPI     = (*EndpointsResource)(nil)
+	_ EventTypesAPI    = (*EventTypesResource)(nil)
+	_ EventsAPI        = (*EventsResource)(nil)
+	_ FiltersAPI       = (*FiltersResource)(nil)
+	_ MessagesAPI      = (*MessagesResource)(nil)
+	_ NotificationsAPI = (*NotificationsResource)(nil)
+	_ OrganizationAPI  = (*OrganizationResource)(nil)
+	_ PortalTokensAPI  = (*PortalTokensResource)(nil)
+	_ RoutesAPI        = (*RoutesResource)(nil)
+	_ SchemasAPI       = (*SchemasResource)(nil)
+	_ SourcesAPI       = (*SourcesResource)(nil)
+	_ SubscriptionsAPI = (*SubscriptionsResource)(nil)
+	_ TransformsAPI    = (*TransformsResource)(nil)
+	_ TunnelsAPI       = (*TunnelsResource)(nil)
+	_ UsageAPI         = (*UsageResource)(nil)
+)
+
+// Interfaces holds a client's resources typed as interfaces. Build one by hand from
+// fakes, or take a real client's with Client.Interfaces.
+type Interfaces struct {
+	// Inbound resources
+	Sources       SourcesAPI
+	Destinations  DestinationsAPI
+	Routes        RoutesAPI
+	Events        EventsAPI
+	Deliveries    DeliveriesAPI
+	Transforms    TransformsAPI
+	Filters       FiltersAPI
+	Schemas       SchemasAPI
+	APIKeys       APIKeysAPI
+	Cron          CronAPI
+	Tunnels       TunnelsAPI
+	Analytics     AnalyticsAPI
+	Organization  OrganizationAPI
+	Usage         UsageAPI
+	AuditLogs     AuditLogsAPI
+	Notifications NotificationsAPI
+
+	// Outbound resources
+	Applications  ApplicationsAPI
+	Endpoints     EndpointsAPI
+	Messages      MessagesAPI
+	EventTypes    EventTypesAPI
+	Subscriptions SubscriptionsAPI
+	PortalTokens  PortalTokensAPI
+	DLQ           DLQAPI
+}
+
+// Interfaces returns the client's resources typed as interfaces.
+func (c *Client) Interfaces() *Interfaces {
+	return &Interfaces{
+		Sources:       c.Sources,
+		Destinations:  c.Destinations,
+		Routes:        c.Routes,
+		Events:        c.Events,
+		Deliveries:    c.Deliveries,
+		Transforms:    c.Transforms,
+		Filters:       c.Filters,
+		Schemas:       c.Schemas,
+		APIKeys:       c.APIKeys,
+		Cron:          c.Cron,
+		Tunnels:       c.Tunnels,
+		Analytics:     c.Analytics,
+		Organization:  c.Organization,
+		Usage:         c.Usage,
+		AuditLogs:     c.AuditLogs,
+		Notifications: c.Notifications,
+		Applications:  c.Applications,
+		Endpoints:     c.Endpoints,
+		Messages:      c.Messages,
+		EventTypes:    c.EventTypes,
+		Subscriptions: c.Subscriptions,
+		PortalTokens:  c.PortalTokens,
+		DLQ:           c.DLQ,
+	}
+}
@@ -0,0 +1,175 @@
+package hookbase
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultWaitInitialInterval = 500 * time.Millisecond
+	defaultWaitMaxInterval     = 5 * time.Second
+)
+
+// WaitOption configures Messages.WaitForDelivery and Messages.WaitForMessage.
+type WaitOption func(*waitConfig)
+
+type waitConfig struct {
+	initialInterval time.Duration
+	maxInterval     time.Duration
+	requestOpts     []RequestOption
+	// after is time.After, replaceable so tests can drive polling without sleeping.
+	after func(time.Duration) <-chan time.Time
+}
+
+// WithWaitInterval sets the polling backoff. Polling starts at initial and doubles
+// after each poll up to max. The defaults are 500ms and 5s.
+func WithWaitInterval(initial, max time.Duration) WaitOption {
+	return func(c *waitConfig) {
+		if initial > 0 {
+			c.initialInterval = initial
+		}
+		if max > 0 {
+			c.maxInterval = max
+		}
+	}
+}
+
+// WithWaitRequestOptions applies request options to every poll request.
+func WithWaitRequestOptions(opts ...RequestOption) WaitOption {
+	return func(c *waitConfig) {
+		c.requestOpts = append(c.requestOpts, opts...)
+	}
+}
+
+// DeliveryWaitError is returned by Messages.WaitForDelivery and Messages.WaitForMessage
+// when ctx is done before delivery finishes. It wraps the context error, so
+// errors.Is(err, context.DeadlineExceeded) reports whether the wait timed out.
+type DeliveryWaitError struct {
+	// Message is the last observed state of the outbound message. It is nil for
+	// WaitForMessage, or if the message was never fetched.
+	Message *OutboundMessage
+	// Messages are the last observed outbound messages.
+	Messages []OutboundMessage
+	Err      error
+}
+
+func (e *DeliveryWaitError) Error() string {
+	return fmt.Sprintf("hookbase: stopped waiting for delivery: %v", e.Err)
+}
+
+func (e *DeliveryWaitError) Unwrap() error {
+	return e.Err
+}
+
+// isTerminalStatus reports whether an outbound message has stopped being delivered.
+func isTerminalStatus(s MessageStatus) bool {
+	return s == MessageSuccess || s == MessageFailed || s == MessageExhausted
+}
+
+// WaitForDelivery polls an outbound message until its status is success, failed, or
+// exhausted, and returns the final message. Bound the wait with a context deadline;
+// when ctx is done first, a *DeliveryWaitError carrying the last observed message is
+// returned. Errors from the API, such as *NotFoundError, end the wait immediately.
+func (r *MessagesResource) WaitForDelivery(ctx context.Context, applicationID, outboundMessageID string, opts ...WaitOption) (*OutboundMessage, error) {
+	cfg := newWaitConfig(opts)
+	var last *OutboundMessage
+	err := cfg.poll(ctx, func() (bool, error) {
+		msg, err := r.Get(ctx, applicationID, outboundMessageID, cfg.requestOpts...)
+		if err != nil {
+			return false, err
+		}
+		last = msg
+		return isTerminalStatus(msg.Status), nil
+	})
+	if err != nil {
+		if werr, ok := err.(*DeliveryWaitError); ok && last != nil {
+			werr.Message = last
+			werr.Messages = []OutboundMessage{*last}
+		}
+		return nil, err
+	}
+	return last, nil
+}
+
+// WaitForMessage polls every outbound message created for a message ID (one per
+// subscribed endpoint) until all of them reach a terminal status. A message that has
+// not fanned out to any endpoint yet is polled until ctx is done, so always bound the
+// wait with a context deadline.
+func (r *MessagesResource) WaitForMessage(ctx context.Context, applicationID, messageID string, opts ...WaitOption) ([]OutboundMessage, error) {
+	cfg := newWaitConfig(opts)
+	var last []OutboundMessage
+	err := cfg.poll(ctx, func() (bool, error) {
+		var all []OutboundMessage
+		params := &ListOutboundMessagesParams{Limit: Ptr(100), MessageID: Ptr(messageID)}
+		for {
+			page, err := r.List(ctx, applicationID, params, cfg.requestOpts...)
+			if err != nil {
+				return false, err
+			}
+			all = append(all, page.Data...)
+			if !page.HasMore || page.NextCursor == nil {
+				break
+			}
+			params.Cursor = page.NextCursor
+		}
+		last = all
+		if len(all) == 0 {
+			return false, nil
+		}
+		for _, msg := range all {
+			if !isTerminalStatus(msg.Status) {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		if werr, ok := err.(*DeliveryWaitError); ok {
+			werr.Messages = last
+		}
+		return nil, err
+	}
+	return last, nil
+}
+
+func newWaitConfig(opts []WaitOption) *waitConfig {
+	cfg := &waitConfig{
+		initialInterval: defaultWaitInitialInterval,
+		maxInterval:     defaultWaitMaxInterval,
+		after:           time.After,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.maxInterval < cfg.initialInterval {
+		cfg.maxInterval = cfg.initialInterval
+	}
+	return cfg
+}
+
+// poll calls check until it reports done, backing off between calls. It returns a
+// *DeliveryWaitError if ctx is done first.
+func (c *waitConfig) poll(ctx context.Context, check func() (bool, error)) error {
+	interval := c.initialInterval
+	for {
+		done, err := check()
+		if err != nil {
+			if ctx.Err() != nil {
+				return &DeliveryWaitError{Err: ctx.Err()}
+			}
+			return err
+		}
+		if done {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return &DeliveryWaitError{Err: ctx.Err()}
+		case <-c.after(interval):
+		}
+		if interval *= 2; interval > c.maxInterval {
+			interval = c.maxInterval
+		}
+	}
+}
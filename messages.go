@@ -2,9 +2,23 @@ package hookbase
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/url"
+	"time"
 )
 
+// MaxMessageBatchSize is the maximum number of events accepted by Messages.SendBatch.
+const MaxMessageBatchSize = 50
+
+// MaxBulkMessageIDs is the largest number of outbound message IDs the API accepts in
+// one bulk request. BulkExpedite and BulkCancel split longer lists automatically.
+const MaxBulkMessageIDs = 100
+
+// MaxMessageContentSize is the largest response Messages.GetContent will read. Larger
+// payloads fail with an error rather than being truncated.
+const MaxMessageContentSize = 20 << 20
+
 // MessageStatus represents the status of an outbound message.
 type MessageStatus string
 
@@ -43,23 +57,33 @@ type MessageAttempt struct {
 	ResponseStatus    *int              `json:"responseStatus"`
 	ResponseBody      *string           `json:"responseBody"`
 	ResponseHeaders   map[string]string `json:"responseHeaders"`
-	Error             *string           `json:"error"`
-	LatencyMs         *int              `json:"latencyMs"`
-	AttemptedAt       string            `json:"attemptedAt"`
+	// Payload is the request body sent in this attempt, if the API records it.
+	Payload     json.RawMessage `json:"payload,omitempty"`
+	Error       *string         `json:"error"`
+	LatencyMs   *int            `json:"latencyMs"`
+	AttemptedAt string          `json:"attemptedAt"`
 }
 
 // SendMessageParams are the parameters for sending a message.
 type SendMessageParams struct {
-	EventType   string                 `json:"eventType"`
-	Payload     map[string]interface{} `json:"payload"`
+	EventType string                 `json:"eventType"`
+	Payload   map[string]interface{} `json:"payload"`
+	// RawPayload is an already-encoded JSON payload, sent without being decoded so key
+	// order and number precision are preserved. It may be any JSON value, including an
+	// array or scalar. Insignificant whitespace is removed. Set either Payload or
+	// RawPayload, not both.
+	RawPayload  json.RawMessage        `json:"-"`
 	EventID     *string                `json:"eventId,omitempty"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
 	EndpointIDs []string               `json:"endpointIds,omitempty"`
+	// DeliverAt schedules delivery for a later time instead of sending immediately.
+	DeliverAt *time.Time `json:"deliverAt,omitempty"`
 }
 
 // SendMessageResponse is the result of sending a message.
 type SendMessageResponse struct {
 	MessageID        string `json:"messageId"`
+	MessagesQueued   int    `json:"messagesQueued"`
 	OutboundMessages []struct {
 		ID         string        `json:"id"`
 		EndpointID string        `json:"endpointId"`
@@ -67,6 +91,36 @@ type SendMessageResponse struct {
 	} `json:"outboundMessages"`
 }
 
+// ScheduleMessageParams are the parameters for scheduling a message for later delivery
+// with Messages.Schedule. DeliverAt takes precedence over SendMessageParams.DeliverAt.
+type ScheduleMessageParams struct {
+	SendMessageParams
+	DeliverAt time.Time `json:"deliverAt"`
+}
+
+// ScheduledMessageResponse is the result of scheduling a message.
+type ScheduledMessageResponse struct {
+	MessageID    string `json:"messageId"`
+	ScheduledFor string `json:"scheduledFor"`
+	Status       string `json:"status"`
+}
+
+// BulkMessageActionResult is the result of expediting or cancelling multiple
+// outbound messages.
+type BulkMessageActionResult struct {
+	Total     int                     `json:"total"`
+	Succeeded int                     `json:"succeeded"`
+	Failed    int                     `json:"failed"`
+	Results   []BulkMessageActionItem `json:"results"`
+}
+
+// BulkMessageActionItem is the outcome for one message in a bulk action.
+type BulkMessageActionItem struct {
+	MessageID string        `json:"messageId"`
+	Status    MessageStatus `json:"status"`
+	Error     *string       `json:"error,omitempty"`
+}
+
 // ListMessagesParams are the parameters for listing messages.
 type ListMessagesParams struct {
 	Limit     *int    `json:"limit,omitempty"`
@@ -152,6 +206,40 @@ type OutboundStatsSummary struct {
 	Exhausted  int `json:"exhausted"`
 	DLQ        int `json:"dlq"`
 	Total      int `json:"total"`
+	// ByEventType breaks the summary down per event type, when the API provides it.
+	ByEventType map[string]OutboundStatsSummary `json:"byEventType,omitempty"`
+}
+
+// OutboundStatsParams filters the outbound statistics summary.
+type OutboundStatsParams struct {
+	ApplicationID *string `json:"applicationId,omitempty"`
+	EndpointID    *string `json:"endpointId,omitempty"`
+	EventType     *string `json:"eventType,omitempty"`
+	StartDate     *string `json:"startDate,omitempty"`
+	EndDate       *string `json:"endDate,omitempty"`
+}
+
+func (p *OutboundStatsParams) toQuery() url.Values {
+	if p == nil {
+		return nil
+	}
+	q := url.Values{}
+	if p.ApplicationID != nil {
+		q.Set("applicationId", *p.ApplicationID)
+	}
+	if p.EndpointID != nil {
+		q.Set("endpointId", *p.EndpointID)
+	}
+	if p.EventType != nil {
+		q.Set("eventType", *p.EventType)
+	}
+	if p.StartDate != nil {
+		q.Set("startDate", *p.StartDate)
+	}
+	if p.EndDate != nil {
+		q.Set("endDate", *p.EndDate)
+	}
+	return q
 }
 
 // MessagesResource provides access to message-related API endpoints.
@@ -161,39 +249,80 @@ type MessagesResource struct {
 
 // Send sends a webhook event to subscribed endpoints.
 func (r *MessagesResource) Send(ctx context.Context, applicationID string, params *SendMessageParams, opts ...RequestOption) (*SendMessageResponse, error) {
-	body := map[string]interface{}{
-		"applicationId": applicationID,
-		"eventType":     params.EventType,
-		"payload":       params.Payload,
+	body, err := sendMessageBody(params)
+	if err != nil {
+		return nil, err
 	}
-	if params.EventID != nil {
-		body["eventId"] = *params.EventID
+	if err := r.validateOutboundPayload(ctx, params, opts...); err != nil {
+		return nil, err
 	}
-	if params.Metadata != nil {
-		body["metadata"] = params.Metadata
+	body["applicationId"] = applicationID
+
+	var apiResp struct {
+		Data sendEventResult `json:"data"`
 	}
-	if params.EndpointIDs != nil {
-		body["endpointIds"] = params.EndpointIDs
+	if err := r.t.do(ctx, "POST", "/api/send-event", nil, body, &apiResp, opts...); err != nil {
+		return nil, err
 	}
+	return apiResp.Data.toResponse(), nil
+}
 
-	var apiResp struct {
+// Schedule queues a webhook event for delivery at params.DeliverAt.
+func (r *MessagesResource) Schedule(ctx context.Context, applicationID string, params *ScheduleMessageParams, opts ...RequestOption) (*ScheduledMessageResponse, error) {
+	if params == nil || params.DeliverAt.IsZero() {
+		return nil, newClientValidationError("deliverAt", "is required")
+	}
+	body, err := sendMessageBody(&params.SendMessageParams)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.validateOutboundPayload(ctx, &params.SendMessageParams, opts...); err != nil {
+		return nil, err
+	}
+	body["applicationId"] = applicationID
+	body["deliverAt"] = params.DeliverAt.UTC().Format(time.RFC3339)
+
+	var resp struct {
 		Data struct {
-			EventID        string `json:"eventId"`
-			MessagesQueued int    `json:"messagesQueued"`
-			Endpoints      []struct {
-				ID  string `json:"id"`
-				URL string `json:"url"`
-			} `json:"endpoints"`
+			EventID      string `json:"eventId"`
+			ScheduledFor string `json:"scheduledFor"`
+			Status       string `json:"status"`
 		} `json:"data"`
 	}
-	if err := r.t.do(ctx, "POST", "/api/send-event", nil, body, &apiResp, opts...); err != nil {
+	if err := r.t.do(ctx, "POST", "/api/send-event", nil, body, &resp, opts...); err != nil {
 		return nil, err
 	}
+	return &ScheduledMessageResponse{
+		MessageID:    resp.Data.EventID,
+		ScheduledFor: resp.Data.ScheduledFor,
+		Status:       resp.Data.Status,
+	}, nil
+}
+
+// CancelScheduled cancels a scheduled message before it is delivered, using the
+// MessageID returned by Schedule. It returns a *NotFoundError if the message is
+// unknown or has already been delivered or cancelled.
+func (r *MessagesResource) CancelScheduled(ctx context.Context, applicationID, messageID string, opts ...RequestOption) error {
+	q := url.Values{"applicationId": {applicationID}}
+	return r.t.do(ctx, "DELETE", "/api/scheduled-messages/"+url.PathEscape(messageID), q, nil, nil, opts...)
+}
+
+// sendEventResult is the API representation of a queued event.
+type sendEventResult struct {
+	EventID        string `json:"eventId"`
+	MessagesQueued int    `json:"messagesQueued"`
+	Endpoints      []struct {
+		ID  string `json:"id"`
+		URL string `json:"url"`
+	} `json:"endpoints"`
+}
 
+func (d *sendEventResult) toResponse() *SendMessageResponse {
 	result := &SendMessageResponse{
-		MessageID: apiResp.Data.EventID,
+		MessageID:      d.EventID,
+		MessagesQueued: d.MessagesQueued,
 	}
-	for _, ep := range apiResp.Data.Endpoints {
+	for _, ep := range d.Endpoints {
 		result.OutboundMessages = append(result.OutboundMessages, struct {
 			ID         string        `json:"id"`
 			EndpointID string        `json:"endpointId"`
@@ -204,7 +333,98 @@ func (r *MessagesResource) Send(ctx context.Context, applicationID string, param
 			Status:     MessagePending,
 		})
 	}
-	return result, nil
+	return result
+}
+
+// sendMessageBody builds the request body for sending a single event.
+func sendMessageBody(params *SendMessageParams) (map[string]interface{}, error) {
+	body := map[string]interface{}{
+		"eventType": params.EventType,
+		"payload":   params.Payload,
+	}
+	if params.RawPayload != nil {
+		if params.Payload != nil {
+			return nil, newClientValidationError("payload", "must not be set together with RawPayload")
+		}
+		if !json.Valid(params.RawPayload) {
+			return nil, newClientValidationError("payload", "RawPayload is not valid JSON")
+		}
+		body["payload"] = params.RawPayload
+	}
+	if params.EventID != nil {
+		body["eventId"] = *params.EventID
+	}
+	if params.Metadata != nil {
+		body["metadata"] = params.Metadata
+	}
+	if params.EndpointIDs != nil {
+		body["endpointIds"] = params.EndpointIDs
+	}
+	if params.DeliverAt != nil {
+		body["deliverAt"] = params.DeliverAt.UTC().Format(time.RFC3339)
+	}
+	return body, nil
+}
+
+// SendBatch sends up to MaxMessageBatchSize events in a single API call. The returned slice
+// has one entry per event, in order. If some events fail, the failed entries are nil
+// and the returned error is a *BatchError describing each failure.
+func (r *MessagesResource) SendBatch(ctx context.Context, applicationID string, batch []SendMessageParams, opts ...RequestOption) ([]*SendMessageResponse, error) {
+	if len(batch) == 0 {
+		return nil, &Error{Message: "hookbase: batch must contain at least one event"}
+	}
+	if len(batch) > MaxMessageBatchSize {
+		return nil, &Error{Message: fmt.Sprintf("hookbase: batch contains %d events, maximum is %d", len(batch), MaxMessageBatchSize)}
+	}
+	events := make([]map[string]interface{}, len(batch))
+	for i := range batch {
+		event, err := sendMessageBody(&batch[i])
+		if err != nil {
+			return nil, err
+		}
+		if err := r.validateOutboundPayload(ctx, &batch[i], opts...); err != nil {
+			return nil, err
+		}
+		events[i] = event
+	}
+	body := map[string]interface{}{
+		"applicationId": applicationID,
+		"events":        events,
+	}
+
+	var apiResp struct {
+		Data struct {
+			Results []struct {
+				sendEventResult
+				Error *string `json:"error"`
+			} `json:"results"`
+		} `json:"data"`
+	}
+	if err := r.t.do(ctx, "POST", "/api/send-events-batch", nil, body, &apiResp, opts...); err != nil {
+		return nil, err
+	}
+
+	responses := make([]*SendMessageResponse, len(batch))
+	errs := make([]error, len(batch))
+	failed := 0
+	for i := range batch {
+		if i >= len(apiResp.Data.Results) {
+			errs[i] = &Error{Message: "hookbase: no result returned for event"}
+			failed++
+			continue
+		}
+		res := apiResp.Data.Results[i]
+		if res.Error != nil {
+			errs[i] = &Error{Message: *res.Error}
+			failed++
+			continue
+		}
+		responses[i] = res.toResponse()
+	}
+	if failed > 0 {
+		return responses, &BatchError{Errors: errs, Failed: failed}
+	}
+	return responses, nil
 }
 
 // List returns outbound messages for an application.
@@ -245,6 +465,35 @@ func (r *MessagesResource) Get(ctx context.Context, applicationID, messageID str
 	return &resp.Data, nil
 }
 
+// GetByEventID returns an outbound message by the event ID supplied in SendMessageParams.EventID.
+func (r *MessagesResource) GetByEventID(ctx context.Context, applicationID, eventID string, opts ...RequestOption) (*OutboundMessage, error) {
+	q := url.Values{"applicationId": {applicationID}}
+	var resp struct {
+		Data OutboundMessage `json:"data"`
+	}
+	if err := r.t.do(ctx, "GET", "/api/outbound-messages/by-event-id/"+url.PathEscape(eventID), q, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// GetContent returns the payload delivered (or to be delivered) by an outbound message,
+// exactly as encoded by the API, along with the request headers sent with it.
+// Responses larger than MaxMessageContentSize fail with an error.
+func (r *MessagesResource) GetContent(ctx context.Context, applicationID, outboundMessageID string, opts ...RequestOption) (json.RawMessage, map[string]string, error) {
+	var resp struct {
+		Data struct {
+			Payload json.RawMessage   `json:"payload"`
+			Headers map[string]string `json:"headers"`
+		} `json:"data"`
+	}
+	opts = append([]RequestOption{withMaxResponseSize(MaxMessageContentSize)}, opts...)
+	if err := r.t.do(ctx, "GET", "/api/outbound-messages/"+url.PathEscape(outboundMessageID)+"/content", nil, nil, &resp, opts...); err != nil {
+		return nil, nil, err
+	}
+	return resp.Data.Payload, resp.Data.Headers, nil
+}
+
 // ListAttempts returns delivery attempts for an outbound message.
 func (r *MessagesResource) ListAttempts(ctx context.Context, applicationID, outboundMessageID string, opts ...RequestOption) ([]MessageAttempt, error) {
 	var resp struct {
@@ -275,12 +524,99 @@ func (r *MessagesResource) Retry(ctx context.Context, applicationID, outboundMes
 	}, nil
 }
 
-// GetStatsSummary returns outbound message statistics summary.
-func (r *MessagesResource) GetStatsSummary(ctx context.Context, opts ...RequestOption) (*OutboundStatsSummary, error) {
+// BulkRetry replays multiple failed outbound messages (up to MaxBulkMessageIDs).
+func (r *MessagesResource) BulkRetry(ctx context.Context, applicationID string, outboundMessageIDs []string, opts ...RequestOption) (*DLQBulkRetryResult, error) {
+	if len(outboundMessageIDs) == 0 {
+		return nil, newClientValidationError("messageIds", "must not be empty")
+	}
+	if len(outboundMessageIDs) > MaxBulkMessageIDs {
+		return nil, newClientValidationError("messageIds", fmt.Sprintf("must contain at most %d IDs", MaxBulkMessageIDs))
+	}
+	var resp struct {
+		Data DLQBulkRetryResult `json:"data"`
+	}
+	body := map[string]interface{}{
+		"applicationId": applicationID,
+		"messageIds":    outboundMessageIDs,
+	}
+	if err := r.t.do(ctx, "POST", "/api/outbound-messages/bulk-retry", nil, body, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Expedite delivers a pending outbound message immediately instead of waiting for its
+// next scheduled retry. If the message has already reached a terminal status, a
+// *ConflictError is returned.
+func (r *MessagesResource) Expedite(ctx context.Context, applicationID, outboundMessageID string, opts ...RequestOption) (*OutboundMessage, error) {
+	return r.action(ctx, outboundMessageID, "expedite", opts...)
+}
+
+// Cancel stops any further delivery attempts for an outbound message. If the message
+// has already reached a terminal status, a *ConflictError is returned.
+func (r *MessagesResource) Cancel(ctx context.Context, applicationID, outboundMessageID string, opts ...RequestOption) (*OutboundMessage, error) {
+	return r.action(ctx, outboundMessageID, "cancel", opts...)
+}
+
+// BulkExpedite expedites multiple outbound messages, splitting the IDs into requests
+// of at most MaxBulkMessageIDs. Messages that are already terminal are reported as
+// failures in the result. If a request fails, the results gathered so far are
+// returned along with the error.
+func (r *MessagesResource) BulkExpedite(ctx context.Context, applicationID string, outboundMessageIDs []string, opts ...RequestOption) (*BulkMessageActionResult, error) {
+	return r.bulkAction(ctx, applicationID, outboundMessageIDs, "bulk-expedite", opts...)
+}
+
+// BulkCancel cancels multiple outbound messages, splitting the IDs into requests of at
+// most MaxBulkMessageIDs. It behaves like BulkExpedite.
+func (r *MessagesResource) BulkCancel(ctx context.Context, applicationID string, outboundMessageIDs []string, opts ...RequestOption) (*BulkMessageActionResult, error) {
+	return r.bulkAction(ctx, applicationID, outboundMessageIDs, "bulk-cancel", opts...)
+}
+
+func (r *MessagesResource) action(ctx context.Context, outboundMessageID, action string, opts ...RequestOption) (*OutboundMessage, error) {
+	var resp struct {
+		Data OutboundMessage `json:"data"`
+	}
+	if err := r.t.do(ctx, "POST", "/api/outbound-messages/"+url.PathEscape(outboundMessageID)+"/"+action, nil, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+func (r *MessagesResource) bulkAction(ctx context.Context, applicationID string, outboundMessageIDs []string, action string, opts ...RequestOption) (*BulkMessageActionResult, error) {
+	if len(outboundMessageIDs) == 0 {
+		return nil, newClientValidationError("messageIds", "must contain at least one message ID")
+	}
+	result := &BulkMessageActionResult{}
+	for start := 0; start < len(outboundMessageIDs); start += MaxBulkMessageIDs {
+		end := start + MaxBulkMessageIDs
+		if end > len(outboundMessageIDs) {
+			end = len(outboundMessageIDs)
+		}
+		var resp struct {
+			Data BulkMessageActionResult `json:"data"`
+		}
+		body := map[string]interface{}{
+			"applicationId": applicationID,
+			"messageIds":    outboundMessageIDs[start:end],
+		}
+		if err := r.t.do(ctx, "POST", "/api/outbound-messages/"+action, nil, body, &resp, opts...); err != nil {
+			return result, err
+		}
+		result.Total += resp.Data.Total
+		result.Succeeded += resp.Data.Succeeded
+		result.Failed += resp.Data.Failed
+		result.Results = append(result.Results, resp.Data.Results...)
+	}
+	return result, nil
+}
+
+// GetStatsSummary returns outbound message statistics summary. A nil params summarizes
+// all messages in the organization.
+func (r *MessagesResource) GetStatsSummary(ctx context.Context, params *OutboundStatsParams, opts ...RequestOption) (*OutboundStatsSummary, error) {
 	var resp struct {
 		Data OutboundStatsSummary `json:"data"`
 	}
-	if err := r.t.do(ctx, "GET", "/api/outbound-messages/stats/summary", nil, nil, &resp, opts...); err != nil {
+	if err := r.t.do(ctx, "GET", "/api/outbound-messages/stats/summary", params.toQuery(), nil, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp.Data, nil
@@ -0,0 +1,238 @@
+package hookbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// NotificationChannelType is the kind of a notification channel.
+type NotificationChannelType string
+
+// Notification channel types.
+const (
+	ChannelEmail     NotificationChannelType = "email"
+	ChannelSlack     NotificationChannelType = "slack"
+	ChannelWebhook   NotificationChannelType = "webhook"
+	ChannelPagerDuty NotificationChannelType = "pagerduty"
+)
+
+// NotificationChannelConfig is the type-specific configuration of a notification
+// channel: *EmailChannelConfig, *SlackChannelConfig, *WebhookChannelConfig, or
+// *PagerDutyChannelConfig.
+type NotificationChannelConfig interface {
+	ChannelType() NotificationChannelType
+}
+
+// EmailChannelConfig sends notifications to a list of email addresses.
+type EmailChannelConfig struct {
+	Recipients []string `json:"recipients"`
+}
+
+// SlackChannelConfig posts notifications to a Slack incoming webhook. The webhook URL
+// is a secret and is redacted in debug logs.
+type SlackChannelConfig struct {
+	WebhookURL string `json:"webhookUrl"`
+	// Channel overrides the webhook's default channel, such as "#alerts".
+	Channel *string `json:"channel,omitempty"`
+}
+
+// WebhookChannelConfig posts notifications as JSON to a URL. If Secret is set,
+// requests are signed with it; it is redacted in debug logs.
+type WebhookChannelConfig struct {
+	URL     string            `json:"url"`
+	Secret  *string           `json:"secret,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// PagerDutyChannelConfig triggers PagerDuty incidents through the Events API. The
+// routing key is a secret and is redacted in debug logs.
+type PagerDutyChannelConfig struct {
+	RoutingKey string `json:"routingKey"`
+	// Severity is the incident severity: "critical", "error", "warning", or "info".
+	Severity *string `json:"severity,omitempty"`
+}
+
+// ChannelType returns ChannelEmail.
+func (*EmailChannelConfig) ChannelType() NotificationChannelType { return ChannelEmail }
+
+// ChannelType returns ChannelSlack.
+func (*SlackChannelConfig) ChannelType() NotificationChannelType { return ChannelSlack }
+
+// ChannelType returns ChannelWebhook.
+func (*WebhookChannelConfig) ChannelType() NotificationChannelType { return ChannelWebhook }
+
+// ChannelType returns ChannelPagerDuty.
+func (*PagerDutyChannelConfig) ChannelType() NotificationChannelType { return ChannelPagerDuty }
+
+// decodeChannelConfig decodes raw into the config type for typ. Unknown types decode
+// to nil so newer channel types do not break listing.
+func decodeChannelConfig(typ NotificationChannelType, raw json.RawMessage) (NotificationChannelConfig, error) {
+	var config NotificationChannelConfig
+	switch typ {
+	case ChannelEmail:
+		config = &EmailChannelConfig{}
+	case ChannelSlack:
+		config = &SlackChannelConfig{}
+	case ChannelWebhook:
+		config = &WebhookChannelConfig{}
+	case ChannelPagerDuty:
+		config = &PagerDutyChannelConfig{}
+	default:
+		return nil, nil
+	}
+	if len(raw) == 0 || string(raw) == "null" {
+		return config, nil
+	}
+	if err := json.Unmarshal(raw, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// NotificationChannel is an organization-level destination for alerts such as route
+// failure notifications.
+type NotificationChannel struct {
+	ID   string                  `json:"id"`
+	Name string                  `json:"name"`
+	Type NotificationChannelType `json:"type"`
+	// Config is the type-specific configuration. It is nil for channel types this
+	// version of the SDK does not know.
+	Config NotificationChannelConfig `json:"-"`
+	// Events lists the alert events sent to the channel. Empty means all events.
+	Events    []string `json:"events"`
+	IsActive  FlexBool `json:"isActive"`
+	CreatedAt string   `json:"createdAt"`
+	UpdatedAt string   `json:"updatedAt"`
+}
+
+// UnmarshalJSON decodes the channel, including Config for its Type.
+func (c *NotificationChannel) UnmarshalJSON(data []byte) error {
+	type plain NotificationChannel
+	var aux struct {
+		plain
+		Config json.RawMessage `json:"config"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	config, err := decodeChannelConfig(aux.Type, aux.Config)
+	if err != nil {
+		return fmt.Errorf("hookbase: decoding %s channel config: %w", aux.Type, err)
+	}
+	*c = NotificationChannel(aux.plain)
+	c.Config = config
+	return nil
+}
+
+// CreateNotificationChannelParams are the parameters for creating a notification
+// channel. The channel type is taken from Config.
+type CreateNotificationChannelParams struct {
+	Name   string                    `json:"name"`
+	Config NotificationChannelConfig `json:"config"`
+	Events []string                  `json:"events,omitempty"`
+}
+
+// MarshalJSON adds the channel type from Config.
+func (p CreateNotificationChannelParams) MarshalJSON() ([]byte, error) {
+	type plain CreateNotificationChannelParams
+	var typ NotificationChannelType
+	if p.Config != nil {
+		typ = p.Config.ChannelType()
+	}
+	return json.Marshal(struct {
+		plain
+		Type NotificationChannelType `json:"type"`
+	}{plain(p), typ})
+}
+
+// UpdateNotificationChannelParams are the parameters for updating a notification
+// channel. A non-nil Config replaces the whole configuration and must be of the
+// channel's type.
+type UpdateNotificationChannelParams struct {
+	Name     *string                   `json:"name,omitempty"`
+	Config   NotificationChannelConfig `json:"config,omitempty"`
+	Events   []string                  `json:"events,omitempty"`
+	IsActive *bool                     `json:"isActive,omitempty"`
+}
+
+// MarshalJSON adds the channel type from Config, if set.
+func (p UpdateNotificationChannelParams) MarshalJSON() ([]byte, error) {
+	type plain UpdateNotificationChannelParams
+	var typ NotificationChannelType
+	if p.Config != nil {
+		typ = p.Config.ChannelType()
+	}
+	return json.Marshal(struct {
+		plain
+		Type NotificationChannelType `json:"type,omitempty"`
+	}{plain(p), typ})
+}
+
+// NotificationTestResult is the outcome of sending a test notification.
+type NotificationTestResult struct {
+	Success bool `json:"success"`
+	// StatusCode is the HTTP status returned by Slack, PagerDuty, or the webhook URL.
+	StatusCode *int    `json:"statusCode"`
+	Error      *string `json:"error"`
+	DurationMs int     `json:"durationMs"`
+}
+
+// NotificationsResource provides access to the organization's notification channels.
+type NotificationsResource struct {
+	t *transport
+}
+
+// ListChannels returns the organization's notification channels.
+func (r *NotificationsResource) ListChannels(ctx context.Context, opts ...RequestOption) ([]NotificationChannel, error) {
+	var resp struct {
+		Data []NotificationChannel `json:"data"`
+	}
+	if err := r.t.do(ctx, "GET", "/api/notifications/channels", nil, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// CreateChannel creates a notification channel.
+func (r *NotificationsResource) CreateChannel(ctx context.Context, params *CreateNotificationChannelParams, opts ...RequestOption) (*NotificationChannel, error) {
+	if params == nil || params.Config == nil {
+		return nil, newClientValidationError("config", "is required")
+	}
+	var resp struct {
+		Data NotificationChannel `json:"data"`
+	}
+	if err := r.t.do(ctx, "POST", "/api/notifications/channels", nil, params, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// UpdateChannel updates a notification channel.
+func (r *NotificationsResource) UpdateChannel(ctx context.Context, id string, params *UpdateNotificationChannelParams, opts ...RequestOption) (*NotificationChannel, error) {
+	var resp struct {
+		Data NotificationChannel `json:"data"`
+	}
+	if err := r.t.do(ctx, "PATCH", "/api/notifications/channels/"+url.PathEscape(id), nil, params, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// DeleteChannel deletes a notification channel.
+func (r *NotificationsResource) DeleteChannel(ctx context.Context, id string, opts ...RequestOption) error {
+	return r.t.do(ctx, "DELETE", "/api/notifications/channels/"+url.PathEscape(id), nil, nil, nil, opts...)
+}
+
+// TestChannel sends a test notification through a channel and reports whether it was
+// delivered. A failed delivery is reported in the result, not as an error.
+func (r *NotificationsResource) TestChannel(ctx context.Context, id string, opts ...RequestOption) (*NotificationTestResult, error) {
+	var resp struct {
+		Data NotificationTestResult `json:"data"`
+	}
+	if err := r.t.do(ctx, "POST", "/api/notifications/channels/"+url.PathEscape(id)+"/test", nil, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
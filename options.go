@@ -1,32 +1,50 @@
 package hookbase
 
 import (
+	"fmt"
+	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 )
 
 const (
-	defaultBaseURL    = "https://api.hookbase.app"
-	defaultTimeout    = 30 * time.Second
-	defaultMaxRetries = 3
+	defaultBaseURL       = "https://api.hookbase.app"
+	defaultPortalBaseURL = "https://portal.hookbase.app"
+	defaultTimeout       = 30 * time.Second
+	defaultMaxRetries    = 3
 )
 
 // ClientOption configures the Hookbase client.
 type ClientOption func(*clientConfig)
 
 type clientConfig struct {
-	baseURL    string
-	timeout    time.Duration
-	maxRetries int
-	httpClient *http.Client
-	debug      bool
+	baseURL          string
+	portalBaseURL    string
+	timeout          time.Duration
+	resourceTimeouts map[string]time.Duration
+	maxRetries       int
+	retryPolicy      *RetryPolicy
+	httpClient       *http.Client
+	debug            bool
+	gzip             bool
+	logger           *slog.Logger
+	organizationID   string
+	tracer           Tracer
+	requestHooks     []func(*http.Request) error
+	responseHooks    []func(*http.Response, []byte) error
+	clientValidation bool
+	outboundSchemas  bool
+	recorder         *Recorder
 }
 
 func defaultConfig() *clientConfig {
 	return &clientConfig{
-		baseURL:    defaultBaseURL,
-		timeout:    defaultTimeout,
-		maxRetries: defaultMaxRetries,
+		baseURL:          defaultBaseURL,
+		portalBaseURL:    defaultPortalBaseURL,
+		timeout:          defaultTimeout,
+		maxRetries:       defaultMaxRetries,
+		clientValidation: true,
 	}
 }
 
@@ -41,6 +59,17 @@ func WithBaseURL(url string) ClientOption {
 	}
 }
 
+// WithPortalBaseURL sets the base URL of the customer portal used by
+// PortalTokens.GetEmbedURL.
+func WithPortalBaseURL(url string) ClientOption {
+	return func(c *clientConfig) {
+		for len(url) > 0 && url[len(url)-1] == '/' {
+			url = url[:len(url)-1]
+		}
+		c.portalBaseURL = url
+	}
+}
+
 // WithTimeout sets the request timeout.
 func WithTimeout(d time.Duration) ClientOption {
 	return func(c *clientConfig) {
@@ -48,6 +77,25 @@ func WithTimeout(d time.Duration) ClientOption {
 	}
 }
 
+// WithResourceTimeout sets the request timeout for one resource, overriding WithTimeout
+// for its requests. resource is the name of a Client resource field, matched without
+// regard to case, such as "sources", "events", "analytics", "tunnels", "endpoints",
+// "messages", or "dlq". The "messages" timeout covers Messages.Send and SendBatch
+// too, while the "dlq" timeout applies to DLQ calls only. WithRequestTimeout still
+// overrides it per request. It panics if resource is not a Client resource.
+func WithResourceTimeout(resource string, d time.Duration) ClientOption {
+	name := strings.ToLower(resource)
+	if _, ok := resourcePathPrefixes[name]; !ok {
+		panic(fmt.Sprintf("hookbase: unknown resource %q", resource))
+	}
+	return func(c *clientConfig) {
+		if c.resourceTimeouts == nil {
+			c.resourceTimeouts = make(map[string]time.Duration)
+		}
+		c.resourceTimeouts[name] = d
+	}
+}
+
 // WithMaxRetries sets the maximum number of retry attempts for failed requests.
 func WithMaxRetries(n int) ClientOption {
 	return func(c *clientConfig) {
@@ -55,6 +103,16 @@ func WithMaxRetries(n int) ClientOption {
 	}
 }
 
+// WithRetryPolicy replaces the default retry behavior, which retries up to 3 times
+// with exponential backoff from 1s to 10s. The policy's MaxRetries replaces any value
+// set with WithMaxRetries; WithRequestRetries still overrides it per request.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *clientConfig) {
+		c.retryPolicy = &policy
+		c.maxRetries = policy.MaxRetries
+	}
+}
+
 // WithHTTPClient sets a custom HTTP client.
 func WithHTTPClient(client *http.Client) ClientOption {
 	return func(c *clientConfig) {
@@ -62,13 +120,106 @@ func WithHTTPClient(client *http.Client) ClientOption {
 	}
 }
 
-// WithDebug enables debug logging of requests and responses.
+// WithRecorder records the client's API traffic to, or replays it from, the cassette
+// of r. It wraps the transport of the client set with WithHTTPClient, if any.
+func WithRecorder(r *Recorder) ClientOption {
+	return func(c *clientConfig) {
+		c.recorder = r
+	}
+}
+
+// WithDebug enables logging of every request at info level, including request and
+// response bodies. Tokens and secrets, such as API keys, signing secrets, passwords,
+// Slack webhook URLs, PagerDuty routing keys, and custom headers, are redacted from
+// logged bodies. Entries go to the logger set with WithLogger, or slog.Default().
 func WithDebug(debug bool) ClientOption {
 	return func(c *clientConfig) {
 		c.debug = debug
 	}
 }
 
+// WithGzipCompression asks the API for gzip-compressed responses and decompresses them
+// before decoding, reducing transfer size for large list responses. Disabled by
+// default.
+func WithGzipCompression(enabled bool) ClientOption {
+	return func(c *clientConfig) {
+		c.gzip = enabled
+	}
+}
+
+// WithLogger sets a structured logger. Each request attempt is logged with method,
+// path, status_code, duration_ms, and request_id attributes, at debug level unless
+// WithDebug is enabled.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *clientConfig) {
+		c.logger = logger
+	}
+}
+
+// WithTracing wraps every API call, including its retries, in a span started by
+// tracer. For OpenTelemetry, pass hookbaseotel.NewTracer from the
+// github.com/HookbaseApp/hookbase-go/hookbaseotel module, which keeps the OpenTelemetry
+// dependency out of this one. Tracing is off unless this option is set.
+func WithTracing(tracer Tracer) ClientOption {
+	return func(c *clientConfig) {
+		c.tracer = tracer
+	}
+}
+
+// WithRequestInterceptor registers fn to run on every outgoing request, including
+// each retry, after the SDK has set its headers. Use it to add headers or inspect
+// requests. If fn returns an error, the call fails with that error and is not
+// retried. Interceptors run in registration order.
+func WithRequestInterceptor(fn func(req *http.Request) error) ClientOption {
+	return func(c *clientConfig) {
+		c.requestHooks = append(c.requestHooks, fn)
+	}
+}
+
+// WithResponseInterceptor registers fn to run on every successful (2xx) response
+// with its raw body, before the body is decoded. If fn returns an error, the call
+// fails with that error. Interceptors run in registration order.
+func WithResponseInterceptor(fn func(resp *http.Response, body []byte) error) ClientOption {
+	return func(c *clientConfig) {
+		c.responseHooks = append(c.responseHooks, fn)
+	}
+}
+
+// WithOrganizationID sends an X-Organization-ID header with every request, for API
+// keys with access to more than one organization. The header is a hint: the server
+// still checks that the API key has access to the organization and rejects the
+// request otherwise. Override it per request with WithRequestOrganizationID.
+func WithOrganizationID(orgID string) ClientOption {
+	return func(c *clientConfig) {
+		c.organizationID = orgID
+	}
+}
+
+// WithClientValidation enables or disables validation of request parameters before
+// they are sent: cron expressions and timezones in Cron.Create and Update, API key
+// scopes in APIKeys.Create and Update, and event type names in EventTypes.Create and
+// Sync. Invalid values fail with a *ValidationError whose code is
+// CodeClientValidation, without a request being made.
+//
+// Enabled by default. This is a breaking change for callers that relied on the API
+// accepting such values or returning its own 400; pass false to keep the previous
+// behavior, or if the SDK lags behind newly added server-side values.
+func WithClientValidation(enabled bool) ClientOption {
+	return func(c *clientConfig) {
+		c.clientValidation = enabled
+	}
+}
+
+// WithOutboundSchemaValidation makes Messages.Send, Schedule, and SendBatch validate
+// each payload against its event type's schema before sending, returning a
+// *ValidationError keyed by JSON pointer on mismatch. Schemas are cached for
+// OutboundSchemaCacheTTL. Disabled by default.
+func WithOutboundSchemaValidation(enabled bool) ClientOption {
+	return func(c *clientConfig) {
+		c.outboundSchemas = enabled
+	}
+}
+
 // RequestOption configures individual API requests.
 type RequestOption func(*requestConfig)
 
@@ -76,6 +227,12 @@ type requestConfig struct {
 	timeout        time.Duration
 	maxRetries     *int
 	idempotencyKey string
+	ifMatch        string
+	organizationID string
+	baseURL        string
+	maxBodyBytes   int64
+	responseMeta   *ResponseMeta
+	force          bool
 }
 
 // WithRequestTimeout overrides the timeout for a single request.
@@ -98,3 +255,64 @@ func WithIdempotencyKey(key string) RequestOption {
 		c.idempotencyKey = key
 	}
 }
+
+// WithRequestOrganizationID overrides the organization set with WithOrganizationID for
+// a single request. As with WithOrganizationID, the server validates access.
+func WithRequestOrganizationID(orgID string) RequestOption {
+	return func(c *requestConfig) {
+		c.organizationID = orgID
+	}
+}
+
+// WithRequestBaseURL sends a single request to baseURL instead of the client's base
+// URL, for example to target another region. The URL is used as given: unlike
+// WithBaseURL, a trailing slash is not removed, so pass it without one.
+func WithRequestBaseURL(baseURL string) RequestOption {
+	return func(c *requestConfig) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithForce makes a delete go ahead even when other resources still depend on what
+// is being deleted, deleting the dependents too. EventTypes.BulkDelete uses it to also
+// delete subscriptions to the event types; other requests ignore it.
+func WithForce() RequestOption {
+	return func(c *requestConfig) {
+		c.force = true
+	}
+}
+
+// ResponseMeta describes the HTTP response to a request made with WithResponseMeta.
+type ResponseMeta struct {
+	StatusCode int
+	RequestID  string
+	Header     http.Header
+	// Quotas holds the plan quotas reported in X-Quota-{Dimension}-Used, -Limit, and
+	// -Reset headers, keyed by the lowercased dimension, such as "events". It is nil
+	// if the response carried none.
+	Quotas map[string]UsageQuota
+}
+
+// WithResponseMeta fills meta from the response to the request, including error
+// responses. When the request is retried, meta describes the last attempt.
+func WithResponseMeta(meta *ResponseMeta) RequestOption {
+	return func(c *requestConfig) {
+		c.responseMeta = meta
+	}
+}
+
+// withIfMatch makes a write conditional on the resource still having etag, the ETag
+// header of an earlier response. The API rejects stale writes with 409 or 412.
+func withIfMatch(etag string) RequestOption {
+	return func(c *requestConfig) {
+		c.ifMatch = etag
+	}
+}
+
+// withMaxResponseSize fails the request with an error instead of reading a response
+// body larger than n bytes.
+func withMaxResponseSize(n int64) RequestOption {
+	return func(c *requestConfig) {
+		c.maxBodyBytes = n
+	}
+}
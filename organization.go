@@ -0,0 +1,167 @@
+package hookbase
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// MemberRole is the role of an organization member.
+type MemberRole string
+
+// Organization member roles.
+const (
+	RoleOwner  MemberRole = "owner"
+	RoleAdmin  MemberRole = "admin"
+	RoleMember MemberRole = "member"
+	RoleViewer MemberRole = "viewer"
+)
+
+// Organization represents the organization the API key belongs to.
+type Organization struct {
+	ID                string             `json:"id"`
+	Name              string             `json:"name"`
+	Slug              string             `json:"slug"`
+	Plan              string             `json:"plan"`
+	NotificationEmail *string            `json:"notificationEmail"`
+	Limits            OrganizationLimits `json:"limits"`
+	CreatedAt         string             `json:"createdAt"`
+	UpdatedAt         string             `json:"updatedAt"`
+}
+
+// OrganizationLimits are the limits of an organization's plan. A nil limit means
+// unlimited.
+type OrganizationLimits struct {
+	EventsPerMonth *int `json:"eventsPerMonth"`
+	Endpoints      *int `json:"endpoints"`
+	Members        *int `json:"members"`
+	RetentionDays  int  `json:"retentionDays"`
+}
+
+// UpdateOrganizationParams are the parameters for updating the organization.
+type UpdateOrganizationParams struct {
+	Name *string `json:"name,omitempty"`
+	// NotificationEmail is the default address for failure and usage notifications.
+	NotificationEmail *string `json:"notificationEmail,omitempty"`
+}
+
+// OrganizationMember is a user in the organization, or a pending invitation.
+type OrganizationMember struct {
+	ID        string     `json:"id"`
+	UserID    *string    `json:"userId"`
+	Email     string     `json:"email"`
+	Name      *string    `json:"name"`
+	Role      MemberRole `json:"role"`
+	Status    string     `json:"status"`
+	InvitedAt *string    `json:"invitedAt"`
+	JoinedAt  *string    `json:"joinedAt"`
+}
+
+// UsageQuota is the consumption of one plan limit. Limit is nil when the plan is
+// unlimited.
+type UsageQuota struct {
+	Used  int  `json:"used"`
+	Limit *int `json:"limit"`
+	// ResetsAt is when Used returns to zero, or nil if the quota does not reset.
+	ResetsAt *string `json:"resetsAt"`
+}
+
+// Exceeded reports whether Used has reached Limit.
+func (q UsageQuota) Exceeded() bool {
+	return q.Limit != nil && q.Used >= *q.Limit
+}
+
+// Fraction returns Used as a fraction of Limit, or 0 if the quota is unlimited.
+func (q UsageQuota) Fraction() float64 {
+	if q.Limit == nil || *q.Limit <= 0 {
+		return 0
+	}
+	return float64(q.Used) / float64(*q.Limit)
+}
+
+// OrganizationUsage is the organization's consumption in the current billing period
+// against its plan limits.
+type OrganizationUsage struct {
+	Plan          string     `json:"plan"`
+	PeriodStart   string     `json:"periodStart"`
+	PeriodEnd     string     `json:"periodEnd"`
+	Events        UsageQuota `json:"events"`
+	Endpoints     UsageQuota `json:"endpoints"`
+	Members       UsageQuota `json:"members"`
+	RetentionDays int        `json:"retentionDays"`
+}
+
+// OrganizationResource provides access to the organization of the API key.
+type OrganizationResource struct {
+	t *transport
+}
+
+// Get returns the organization, including its plan limits.
+func (r *OrganizationResource) Get(ctx context.Context, opts ...RequestOption) (*Organization, error) {
+	var resp struct {
+		Data Organization `json:"data"`
+	}
+	if err := r.t.do(ctx, "GET", "/api/organization", nil, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Update updates the organization's name and notification defaults.
+func (r *OrganizationResource) Update(ctx context.Context, params *UpdateOrganizationParams, opts ...RequestOption) (*Organization, error) {
+	var resp struct {
+		Data Organization `json:"data"`
+	}
+	if err := r.t.do(ctx, "PATCH", "/api/organization", nil, params, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// ListMembers returns the organization's members and pending invitations.
+func (r *OrganizationResource) ListMembers(ctx context.Context, opts ...RequestOption) ([]OrganizationMember, error) {
+	var resp struct {
+		Data []OrganizationMember `json:"data"`
+	}
+	if err := r.t.do(ctx, "GET", "/api/organization/members", nil, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// InviteMember invites email to the organization with role. The returned member's
+// Status is "invited" until the invitation is accepted.
+func (r *OrganizationResource) InviteMember(ctx context.Context, email string, role MemberRole, opts ...RequestOption) (*OrganizationMember, error) {
+	if !strings.Contains(email, "@") {
+		return nil, newClientValidationError("email", "must be an email address")
+	}
+	if role == "" {
+		return nil, newClientValidationError("role", "is required")
+	}
+	body := map[string]interface{}{"email": email, "role": role}
+	var resp struct {
+		Data OrganizationMember `json:"data"`
+	}
+	if err := r.t.do(ctx, "POST", "/api/organization/members", nil, body, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// RemoveMember removes a member from the organization, or cancels a pending
+// invitation.
+func (r *OrganizationResource) RemoveMember(ctx context.Context, memberID string, opts ...RequestOption) error {
+	return r.t.do(ctx, "DELETE", "/api/organization/members/"+url.PathEscape(memberID), nil, nil, nil, opts...)
+}
+
+// GetUsage returns the organization's consumption in the current billing period,
+// such as events sent this month and endpoints in use, against its plan limits.
+func (r *OrganizationResource) GetUsage(ctx context.Context, opts ...RequestOption) (*OrganizationUsage, error) {
+	var resp struct {
+		Data OrganizationUsage `json:"data"`
+	}
+	if err := r.t.do(ctx, "GET", "/api/organization/usage", nil, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
@@ -2,7 +2,19 @@ package hookbase
 
 import (
 	"context"
+	"fmt"
 	"net/url"
+	"strings"
+)
+
+// Portal token scopes, which limit what a customer can do in the embedded portal.
+const (
+	ScopePortalReadEndpoints       = "endpoints:read"
+	ScopePortalManageEndpoints     = "endpoints:write"
+	ScopePortalReadMessages        = "messages:read"
+	ScopePortalRetryMessages       = "messages:retry"
+	ScopePortalReadEventTypes      = "event-types:read"
+	ScopePortalManageSubscriptions = "subscriptions:write"
 )
 
 // PortalToken represents an embeddable portal access token.
@@ -27,6 +39,41 @@ type CreatePortalTokenParams struct {
 	AllowedIPs    []string `json:"allowedIps,omitempty"`
 }
 
+// PortalTokenValidation describes whether a portal token can still be used.
+type PortalTokenValidation struct {
+	Valid     bool     `json:"valid"`
+	Expired   bool     `json:"expired"`
+	Revoked   bool     `json:"revoked"`
+	Scopes    []string `json:"scopes"`
+	ExpiresAt string   `json:"expiresAt"`
+}
+
+// PortalTokenInfo describes a portal token as seen by the introspection endpoint.
+type PortalTokenInfo struct {
+	TokenID       string   `json:"tokenId"`
+	ApplicationID string   `json:"applicationId"`
+	Scopes        []string `json:"scopes"`
+	AllowedIPs    []string `json:"allowedIps,omitempty"`
+	ExpiresAt     string   `json:"expiresAt"`
+	IsExpired     bool     `json:"isExpired"`
+	IsRevoked     bool     `json:"isRevoked"`
+}
+
+// Active reports whether the token is neither expired nor revoked.
+func (i *PortalTokenInfo) Active() bool {
+	return !i.IsExpired && !i.IsRevoked
+}
+
+// HasScope reports whether the token was granted scope.
+func (i *PortalTokenInfo) HasScope(scope string) bool {
+	for _, s := range i.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
 // PortalTokensResource provides access to portal token-related API endpoints.
 type PortalTokensResource struct {
 	t *transport
@@ -46,6 +93,66 @@ func (r *PortalTokensResource) Create(ctx context.Context, applicationID string,
 	return &resp.Data, nil
 }
 
+// GetEmbedURL creates a portal token and returns the full URL for embedding the
+// customer portal, such as https://portal.hookbase.app/embed?token=... The portal
+// host can be changed with WithPortalBaseURL.
+func (r *PortalTokensResource) GetEmbedURL(ctx context.Context, applicationID string, params *CreatePortalTokenParams, opts ...RequestOption) (string, error) {
+	token, err := r.Create(ctx, applicationID, params, opts...)
+	if err != nil {
+		return "", err
+	}
+	if token.Token == nil || *token.Token == "" {
+		return "", &Error{Message: "hookbase: portal token response did not include a token"}
+	}
+	return PortalURL(r.t.portalBaseURL, *token.Token)
+}
+
+// PortalURLOption configures the portal URL built by PortalURL.
+type PortalURLOption func(url.Values)
+
+// WithPortalTheme sets the portal color theme, such as "light" or "dark".
+func WithPortalTheme(theme string) PortalURLOption {
+	return func(q url.Values) {
+		q.Set("theme", theme)
+	}
+}
+
+// WithPortalLocale sets the portal language as a BCP 47 tag, such as "en-US".
+func WithPortalLocale(locale string) PortalURLOption {
+	return func(q url.Values) {
+		q.Set("locale", locale)
+	}
+}
+
+// WithPortalSection opens the portal on a section, such as "endpoints" or "messages".
+func WithPortalSection(section string) PortalURLOption {
+	return func(q url.Values) {
+		q.Set("section", section)
+	}
+}
+
+// PortalURL builds the customer-facing portal URL for a portal token, such as
+// https://portal.hookbase.app/embed?token=... baseURL may include a path prefix.
+// It makes no API calls.
+func PortalURL(baseURL, token string, opts ...PortalURLOption) (string, error) {
+	if token == "" {
+		return "", newClientValidationError("token", "is required")
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", newClientValidationError("baseURL", fmt.Sprintf("invalid portal base URL %q", baseURL))
+	}
+	u.Path = strings.TrimRight(u.Path, "/") + "/embed"
+	u.RawPath = ""
+	q := u.Query()
+	q.Set("token", token)
+	for _, opt := range opts {
+		opt(q)
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
 // List returns portal tokens for an application.
 func (r *PortalTokensResource) List(ctx context.Context, applicationID string, opts ...RequestOption) ([]PortalToken, error) {
 	var resp struct {
@@ -61,3 +168,74 @@ func (r *PortalTokensResource) List(ctx context.Context, applicationID string, o
 func (r *PortalTokensResource) Revoke(ctx context.Context, applicationID, tokenID string, opts ...RequestOption) error {
 	return r.t.do(ctx, "DELETE", "/api/portal/tokens/"+url.PathEscape(tokenID), nil, nil, nil, opts...)
 }
+
+// BulkRevoke revokes every portal token for an application, immediately ending all
+// active portal sessions. Use it when a customer's account is suspended or deleted.
+// This cannot be undone: revoked tokens cannot be restored, and new tokens must be
+// created and distributed.
+func (r *PortalTokensResource) BulkRevoke(ctx context.Context, applicationID string, opts ...RequestOption) (*BulkUpdateResult, error) {
+	var resp BulkUpdateResult
+	if err := r.t.do(ctx, "DELETE", "/api/portal/webhook-applications/"+url.PathEscape(applicationID)+"/tokens", nil, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Validate reports whether a portal token is still valid, expired, or revoked.
+func (r *PortalTokensResource) Validate(ctx context.Context, applicationID string, token string, opts ...RequestOption) (*PortalTokenValidation, error) {
+	var resp struct {
+		Data PortalTokenValidation `json:"data"`
+	}
+	body := map[string]interface{}{"token": token}
+	if err := r.t.do(ctx, "POST", "/api/portal/tokens/validate", nil, body, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Introspect looks up a portal token presented by the embedded portal, returning the
+// application it is bound to, its scopes, allowed IPs, and expiry. Expired and revoked
+// tokens are returned with IsExpired or IsRevoked set; a token the API does not
+// recognize returns *InvalidPortalTokenError. Other authentication failures, such as
+// an invalid API key, return *AuthenticationError.
+func (r *PortalTokensResource) Introspect(ctx context.Context, token string, opts ...RequestOption) (*PortalTokenInfo, error) {
+	if r.t.clientValidation && token == "" {
+		return nil, newClientValidationError("token", "is required")
+	}
+	var resp struct {
+		Data PortalTokenInfo `json:"data"`
+	}
+	body := map[string]interface{}{"token": token}
+	if err := r.t.do(ctx, "POST", "/api/portal/tokens/introspect", nil, body, &resp, opts...); err != nil {
+		if authErr, ok := err.(*AuthenticationError); ok && authErr.Code == CodeInvalidToken {
+			return nil, &InvalidPortalTokenError{APIError: authErr.APIError}
+		}
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Refresh extends a portal token's expiry by extendByDays. The token keeps its ID and
+// value, so portal sessions using it are not interrupted.
+func (r *PortalTokensResource) Refresh(ctx context.Context, applicationID string, tokenID string, extendByDays int, opts ...RequestOption) (*PortalToken, error) {
+	if extendByDays <= 0 {
+		return nil, newClientValidationError("extendByDays", "must be greater than 0")
+	}
+	var resp struct {
+		Data PortalToken `json:"data"`
+	}
+	body := map[string]interface{}{"extendByDays": extendByDays}
+	if err := r.t.do(ctx, "POST", "/api/portal/tokens/"+url.PathEscape(tokenID)+"/refresh", nil, body, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Extend pushes a portal token's expiry back by additionalDays. It is equivalent to
+// Refresh.
+func (r *PortalTokensResource) Extend(ctx context.Context, applicationID string, tokenID string, additionalDays int, opts ...RequestOption) (*PortalToken, error) {
+	if r.t.clientValidation && additionalDays <= 0 {
+		return nil, newClientValidationError("additionalDays", "must be greater than 0")
+	}
+	return r.Refresh(ctx, applicationID, tokenID, additionalDays, opts...)
+}
@@ -0,0 +1,411 @@
+package hookbase
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// RecorderMode selects whether a Recorder records live API traffic or replays a
+// cassette.
+type RecorderMode string
+
+const (
+	// RecordMode sends requests to the API and records each request/response pair.
+	RecordMode RecorderMode = "record"
+	// ReplayMode answers requests from the cassette without using the network.
+	ReplayMode RecorderMode = "replay"
+)
+
+// recorderSkippedHeaders are response headers that are not recorded.
+var recorderSkippedHeaders = map[string]bool{
+	"Set-Cookie":        true,
+	"Content-Length":    true,
+	"Content-Encoding":  true,
+	"Transfer-Encoding": true,
+}
+
+// Interaction is one recorded request/response pair in a cassette.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the sanitized request of an Interaction.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Body   string `json:"body,omitempty"`
+}
+
+// RecordedResponse is the sanitized response of an Interaction.
+type RecordedResponse struct {
+	StatusCode int               `json:"statusCode"`
+	Header     map[string]string `json:"header,omitempty"`
+	Body       string            `json:"body,omitempty"`
+}
+
+type cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// RecorderOption configures how a Recorder matches requests in replay mode.
+type RecorderOption func(*Recorder)
+
+// WithIgnoredBodyFields excludes JSON object keys, at any depth, from request body
+// matching. Use it for volatile fields such as generated event IDs or timestamps.
+func WithIgnoredBodyFields(fields ...string) RecorderOption {
+	return func(r *Recorder) {
+		for _, f := range fields {
+			r.ignoredFields[f] = true
+		}
+	}
+}
+
+// WithIgnoredQueryParams excludes query parameters from request matching.
+func WithIgnoredQueryParams(params ...string) RecorderOption {
+	return func(r *Recorder) {
+		for _, p := range params {
+			r.ignoredParams[p] = true
+		}
+	}
+}
+
+// Recorder records API interactions to a JSON cassette file or replays them, for
+// running integration tests without a live account. Attach it to a client with
+// WithRecorder.
+//
+// In record mode requests go to the API and each request/response pair is kept in
+// memory until Save writes the cassette. The Authorization header is never recorded,
+// and bodies are redacted the same way as WithDebug logs: secrets, tokens, passwords,
+// notification webhook URLs and routing keys, and custom headers become "[REDACTED]".
+//
+// In replay mode a request is answered by the first unused interaction with the same
+// method, path, query (sorted), and body; once all such interactions are used, the
+// last one is reused. A request without a match fails with a *ReplayMismatchError.
+type Recorder struct {
+	mode          RecorderMode
+	path          string
+	ignoredFields map[string]bool
+	ignoredParams map[string]bool
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewRecorder returns a Recorder for the cassette at path. In replay mode the
+// cassette is read immediately and must exist.
+func NewRecorder(path string, mode RecorderMode, opts ...RecorderOption) (*Recorder, error) {
+	if mode != RecordMode && mode != ReplayMode {
+		return nil, &Error{Message: fmt.Sprintf("hookbase: unknown recorder mode %q", mode)}
+	}
+	r := &Recorder{
+		mode:          mode,
+		path:          path,
+		ignoredFields: make(map[string]bool),
+		ignoredParams: make(map[string]bool),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if mode == ReplayMode {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, &Error{Message: fmt.Sprintf("hookbase: failed to read cassette: %v", err)}
+		}
+		var c cassette
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, &Error{Message: fmt.Sprintf("hookbase: failed to parse cassette %s: %v", path, err)}
+		}
+		r.interactions = c.Interactions
+		r.used = make([]bool, len(c.Interactions))
+	}
+	return r, nil
+}
+
+// Mode returns the recorder's mode.
+func (r *Recorder) Mode() RecorderMode {
+	return r.mode
+}
+
+// Interactions returns a copy of the recorded or loaded interactions.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.interactions...)
+}
+
+// Save writes the recorded interactions to the cassette file. It does nothing in
+// replay mode.
+func (r *Recorder) Save() error {
+	if r.mode != RecordMode {
+		return nil
+	}
+	r.mu.Lock()
+	data, err := json.MarshalIndent(cassette{Interactions: r.interactions}, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return &Error{Message: fmt.Sprintf("hookbase: failed to encode cassette: %v", err)}
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return &Error{Message: fmt.Sprintf("hookbase: failed to write cassette: %v", err)}
+	}
+	return nil
+}
+
+// roundTripper returns an http.RoundTripper that records through base or replays.
+func (r *Recorder) roundTripper(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return recorderTransport{r: r, base: base}
+}
+
+type recorderTransport struct {
+	r    *Recorder
+	base http.RoundTripper
+}
+
+func (rt recorderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	recorded := RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.RawQuery,
+		Body:   string(scrubJSON(body, redactedFields, nil)),
+	}
+	if rt.r.mode == ReplayMode {
+		return rt.r.replay(req, recorded)
+	}
+	return rt.r.record(req, recorded, rt.base)
+}
+
+func (r *Recorder) record(req *http.Request, recorded RecordedRequest, base http.RoundTripper) (*http.Response, error) {
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	reader := io.Reader(resp.Body)
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		zr, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		reader = zr
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make(map[string]string)
+	for k := range resp.Header {
+		if !recorderSkippedHeaders[k] {
+			header[k] = resp.Header.Get(k)
+		}
+	}
+	r.mu.Lock()
+	r.interactions = append(r.interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     header,
+			Body:       string(scrubJSON(body, redactedFields, nil)),
+		},
+	})
+	r.mu.Unlock()
+
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = int64(len(body))
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	key := r.matchKey(recorded)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	match := -1
+	for i, in := range r.interactions {
+		if r.matchKey(in.Request) != key {
+			continue
+		}
+		match = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, r.mismatch(recorded)
+	}
+	r.used[match] = true
+
+	in := r.interactions[match].Response
+	header := make(http.Header)
+	for k, v := range in.Header {
+		header.Set(k, v)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", in.StatusCode, http.StatusText(in.StatusCode)),
+		StatusCode:    in.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(in.Body)),
+		ContentLength: int64(len(in.Body)),
+		Request:       req,
+	}, nil
+}
+
+// requestKey is the normalized form of a request used for matching.
+type requestKey struct {
+	method, path, query, bodyHash string
+}
+
+func (r *Recorder) matchKey(req RecordedRequest) requestKey {
+	query, _ := url.ParseQuery(req.Query)
+	for p := range r.ignoredParams {
+		query.Del(p)
+	}
+	sum := sha256.Sum256(scrubJSON([]byte(req.Body), nil, r.ignoredFields))
+	return requestKey{
+		method:   req.Method,
+		path:     req.Path,
+		query:    query.Encode(),
+		bodyHash: hex.EncodeToString(sum[:]),
+	}
+}
+
+// mismatch builds the error for a request with no recorded match, naming the
+// recorded interaction that differs in the fewest parts.
+func (r *Recorder) mismatch(recorded RecordedRequest) *ReplayMismatchError {
+	err := &ReplayMismatchError{Method: recorded.Method, Path: recorded.Path, Query: recorded.Query}
+	key := r.matchKey(recorded)
+	best := -1
+	for _, in := range r.interactions {
+		k := r.matchKey(in.Request)
+		var diffs []string
+		if k.method != key.method {
+			diffs = append(diffs, "method")
+		}
+		if k.path != key.path {
+			diffs = append(diffs, "path")
+		}
+		if k.query != key.query {
+			diffs = append(diffs, "query")
+		}
+		if k.bodyHash != key.bodyHash {
+			diffs = append(diffs, "body")
+		}
+		// A different path outweighs every other difference.
+		score := len(diffs)
+		if k.path != key.path {
+			score += 4
+		}
+		if best < 0 || score < best {
+			best = score
+			closest := in.Request
+			err.Closest = &closest
+			err.Differences = diffs
+		}
+	}
+	return err
+}
+
+// ReplayMismatchError is returned when a Recorder in replay mode has no recorded
+// interaction matching a request. It is not retried.
+type ReplayMismatchError struct {
+	Method string
+	Path   string
+	Query  string
+	// Closest is the recorded request that differs in the fewest parts, or nil if the
+	// cassette is empty.
+	Closest *RecordedRequest
+	// Differences names the parts of Closest that differ: "method", "path", "query",
+	// or "body".
+	Differences []string
+}
+
+func (e *ReplayMismatchError) Error() string {
+	target := e.Method + " " + e.Path
+	if e.Query != "" {
+		target += "?" + e.Query
+	}
+	msg := "hookbase: no recorded interaction matches " + target
+	if e.Closest == nil {
+		return msg + "; the cassette is empty"
+	}
+	closest := e.Closest.Method + " " + e.Closest.Path
+	if e.Closest.Query != "" {
+		closest += "?" + e.Closest.Query
+	}
+	return fmt.Sprintf("%s; closest is %s (differs in %s)", msg, closest, strings.Join(e.Differences, ", "))
+}
+
+// scrubJSON re-encodes a JSON body with sorted keys, replacing the values of redact
+// keys and removing drop keys at any depth. Bodies that are not JSON are returned
+// unchanged.
+func scrubJSON(body []byte, redact, drop map[string]bool) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return body
+	}
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch val := v.(type) {
+		case map[string]interface{}:
+			for k, child := range val {
+				switch {
+				case drop[k]:
+					delete(val, k)
+				case redact[k]:
+					val[k] = "[REDACTED]"
+				default:
+					walk(child)
+				}
+			}
+		case []interface{}:
+			for _, child := range val {
+				walk(child)
+			}
+		}
+	}
+	walk(v)
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return body
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
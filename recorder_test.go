@@ -0,0 +1,115 @@
+package hookbase
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRecorderRecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req_1")
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/sources":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			json.NewEncoder(w).Encode(map[string]interface{}{"source": map[string]interface{}{
+				"id": "src_1", "name": body["name"], "signingSecret": "whsec_live",
+			}})
+		case r.Method == "GET" && r.URL.Path == "/api/sources/src_1":
+			w.Write([]byte(`{"source":{"id":"src_1","name":"Orders"}}`))
+		default:
+			w.WriteHeader(404)
+			w.Write([]byte(`{"error":{"message":"not found"}}`))
+		}
+	}))
+	cassettePath := filepath.Join(t.TempDir(), "sources.json")
+	ctx := context.Background()
+	desc := "first run"
+
+	rec, err := NewRecorder(cassettePath, RecordMode)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client := New("test_key", WithBaseURL(server.URL), WithRecorder(rec))
+	created, err := client.Sources.Create(ctx, &CreateSourceParams{Name: "Orders", Description: &desc})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created.SigningSecret == nil || *created.SigningSecret != "whsec_live" {
+		t.Errorf("expected the live secret while recording, got %v", created.SigningSecret)
+	}
+	if _, err := client.Sources.Get(ctx, "src_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	server.Close()
+
+	data, err := os.ReadFile(cassettePath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(data), "test_key") || strings.Contains(string(data), "whsec_live") {
+		t.Errorf("expected cassette to be sanitized, got %s", data)
+	}
+
+	replay, err := NewRecorder(cassettePath, ReplayMode, WithIgnoredBodyFields("description"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client = New("test_key", WithBaseURL(server.URL), WithRecorder(replay))
+	desc = "second run"
+	replayed, err := client.Sources.Create(ctx, &CreateSourceParams{Name: "Orders", Description: &desc})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if replayed.ID != "src_1" || *replayed.SigningSecret != "[REDACTED]" {
+		t.Errorf("unexpected replayed source: %+v", replayed)
+	}
+	for i := 0; i < 2; i++ {
+		src, err := client.Sources.Get(ctx, "src_1")
+		if err != nil || src.Name != "Orders" {
+			t.Errorf("unexpected replay %d: %+v, %v", i, src, err)
+		}
+	}
+
+	_, err = client.Sources.Get(ctx, "src_2")
+	mismatch, ok := err.(*ReplayMismatchError)
+	if !ok {
+		t.Fatalf("expected ReplayMismatchError, got %T: %v", err, err)
+	}
+	if mismatch.Closest == nil || mismatch.Closest.Path != "/api/sources/src_1" || !reflect.DeepEqual(mismatch.Differences, []string{"path"}) {
+		t.Errorf("unexpected mismatch: %+v", mismatch)
+	}
+	if !strings.Contains(err.Error(), "closest is GET /api/sources/src_1 (differs in path)") {
+		t.Errorf("unexpected message: %s", err)
+	}
+
+	_, err = client.Sources.Create(ctx, &CreateSourceParams{Name: "Billing"})
+	if mismatch, ok := err.(*ReplayMismatchError); !ok || !reflect.DeepEqual(mismatch.Differences, []string{"body"}) {
+		t.Errorf("expected body mismatch, got %v", err)
+	}
+}
+
+func TestRecorderRedactsLoggedFields(t *testing.T) {
+	body := []byte(`{"config":{"webhookUrl":"https://hooks.slack.com/T0/B0/x","routingKey":"pd_abc","password":"hunter2"},"headers":{"Authorization":"Bearer abc"},"name":"Ops"}`)
+	want := `{"config":{"password":"[REDACTED]","routingKey":"[REDACTED]","webhookUrl":"[REDACTED]"},"headers":"[REDACTED]","name":"Ops"}`
+	if got := string(scrubJSON(body, redactedFields, nil)); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestRecorderReplayMissingCassette(t *testing.T) {
+	if _, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"), ReplayMode); err == nil {
+		t.Error("expected error for missing cassette")
+	}
+}
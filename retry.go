@@ -0,0 +1,100 @@
+package hookbase
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried. Set it with WithRetryPolicy,
+// usually starting from ExponentialBackoff, LinearBackoff, or FixedInterval.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt.
+	MaxRetries int
+	// InitialInterval is the wait before the first retry.
+	InitialInterval time.Duration
+	// MaxInterval caps the wait between retries. Zero means no cap.
+	MaxInterval time.Duration
+	// Multiplier scales the wait after each retry. Values below 1 are treated as 1.
+	Multiplier float64
+	// Jitter adds a random extra wait of up to this fraction of the interval, so 0.2
+	// waits between 100% and 120% of the interval.
+	Jitter float64
+	// ShouldRetry reports whether to retry after err on the given attempt (0 for the
+	// first). If nil, network errors, rate limits (429), and server errors are retried,
+	// and other client errors are not. Rate-limited (429) requests, and 503 responses
+	// that carry Retry-After, wait for the Retry-After period instead of the policy
+	// interval.
+	ShouldRetry func(err error, attempt int) bool
+
+	// linear grows the interval by InitialInterval per retry instead of by Multiplier.
+	linear bool
+}
+
+// ExponentialBackoff returns a policy whose wait starts at initial and doubles after
+// each retry up to max, with 20% jitter.
+func ExponentialBackoff(maxRetries int, initial, max time.Duration) RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:      maxRetries,
+		InitialInterval: initial,
+		MaxInterval:     max,
+		Multiplier:      2,
+		Jitter:          0.2,
+	}
+}
+
+// LinearBackoff returns a policy whose wait grows by increment after each retry
+// (increment, 2*increment, ...) up to max, with 20% jitter.
+func LinearBackoff(maxRetries int, increment, max time.Duration) RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:      maxRetries,
+		InitialInterval: increment,
+		MaxInterval:     max,
+		Multiplier:      1,
+		Jitter:          0.2,
+		linear:          true,
+	}
+}
+
+// FixedInterval returns a policy that waits interval between every retry, without
+// jitter.
+func FixedInterval(maxRetries int, interval time.Duration) RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:      maxRetries,
+		InitialInterval: interval,
+		MaxInterval:     interval,
+		Multiplier:      1,
+	}
+}
+
+// interval returns the wait before retrying after the given attempt, before jitter.
+func (p *RetryPolicy) interval(attempt int) time.Duration {
+	var d float64
+	if p.linear {
+		d = float64(p.InitialInterval) * float64(attempt+1)
+	} else {
+		d = float64(p.InitialInterval) * math.Pow(math.Max(p.Multiplier, 1), float64(attempt))
+	}
+	if p.MaxInterval > 0 && d > float64(p.MaxInterval) {
+		d = float64(p.MaxInterval)
+	}
+	return time.Duration(d)
+}
+
+// wait returns the jittered wait before retrying after the given attempt.
+func (p *RetryPolicy) wait(attempt int) time.Duration {
+	d := p.interval(attempt)
+	if p.Jitter > 0 {
+		d += time.Duration(rand.Float64() * p.Jitter * float64(d))
+	}
+	return d
+}
+
+// defaultShouldRetry retries everything except client errors other than 429.
+func defaultShouldRetry(err error) bool {
+	switch err.(type) {
+	case *AuthenticationError, *ForbiddenError, *NotFoundError, *ConflictError, *ValidationError:
+		return false
+	}
+	return true
+}
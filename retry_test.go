@@ -0,0 +1,71 @@
+package hookbase
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyIntervals(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy RetryPolicy
+		want   []time.Duration
+	}{
+		{"exponential", ExponentialBackoff(5, 100*time.Millisecond, time.Second), []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second}},
+		{"linear", LinearBackoff(5, 100*time.Millisecond, 250*time.Millisecond), []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 250 * time.Millisecond, 250 * time.Millisecond, 250 * time.Millisecond}},
+		{"fixed", FixedInterval(3, 50*time.Millisecond), []time.Duration{50 * time.Millisecond, 50 * time.Millisecond, 50 * time.Millisecond}},
+		{"custom", RetryPolicy{InitialInterval: 10 * time.Millisecond, Multiplier: 3}, []time.Duration{10 * time.Millisecond, 30 * time.Millisecond, 90 * time.Millisecond}},
+	}
+	for _, tt := range tests {
+		for attempt, want := range tt.want {
+			if got := tt.policy.interval(attempt); got != want {
+				t.Errorf("%s: attempt %d: expected %v, got %v", tt.name, attempt, want, got)
+			}
+		}
+	}
+
+	p := ExponentialBackoff(3, 100*time.Millisecond, time.Second)
+	for i := 0; i < 50; i++ {
+		if d := p.wait(1); d < 200*time.Millisecond || d > 240*time.Millisecond {
+			t.Fatalf("jittered wait %v outside [200ms, 240ms]", d)
+		}
+	}
+}
+
+func TestWithRetryPolicy(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(404)
+			w.Write([]byte(`{"error":{"message":"not yet replicated","code":"not_found"}}`))
+			return
+		}
+		w.Write([]byte(`{"data":{"id":"app_1"}}`))
+	}))
+	defer server.Close()
+
+	var seen []int
+	policy := FixedInterval(5, time.Millisecond)
+	policy.ShouldRetry = func(err error, attempt int) bool {
+		seen = append(seen, attempt)
+		_, notFound := err.(*NotFoundError)
+		return notFound
+	}
+	client := New("test_key", WithBaseURL(server.URL), WithRetryPolicy(policy))
+	if _, err := client.Applications.Get(context.Background(), "app_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempts != 3 || len(seen) != 2 || seen[0] != 0 || seen[1] != 1 {
+		t.Errorf("expected 3 attempts with ShouldRetry called for attempts 0 and 1, got %d attempts, %v", attempts, seen)
+	}
+
+	attempts = 0
+	client = New("test_key", WithBaseURL(server.URL), WithRetryPolicy(FixedInterval(5, time.Millisecond)))
+	if _, err := client.Applications.Get(context.Background(), "app_1"); err == nil || attempts != 1 {
+		t.Errorf("expected the default ShouldRetry not to retry 404, got %d attempts, %v", attempts, err)
+	}
+}
@@ -121,6 +121,15 @@ func (p *ListRoutesParams) toQuery() url.Values {
 	return q
 }
 
+// RouteRef is a lightweight reference to a route, used when reporting which
+// routes depend on another resource.
+type RouteRef struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	SourceID string `json:"sourceId"`
+	IsActive bool   `json:"isActive"`
+}
+
 // CircuitStatusInfo contains circuit breaker status for a route.
 type CircuitStatusInfo struct {
 	CircuitState                 CircuitState `json:"circuitState"`
@@ -286,3 +295,30 @@ func (r *RoutesResource) ResetCircuit(ctx context.Context, routeID string, opts
 func (r *RoutesResource) UpdateCircuitConfig(ctx context.Context, routeID string, config *CircuitBreakerConfig, opts ...RequestOption) error {
 	return r.t.do(ctx, "PATCH", "/api/routes/"+url.PathEscape(routeID)+"/circuit-config", nil, config, nil, opts...)
 }
+
+// findReferences walks every page of routes and returns those for which match reports true.
+func (r *RoutesResource) findReferences(ctx context.Context, match func(*Route) bool, opts ...RequestOption) ([]RouteRef, error) {
+	var refs []RouteRef
+	params := &ListRoutesParams{Page: Ptr(1), PageSize: Ptr(100)}
+	for {
+		page, err := r.List(ctx, params, opts...)
+		if err != nil {
+			return nil, err
+		}
+		for i := range page.Data {
+			route := &page.Data[i]
+			if match(route) {
+				refs = append(refs, RouteRef{
+					ID:       route.ID,
+					Name:     route.Name,
+					SourceID: route.SourceID,
+					IsActive: route.IsActive.Bool(),
+				})
+			}
+		}
+		if !page.HasMore || len(page.Data) == 0 {
+			return refs, nil
+		}
+		params.Page = Ptr(*params.Page + 1)
+	}
+}
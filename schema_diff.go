@@ -0,0 +1,167 @@
+package hookbase
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SchemaChangeKind classifies a difference between two JSON Schemas.
+type SchemaChangeKind string
+
+const (
+	SchemaPropertyAdded   SchemaChangeKind = "property_added"
+	SchemaPropertyRemoved SchemaChangeKind = "property_removed"
+	SchemaTypeChanged     SchemaChangeKind = "type_changed"
+	SchemaRequiredAdded   SchemaChangeKind = "required_added"
+	SchemaRequiredRemoved SchemaChangeKind = "required_removed"
+)
+
+// SchemaChange describes a single difference between two JSON Schemas.
+// Breaking is true when payloads valid under the old schema may be rejected
+// by the new one, or consumers relying on the old shape may break.
+type SchemaChange struct {
+	Kind     SchemaChangeKind
+	Path     string
+	Old      interface{}
+	New      interface{}
+	Breaking bool
+}
+
+func (c SchemaChange) String() string {
+	switch c.Kind {
+	case SchemaTypeChanged:
+		return fmt.Sprintf("%s: %s (%v -> %v)", c.Path, c.Kind, c.Old, c.New)
+	default:
+		return fmt.Sprintf("%s: %s", c.Path, c.Kind)
+	}
+}
+
+// DiffSchemas compares two JSON Schema documents and reports added, removed, and
+// retyped properties and changes to required lists. Nested object properties and
+// array items are compared recursively. Paths use dot notation, with "[]" for array items.
+func DiffSchemas(a, b map[string]interface{}) []SchemaChange {
+	var changes []SchemaChange
+	diffSchemaNode(a, b, "", &changes)
+	return changes
+}
+
+// HasBreakingChanges reports whether any of the changes are breaking.
+func HasBreakingChanges(changes []SchemaChange) bool {
+	for _, c := range changes {
+		if c.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+func diffSchemaNode(a, b map[string]interface{}, path string, changes *[]SchemaChange) {
+	if !typesEqual(a["type"], b["type"]) {
+		*changes = append(*changes, SchemaChange{
+			Kind:     SchemaTypeChanged,
+			Path:     displayPath(path),
+			Old:      a["type"],
+			New:      b["type"],
+			Breaking: true,
+		})
+		return
+	}
+
+	oldRequired := stringSet(a["required"])
+	newRequired := stringSet(b["required"])
+	for _, name := range sortedKeys(newRequired) {
+		if !oldRequired[name] {
+			*changes = append(*changes, SchemaChange{Kind: SchemaRequiredAdded, Path: joinPath(path, name), Breaking: true})
+		}
+	}
+	for _, name := range sortedKeys(oldRequired) {
+		if !newRequired[name] {
+			*changes = append(*changes, SchemaChange{Kind: SchemaRequiredRemoved, Path: joinPath(path, name)})
+		}
+	}
+
+	oldProps, _ := a["properties"].(map[string]interface{})
+	newProps, _ := b["properties"].(map[string]interface{})
+	names := map[string]bool{}
+	for k := range oldProps {
+		names[k] = true
+	}
+	for k := range newProps {
+		names[k] = true
+	}
+	for _, name := range sortedKeys(names) {
+		oldProp, inOld := oldProps[name].(map[string]interface{})
+		newProp, inNew := newProps[name].(map[string]interface{})
+		switch {
+		case inOld && !inNew:
+			*changes = append(*changes, SchemaChange{Kind: SchemaPropertyRemoved, Path: joinPath(path, name), Old: oldProp["type"], Breaking: true})
+		case !inOld && inNew:
+			*changes = append(*changes, SchemaChange{Kind: SchemaPropertyAdded, Path: joinPath(path, name), New: newProp["type"]})
+		case inOld && inNew:
+			diffSchemaNode(oldProp, newProp, joinPath(path, name), changes)
+		}
+	}
+
+	oldItems, okOld := a["items"].(map[string]interface{})
+	newItems, okNew := b["items"].(map[string]interface{})
+	if okOld && okNew {
+		diffSchemaNode(oldItems, newItems, path+"[]", changes)
+	}
+}
+
+func typesEqual(a, b interface{}) bool {
+	return fmt.Sprint(normalizeType(a)) == fmt.Sprint(normalizeType(b))
+}
+
+func normalizeType(t interface{}) interface{} {
+	list, ok := t.([]interface{})
+	if !ok {
+		return t
+	}
+	names := make([]string, 0, len(list))
+	for _, x := range list {
+		names = append(names, fmt.Sprint(x))
+	}
+	sort.Strings(names)
+	return names
+}
+
+func stringSet(v interface{}) map[string]bool {
+	set := map[string]bool{}
+	switch list := v.(type) {
+	case []interface{}:
+		for _, x := range list {
+			if s, ok := x.(string); ok {
+				set[s] = true
+			}
+		}
+	case []string:
+		for _, s := range list {
+			set[s] = true
+		}
+	}
+	return set
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "(root)"
+	}
+	return path
+}
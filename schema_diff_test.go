@@ -0,0 +1,127 @@
+package hookbase
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDiffSchemas(t *testing.T) {
+	old := map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"id", "status"},
+		"properties": map[string]interface{}{
+			"id":     map[string]interface{}{"type": "integer"},
+			"status": map[string]interface{}{"type": "string"},
+			"note":   map[string]interface{}{"type": "string"},
+			"items": map[string]interface{}{
+				"type":  "array",
+				"items": map[string]interface{}{"type": "object", "properties": map[string]interface{}{"sku": map[string]interface{}{"type": "string"}}},
+			},
+		},
+	}
+	updated := map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"id", "currency"},
+		"properties": map[string]interface{}{
+			"id":       map[string]interface{}{"type": "string"},
+			"status":   map[string]interface{}{"type": "string"},
+			"currency": map[string]interface{}{"type": "string"},
+			"items": map[string]interface{}{
+				"type":  "array",
+				"items": map[string]interface{}{"type": "object", "properties": map[string]interface{}{"sku": map[string]interface{}{"type": "string"}, "qty": map[string]interface{}{"type": "integer"}}},
+			},
+		},
+	}
+
+	changes := DiffSchemas(old, updated)
+	want := []struct {
+		kind     SchemaChangeKind
+		path     string
+		breaking bool
+	}{
+		{SchemaRequiredAdded, "currency", true},
+		{SchemaRequiredRemoved, "status", false},
+		{SchemaPropertyAdded, "currency", false},
+		{SchemaTypeChanged, "id", true},
+		{SchemaPropertyAdded, "items[].qty", false},
+		{SchemaPropertyRemoved, "note", true},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("expected %d changes, got %d: %v", len(want), len(changes), changes)
+	}
+	for i, w := range want {
+		c := changes[i]
+		if c.Kind != w.kind || c.Path != w.path || c.Breaking != w.breaking {
+			t.Errorf("change %d: got %s (breaking=%v), want %s %s (breaking=%v)", i, c, c.Breaking, w.path, w.kind, w.breaking)
+		}
+	}
+	if !HasBreakingChanges(changes) {
+		t.Error("expected breaking changes")
+	}
+
+	additive := DiffSchemas(old, map[string]interface{}{
+		"type":       "object",
+		"required":   []interface{}{"id"},
+		"properties": map[string]interface{}{"id": map[string]interface{}{"type": "integer"}, "status": map[string]interface{}{"type": "string"}, "note": map[string]interface{}{"type": "string"}, "items": old["properties"].(map[string]interface{})["items"], "extra": map[string]interface{}{"type": "boolean"}},
+	})
+	if HasBreakingChanges(additive) {
+		t.Errorf("expected only additive changes, got %v", additive)
+	}
+}
+
+func TestSchemasVersions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/schemas/sch_1/versions":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"versions": []map[string]interface{}{
+					{"version": 2, "jsonSchema": `{"type":"object"}`, "createdAt": "2024-01-02"},
+					{"version": 1, "jsonSchema": `{"type":"string"}`, "createdAt": "2024-01-01"},
+				},
+			})
+		case r.Method == "GET" && r.URL.Path == "/api/schemas/sch_1/versions/1":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"version": map[string]interface{}{"version": 1, "jsonSchema": `{"type":"string"}`, "createdAt": "2024-01-01"},
+			})
+		case r.Method == "POST" && r.URL.Path == "/api/schemas/sch_1/rollback":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["version"] != float64(1) {
+				t.Errorf("expected version 1, got %v", body["version"])
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"schema": map[string]interface{}{"id": "sch_1", "name": "Order", "version": 3, "jsonSchema": `{"type":"string"}`},
+			})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := New("test_key", WithBaseURL(server.URL))
+	versions, err := client.Schemas.ListVersions(ctx, "sch_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(versions) != 2 || versions[0].Version != 2 {
+		t.Errorf("unexpected versions: %+v", versions)
+	}
+	v1, err := client.Schemas.GetVersion(ctx, "sch_1", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v1.JSONSchema.Value["type"] != "string" {
+		t.Errorf("unexpected version: %+v", v1)
+	}
+	schema, err := client.Schemas.Rollback(ctx, "sch_1", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if schema.Version != 3 {
+		t.Errorf("expected version 3, got %d", schema.Version)
+	}
+}
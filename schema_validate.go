@@ -0,0 +1,501 @@
+package hookbase
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ValidateAgainstSchema validates a payload against a JSON Schema document locally,
+// without calling the API. It supports the commonly used draft-07 keywords: type,
+// enum, const, properties, required, additionalProperties, patternProperties,
+// items, min/max constraints, pattern, format, allOf/anyOf/oneOf/not, and local
+// $ref pointers. Errors are reported with a JSON pointer to the failing value.
+//
+// The payload may be raw JSON ([]byte or json.RawMessage) or any value that
+// encodes to JSON.
+func ValidateAgainstSchema(schemaJSON []byte, payload interface{}) (*SchemaValidationResult, error) {
+	var schema interface{}
+	if err := decodeJSONNumber(schemaJSON, &schema); err != nil {
+		return nil, &Error{Message: fmt.Sprintf("hookbase: invalid JSON schema: %v", err)}
+	}
+
+	var raw []byte
+	switch p := payload.(type) {
+	case []byte:
+		raw = p
+	case json.RawMessage:
+		raw = p
+	default:
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return nil, &Error{Message: fmt.Sprintf("hookbase: failed to marshal payload: %v", err)}
+		}
+		raw = b
+	}
+	var doc interface{}
+	if err := decodeJSONNumber(raw, &doc); err != nil {
+		return nil, &Error{Message: fmt.Sprintf("hookbase: invalid JSON payload: %v", err)}
+	}
+
+	v := &schemaValidator{root: schema}
+	v.validate(schema, doc, "")
+	return &SchemaValidationResult{Valid: len(v.errors) == 0, Errors: v.errors}, nil
+}
+
+// ValidateLocal validates a payload against this schema's JSONSchema without calling the API.
+func (s *Schema) ValidateLocal(payload interface{}) (*SchemaValidationResult, error) {
+	raw, err := json.Marshal(s.JSONSchema.Value)
+	if err != nil {
+		return nil, &Error{Message: fmt.Sprintf("hookbase: failed to marshal schema: %v", err)}
+	}
+	return ValidateAgainstSchema(raw, payload)
+}
+
+func decodeJSONNumber(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+type schemaValidator struct {
+	root   interface{}
+	errors []string
+}
+
+func (v *schemaValidator) fail(path, format string, args ...interface{}) {
+	if path == "" {
+		path = "/"
+	}
+	v.errors = append(v.errors, path+": "+fmt.Sprintf(format, args...))
+}
+
+// check runs a sub-validation and reports whether it produced no errors, without
+// recording any of them.
+func (v *schemaValidator) check(schema, value interface{}, path string) bool {
+	sub := &schemaValidator{root: v.root}
+	sub.validate(schema, value, path)
+	return len(sub.errors) == 0
+}
+
+func (v *schemaValidator) validate(schema, value interface{}, path string) {
+	switch s := schema.(type) {
+	case bool:
+		if !s {
+			v.fail(path, "no value is allowed here")
+		}
+		return
+	case map[string]interface{}:
+		v.validateObject(s, value, path)
+	}
+}
+
+func (v *schemaValidator) validateObject(s map[string]interface{}, value interface{}, path string) {
+	if ref, ok := s["$ref"].(string); ok {
+		target, err := v.resolveRef(ref)
+		if err != nil {
+			v.fail(path, "%v", err)
+			return
+		}
+		// In draft-07, $ref overrides all sibling keywords.
+		v.validate(target, value, path)
+		return
+	}
+
+	if t, ok := s["type"]; ok && !matchesType(t, value) {
+		v.fail(path, "expected %s, got %s", describeType(t), jsonTypeOf(value))
+		return
+	}
+
+	if enum, ok := s["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range enum {
+			if jsonEqual(e, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.fail(path, "value must be one of %s", compactJSON(enum))
+		}
+	}
+	if c, ok := s["const"]; ok && !jsonEqual(c, value) {
+		v.fail(path, "value must be %s", compactJSON(c))
+	}
+
+	switch val := value.(type) {
+	case map[string]interface{}:
+		v.validateProperties(s, val, path)
+	case []interface{}:
+		v.validateItems(s, val, path)
+	case string:
+		v.validateString(s, val, path)
+	case json.Number:
+		v.validateNumber(s, val, path)
+	}
+
+	if all, ok := s["allOf"].([]interface{}); ok {
+		for _, sub := range all {
+			v.validate(sub, value, path)
+		}
+	}
+	if anyOf, ok := s["anyOf"].([]interface{}); ok {
+		matched := false
+		for _, sub := range anyOf {
+			if v.check(sub, value, path) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			v.fail(path, "value does not match any of the allowed schemas")
+		}
+	}
+	if one, ok := s["oneOf"].([]interface{}); ok {
+		matches := 0
+		for _, sub := range one {
+			if v.check(sub, value, path) {
+				matches++
+			}
+		}
+		if matches != 1 {
+			v.fail(path, "value must match exactly one schema, matched %d", matches)
+		}
+	}
+	if not, ok := s["not"]; ok && v.check(not, value, path) {
+		v.fail(path, "value must not match the disallowed schema")
+	}
+}
+
+func (v *schemaValidator) validateProperties(s map[string]interface{}, obj map[string]interface{}, path string) {
+	if required, ok := s["required"].([]interface{}); ok {
+		for _, r := range required {
+			name, _ := r.(string)
+			if _, present := obj[name]; !present {
+				v.fail(path, "missing required property %q", name)
+			}
+		}
+	}
+	if n, ok := schemaInt(s["minProperties"]); ok && len(obj) < n {
+		v.fail(path, "must have at least %d properties", n)
+	}
+	if n, ok := schemaInt(s["maxProperties"]); ok && len(obj) > n {
+		v.fail(path, "must have at most %d properties", n)
+	}
+
+	props, _ := s["properties"].(map[string]interface{})
+	patterns, _ := s["patternProperties"].(map[string]interface{})
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		childPath := path + "/" + escapePointer(k)
+		matched := false
+		if ps, ok := props[k]; ok {
+			v.validate(ps, obj[k], childPath)
+			matched = true
+		}
+		for pattern, ps := range patterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				continue
+			}
+			if re.MatchString(k) {
+				v.validate(ps, obj[k], childPath)
+				matched = true
+			}
+		}
+		if matched {
+			continue
+		}
+		switch ap := s["additionalProperties"].(type) {
+		case bool:
+			if !ap {
+				v.fail(childPath, "additional property %q is not allowed", k)
+			}
+		case map[string]interface{}:
+			v.validate(ap, obj[k], childPath)
+		}
+	}
+}
+
+func (v *schemaValidator) validateItems(s map[string]interface{}, arr []interface{}, path string) {
+	if n, ok := schemaInt(s["minItems"]); ok && len(arr) < n {
+		v.fail(path, "must have at least %d items", n)
+	}
+	if n, ok := schemaInt(s["maxItems"]); ok && len(arr) > n {
+		v.fail(path, "must have at most %d items", n)
+	}
+	if unique, _ := s["uniqueItems"].(bool); unique {
+		for i := 0; i < len(arr); i++ {
+			for j := i + 1; j < len(arr); j++ {
+				if jsonEqual(arr[i], arr[j]) {
+					v.fail(path, "items at index %d and %d are not unique", i, j)
+				}
+			}
+		}
+	}
+	switch items := s["items"].(type) {
+	case map[string]interface{}, bool:
+		for i, item := range arr {
+			v.validate(items, item, path+"/"+strconv.Itoa(i))
+		}
+	case []interface{}:
+		for i, item := range arr {
+			if i < len(items) {
+				v.validate(items[i], item, path+"/"+strconv.Itoa(i))
+			} else if extra, ok := s["additionalItems"]; ok {
+				v.validate(extra, item, path+"/"+strconv.Itoa(i))
+			}
+		}
+	}
+	if contains, ok := s["contains"]; ok {
+		found := false
+		for i, item := range arr {
+			if v.check(contains, item, path+"/"+strconv.Itoa(i)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.fail(path, "must contain at least one matching item")
+		}
+	}
+}
+
+func (v *schemaValidator) validateString(s map[string]interface{}, str, path string) {
+	length := utf8.RuneCountInString(str)
+	if n, ok := schemaInt(s["minLength"]); ok && length < n {
+		v.fail(path, "must be at least %d characters", n)
+	}
+	if n, ok := schemaInt(s["maxLength"]); ok && length > n {
+		v.fail(path, "must be at most %d characters", n)
+	}
+	if pattern, ok := s["pattern"].(string); ok {
+		if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(str) {
+			v.fail(path, "does not match pattern %q", pattern)
+		}
+	}
+	if format, ok := s["format"].(string); ok && !matchesFormat(format, str) {
+		v.fail(path, "is not a valid %s", format)
+	}
+}
+
+func (v *schemaValidator) validateNumber(s map[string]interface{}, num json.Number, path string) {
+	f, err := num.Float64()
+	if err != nil {
+		return
+	}
+	if m, ok := schemaFloat(s["minimum"]); ok && f < m {
+		v.fail(path, "must be >= %v", m)
+	}
+	if m, ok := schemaFloat(s["maximum"]); ok && f > m {
+		v.fail(path, "must be <= %v", m)
+	}
+	if m, ok := schemaFloat(s["exclusiveMinimum"]); ok && f <= m {
+		v.fail(path, "must be > %v", m)
+	}
+	if m, ok := schemaFloat(s["exclusiveMaximum"]); ok && f >= m {
+		v.fail(path, "must be < %v", m)
+	}
+	if m, ok := schemaFloat(s["multipleOf"]); ok && m > 0 {
+		q := f / m
+		if math.Abs(q-math.Round(q)) > 1e-9 {
+			v.fail(path, "must be a multiple of %v", m)
+		}
+	}
+}
+
+func (v *schemaValidator) resolveRef(ref string) (interface{}, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("unsupported remote $ref %q", ref)
+	}
+	node := v.root
+	pointer := strings.TrimPrefix(ref, "#")
+	if pointer == "" {
+		return node, nil
+	}
+	for _, part := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+		if unescaped, err := url.PathUnescape(part); err == nil {
+			part = unescaped
+		}
+		obj, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("cannot resolve $ref %q", ref)
+		}
+		if node, ok = obj[part]; !ok {
+			return nil, fmt.Errorf("cannot resolve $ref %q", ref)
+		}
+	}
+	return node, nil
+}
+
+func matchesType(t interface{}, value interface{}) bool {
+	switch tt := t.(type) {
+	case string:
+		return matchesSingleType(tt, value)
+	case []interface{}:
+		for _, x := range tt {
+			if name, ok := x.(string); ok && matchesSingleType(name, value) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func matchesSingleType(name string, value interface{}) bool {
+	switch name {
+	case "integer":
+		n, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		if _, err := n.Int64(); err == nil {
+			return true
+		}
+		f, err := n.Float64()
+		return err == nil && f == math.Trunc(f)
+	case "number":
+		_, ok := value.(json.Number)
+		return ok
+	default:
+		return jsonTypeOf(value) == name
+	}
+}
+
+func describeType(t interface{}) string {
+	if list, ok := t.([]interface{}); ok {
+		names := make([]string, 0, len(list))
+		for _, x := range list {
+			names = append(names, fmt.Sprint(x))
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+func jsonTypeOf(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+var (
+	formatDate     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	formatUUID     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	formatHostname = regexp.MustCompile(`^(?i)[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?(\.[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)*$`)
+)
+
+// matchesFormat checks the well-known string formats. Unknown formats are
+// treated as annotations and always pass, as the specification allows.
+func matchesFormat(format, s string) bool {
+	switch format {
+	case "date-time":
+		_, err := time.Parse(time.RFC3339Nano, s)
+		return err == nil
+	case "date":
+		if !formatDate.MatchString(s) {
+			return false
+		}
+		_, err := time.Parse("2006-01-02", s)
+		return err == nil
+	case "time":
+		_, err := time.Parse("15:04:05Z07:00", s)
+		if err != nil {
+			_, err = time.Parse("15:04:05.999999999Z07:00", s)
+		}
+		return err == nil
+	case "email":
+		addr, err := mail.ParseAddress(s)
+		return err == nil && addr.Address == s
+	case "hostname":
+		return len(s) <= 253 && formatHostname.MatchString(s)
+	case "ipv4":
+		ip := net.ParseIP(s)
+		return ip != nil && ip.To4() != nil && !strings.Contains(s, ":")
+	case "ipv6":
+		ip := net.ParseIP(s)
+		return ip != nil && strings.Contains(s, ":")
+	case "uri":
+		u, err := url.Parse(s)
+		return err == nil && u.Scheme != ""
+	case "uri-reference":
+		_, err := url.Parse(s)
+		return err == nil
+	case "uuid":
+		return formatUUID.MatchString(s)
+	case "regex":
+		_, err := regexp.Compile(s)
+		return err == nil
+	}
+	return true
+}
+
+func schemaInt(v interface{}) (int, bool) {
+	f, ok := schemaFloat(v)
+	return int(f), ok
+}
+
+func schemaFloat(v interface{}) (float64, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	f, err := n.Float64()
+	return f, err == nil
+}
+
+func jsonEqual(a, b interface{}) bool {
+	if na, ok := a.(json.Number); ok {
+		nb, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		fa, err1 := na.Float64()
+		fb, err2 := nb.Float64()
+		return err1 == nil && err2 == nil && fa == fb
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func compactJSON(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+func escapePointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
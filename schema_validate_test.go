@@ -0,0 +1,149 @@
+package hookbase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const orderSchema = `{
+	"$schema": "http://json-schema.org/draft-07/schema#",
+	"type": "object",
+	"required": ["id", "status", "customer"],
+	"properties": {
+		"id": {"type": "integer", "minimum": 1},
+		"status": {"type": "string", "enum": ["pending", "paid", "shipped"]},
+		"customer": {"$ref": "#/definitions/customer"},
+		"items": {"type": "array", "minItems": 1, "items": {"type": "string"}}
+	},
+	"additionalProperties": false,
+	"definitions": {
+		"customer": {
+			"type": "object",
+			"required": ["email"],
+			"properties": {
+				"email": {"type": "string", "format": "email"},
+				"createdAt": {"type": "string", "format": "date-time"}
+			}
+		}
+	}
+}`
+
+func TestValidateAgainstSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		wantErr []string
+	}{
+		{
+			name:    "valid",
+			payload: `{"id": 1, "status": "paid", "customer": {"email": "a@example.com", "createdAt": "2024-01-01T00:00:00Z"}, "items": ["sku_1"]}`,
+		},
+		{
+			name:    "required",
+			payload: `{"id": 1, "customer": {}}`,
+			wantErr: []string{`/: missing required property "status"`, `/customer: missing required property "email"`},
+		},
+		{
+			name:    "type",
+			payload: `{"id": 1.5, "status": "paid", "customer": {"email": "a@example.com"}, "items": [1]}`,
+			wantErr: []string{"/id: expected integer, got number", "/items/0: expected string, got number"},
+		},
+		{
+			name:    "enum",
+			payload: `{"id": 1, "status": "lost", "customer": {"email": "a@example.com"}}`,
+			wantErr: []string{`/status: value must be one of ["pending","paid","shipped"]`},
+		},
+		{
+			name:    "format",
+			payload: `{"id": 1, "status": "paid", "customer": {"email": "not-an-email", "createdAt": "yesterday"}}`,
+			wantErr: []string{"/customer/createdAt: is not a valid date-time", "/customer/email: is not a valid email"},
+		},
+		{
+			name:    "additional properties and bounds",
+			payload: `{"id": 0, "status": "paid", "customer": {"email": "a@example.com"}, "items": [], "extra": true}`,
+			wantErr: []string{`/extra: additional property "extra" is not allowed`, "/id: must be >= 1", "/items: must have at least 1 items"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ValidateAgainstSchema([]byte(orderSchema), []byte(tt.payload))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Valid != (len(tt.wantErr) == 0) {
+				t.Errorf("expected valid=%v, got errors %v", len(tt.wantErr) == 0, result.Errors)
+			}
+			if strings.Join(result.Errors, "\n") != strings.Join(tt.wantErr, "\n") {
+				t.Errorf("got errors:\n%s\nwant:\n%s", strings.Join(result.Errors, "\n"), strings.Join(tt.wantErr, "\n"))
+			}
+		})
+	}
+}
+
+func TestSchemaValidateLocal(t *testing.T) {
+	s := &Schema{JSONSchema: JSONString[map[string]interface{}]{Value: map[string]interface{}{"type": "object", "required": []interface{}{"id"}}}}
+	result, err := s.ValidateLocal(map[string]interface{}{"name": "x"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Valid || len(result.Errors) != 1 {
+		t.Errorf("expected one error, got %v", result.Errors)
+	}
+
+	if _, err := ValidateAgainstSchema([]byte(`{not json`), nil); err == nil {
+		t.Error("expected error for malformed schema")
+	}
+}
+
+func TestSchemasCreateRawJSONRoundTrip(t *testing.T) {
+	var sent map[string]json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		// The API stores the schema as a string column and returns it double-encoded.
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"schema": map[string]interface{}{"id": "sch_1", "name": "Order", "version": 1, "jsonSchema": string(sent["jsonSchema"])},
+		})
+	}))
+	defer server.Close()
+
+	client := New("test_key", WithBaseURL(server.URL))
+	fileBytes := []byte(orderSchema)
+	schema, err := client.Schemas.Create(context.Background(), &CreateSchemaParams{
+		Name:          "Order",
+		JSONSchema:    map[string]interface{}{"type": "string"},
+		RawJSONSchema: fileBytes,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var compact bytes.Buffer
+	json.Compact(&compact, fileBytes)
+	if !bytes.Equal(sent["jsonSchema"], compact.Bytes()) {
+		t.Errorf("expected raw schema to be sent verbatim, got %s", sent["jsonSchema"])
+	}
+	if schema.JSONSchema.Value["type"] != "object" {
+		t.Fatalf("expected parsed schema, got %v", schema.JSONSchema.Value)
+	}
+	result, err := schema.ValidateLocal([]byte(`{"id": 1}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Valid {
+		t.Error("expected payload missing required fields to be invalid")
+	}
+
+	for _, bad := range []string{`{"type": "object"`, `[1, 2]`, `{"name": "not a schema"}`} {
+		_, err := client.Schemas.Create(context.Background(), &CreateSchemaParams{Name: "Bad", RawJSONSchema: json.RawMessage(bad)})
+		var verr *ValidationError
+		if !errors.As(err, &verr) || len(verr.ValidationErrors["jsonSchema"]) == 0 {
+			t.Errorf("expected ValidationError for %s, got %v", bad, err)
+		}
+	}
+}
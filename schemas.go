@@ -2,18 +2,19 @@ package hookbase
 
 import (
 	"context"
+	"encoding/json"
 	"net/url"
 )
 
 // Schema represents a webhook payload validation schema.
 type Schema struct {
-	ID             string `json:"id"`
-	OrganizationID string `json:"organizationId"`
-	Name           string `json:"name"`
-	Slug           string `json:"slug"`
-	Description    *string `json:"description"`
-	JSONSchema     string  `json:"jsonSchema"`
-	Version        int     `json:"version"`
+	ID             string                             `json:"id"`
+	OrganizationID string                             `json:"organizationId"`
+	Name           string                             `json:"name"`
+	Slug           string                             `json:"slug"`
+	Description    *string                            `json:"description"`
+	JSONSchema     JSONString[map[string]interface{}] `json:"jsonSchema"`
+	Version        int                                `json:"version"`
 	Routes         []struct {
 		ID   string `json:"id"`
 		Name string `json:"name"`
@@ -23,18 +24,57 @@ type Schema struct {
 }
 
 // CreateSchemaParams are the parameters for creating a schema.
+// Set either JSONSchema or RawJSONSchema; if both are set, RawJSONSchema takes precedence.
 type CreateSchemaParams struct {
-	Name        string                 `json:"name"`
-	Slug        *string                `json:"slug,omitempty"`
-	Description *string                `json:"description,omitempty"`
-	JSONSchema  map[string]interface{} `json:"jsonSchema"`
+	Name          string                 `json:"name"`
+	Slug          *string                `json:"slug,omitempty"`
+	Description   *string                `json:"description,omitempty"`
+	JSONSchema    map[string]interface{} `json:"jsonSchema"`
+	RawJSONSchema json.RawMessage        `json:"-"` // e.g. the contents of a .json file
 }
 
 // UpdateSchemaParams are the parameters for updating a schema.
+// Set either JSONSchema or RawJSONSchema; if both are set, RawJSONSchema takes precedence.
 type UpdateSchemaParams struct {
-	Name        *string                `json:"name,omitempty"`
-	Description *string                `json:"description,omitempty"`
-	JSONSchema  map[string]interface{} `json:"jsonSchema,omitempty"`
+	Name          *string                `json:"name,omitempty"`
+	Description   *string                `json:"description,omitempty"`
+	JSONSchema    map[string]interface{} `json:"jsonSchema,omitempty"`
+	RawJSONSchema json.RawMessage        `json:"-"`
+}
+
+// schemaDocument returns the schema document to send, preferring raw over map, and
+// checks that it is a plausible JSON Schema. It returns nil if neither is set.
+func schemaDocument(raw json.RawMessage, doc map[string]interface{}) (json.RawMessage, error) {
+	if len(raw) == 0 {
+		if doc == nil {
+			return nil, nil
+		}
+		b, err := json.Marshal(doc)
+		if err != nil {
+			return nil, &Error{Message: "hookbase: failed to marshal JSON schema: " + err.Error()}
+		}
+		raw = b
+	}
+	if err := ValidateSchemaDocument(raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// ValidateSchemaDocument checks that raw is syntactically valid JSON and structurally
+// looks like a JSON Schema: an object with at least one of $schema, type, properties,
+// $ref, enum, const, allOf, anyOf, or oneOf. It returns a *ValidationError otherwise.
+func ValidateSchemaDocument(raw []byte) error {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return newClientValidationError("jsonSchema", "must be a JSON object: "+err.Error())
+	}
+	for _, key := range []string{"$schema", "type", "properties", "$ref", "enum", "const", "allOf", "anyOf", "oneOf"} {
+		if _, ok := doc[key]; ok {
+			return nil
+		}
+	}
+	return newClientValidationError("jsonSchema", "does not look like a JSON Schema (expected $schema, type, or properties)")
 }
 
 // ListSchemasParams are the parameters for listing schemas.
@@ -63,6 +103,14 @@ type SchemaValidationResult struct {
 	Errors []string `json:"errors"`
 }
 
+// SchemaVersion is a historical version of a schema.
+type SchemaVersion struct {
+	Version    int                                `json:"version"`
+	JSONSchema JSONString[map[string]interface{}] `json:"jsonSchema"`
+	CreatedBy  *string                            `json:"createdBy"`
+	CreatedAt  string                             `json:"createdAt"`
+}
+
 // SchemasResource provides access to schema-related API endpoints.
 type SchemasResource struct {
 	t *transport
@@ -103,10 +151,27 @@ func (r *SchemasResource) Get(ctx context.Context, id string, opts ...RequestOpt
 
 // Create creates a new schema.
 func (r *SchemasResource) Create(ctx context.Context, params *CreateSchemaParams, opts ...RequestOption) (*Schema, error) {
+	doc, err := schemaDocument(params.RawJSONSchema, params.JSONSchema)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, newClientValidationError("jsonSchema", "is required")
+	}
+	body := map[string]interface{}{
+		"name":       params.Name,
+		"jsonSchema": doc,
+	}
+	if params.Slug != nil {
+		body["slug"] = *params.Slug
+	}
+	if params.Description != nil {
+		body["description"] = *params.Description
+	}
 	var resp struct {
 		Schema Schema `json:"schema"`
 	}
-	if err := r.t.do(ctx, "POST", "/api/schemas", nil, params, &resp, opts...); err != nil {
+	if err := r.t.do(ctx, "POST", "/api/schemas", nil, body, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp.Schema, nil
@@ -114,7 +179,21 @@ func (r *SchemasResource) Create(ctx context.Context, params *CreateSchemaParams
 
 // Update updates a schema.
 func (r *SchemasResource) Update(ctx context.Context, id string, params *UpdateSchemaParams, opts ...RequestOption) error {
-	return r.t.do(ctx, "PUT", "/api/schemas/"+url.PathEscape(id), nil, params, nil, opts...)
+	doc, err := schemaDocument(params.RawJSONSchema, params.JSONSchema)
+	if err != nil {
+		return err
+	}
+	body := map[string]interface{}{}
+	if params.Name != nil {
+		body["name"] = *params.Name
+	}
+	if params.Description != nil {
+		body["description"] = *params.Description
+	}
+	if doc != nil {
+		body["jsonSchema"] = doc
+	}
+	return r.t.do(ctx, "PUT", "/api/schemas/"+url.PathEscape(id), nil, body, nil, opts...)
 }
 
 // Delete deletes a schema.
@@ -122,6 +201,40 @@ func (r *SchemasResource) Delete(ctx context.Context, id string, opts ...Request
 	return r.t.do(ctx, "DELETE", "/api/schemas/"+url.PathEscape(id), nil, nil, nil, opts...)
 }
 
+// ListVersions returns all versions of a schema, newest first.
+func (r *SchemasResource) ListVersions(ctx context.Context, id string, opts ...RequestOption) ([]SchemaVersion, error) {
+	var resp struct {
+		Versions []SchemaVersion `json:"versions"`
+	}
+	if err := r.t.do(ctx, "GET", "/api/schemas/"+url.PathEscape(id)+"/versions", nil, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return resp.Versions, nil
+}
+
+// GetVersion returns a specific version of a schema.
+func (r *SchemasResource) GetVersion(ctx context.Context, id string, version int, opts ...RequestOption) (*SchemaVersion, error) {
+	var resp struct {
+		Version SchemaVersion `json:"version"`
+	}
+	if err := r.t.do(ctx, "GET", "/api/schemas/"+url.PathEscape(id)+"/versions/"+itoa(version), nil, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Version, nil
+}
+
+// Rollback restores a schema to a previous version. The rollback is recorded as a new version.
+func (r *SchemasResource) Rollback(ctx context.Context, id string, version int, opts ...RequestOption) (*Schema, error) {
+	var resp struct {
+		Schema Schema `json:"schema"`
+	}
+	body := map[string]interface{}{"version": version}
+	if err := r.t.do(ctx, "POST", "/api/schemas/"+url.PathEscape(id)+"/rollback", nil, body, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Schema, nil
+}
+
 // Validate validates a payload against a schema.
 func (r *SchemasResource) Validate(ctx context.Context, id string, payload interface{}, opts ...RequestOption) (*SchemaValidationResult, error) {
 	var resp SchemaValidationResult
@@ -131,3 +244,40 @@ func (r *SchemasResource) Validate(ctx context.Context, id string, payload inter
 	}
 	return &resp, nil
 }
+
+// SchemasExport is the document returned by Schemas.Export. The schemas it
+// contains can be passed directly to Schemas.Import.
+type SchemasExport struct {
+	Version    string                   `json:"version,omitempty"`
+	ExportedAt string                   `json:"exportedAt,omitempty"`
+	Schemas    []map[string]interface{} `json:"schemas"`
+}
+
+// Export exports schemas as JSON.
+func (r *SchemasResource) Export(ctx context.Context, ids []string, opts ...RequestOption) (*SchemasExport, error) {
+	var q url.Values
+	if len(ids) > 0 {
+		q = url.Values{"ids": {joinIDs(ids)}}
+	}
+	var resp SchemasExport
+	if err := r.t.do(ctx, "GET", "/api/schemas/export", q, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ImportSchemasParams are the parameters for importing schemas.
+type ImportSchemasParams struct {
+	Schemas          []map[string]interface{} `json:"schemas"`
+	ConflictStrategy *string                  `json:"conflictStrategy,omitempty"`
+	ValidateOnly     *bool                    `json:"validateOnly,omitempty"`
+}
+
+// Import imports schemas from JSON.
+func (r *SchemasResource) Import(ctx context.Context, params *ImportSchemasParams, opts ...RequestOption) (*ImportResult, error) {
+	var resp ImportResult
+	if err := r.t.do(ctx, "POST", "/api/schemas/import", nil, params, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
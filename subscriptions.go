@@ -2,6 +2,7 @@ package hookbase
 
 import (
 	"context"
+	"fmt"
 	"net/url"
 )
 
@@ -66,6 +67,43 @@ type BulkSubscribeResult struct {
 	Subscriptions []Subscription `json:"subscriptions"`
 }
 
+// Outcomes reported in BulkUnsubscribeItem.Status.
+const (
+	UnsubscribeDeleted  = "deleted"
+	UnsubscribeNotFound = "not_found"
+)
+
+// BulkUnsubscribeResult is the result of unsubscribing an endpoint from multiple
+// event types.
+type BulkUnsubscribeResult struct {
+	Deleted  int                   `json:"deleted"`
+	NotFound int                   `json:"notFound"`
+	Results  []BulkUnsubscribeItem `json:"results"`
+}
+
+// BulkUnsubscribeItem is the outcome for one event type in a bulk unsubscribe.
+type BulkUnsubscribeItem struct {
+	EventTypeID string `json:"eventTypeId"`
+	Status      string `json:"status"`
+}
+
+// SubscriptionSyncOptions configures Subscriptions.Sync.
+type SubscriptionSyncOptions struct {
+	// DryRun computes the result without creating, enabling, or deleting anything.
+	DryRun bool
+}
+
+// SubscriptionSyncResult reports what Subscriptions.Sync changed or would change.
+type SubscriptionSyncResult struct {
+	Created   int
+	Deleted   int
+	Enabled   int
+	Unchanged int
+	// Subscriptions is the endpoint's subscription list after the sync. In a dry run it
+	// holds only the existing subscriptions that would be kept.
+	Subscriptions []Subscription
+}
+
 // SubscriptionsResource provides access to subscription-related API endpoints.
 type SubscriptionsResource struct {
 	t *transport
@@ -98,6 +136,28 @@ func (r *SubscriptionsResource) List(ctx context.Context, applicationID string,
 	}, nil
 }
 
+// GetByEventType returns every subscription in an application for the given event
+// type, following pagination until all pages are read.
+func (r *SubscriptionsResource) GetByEventType(ctx context.Context, applicationID string, eventTypeID string, opts ...RequestOption) ([]Subscription, error) {
+	params := &ListSubscriptionsParams{
+		Limit:       Ptr(100),
+		Offset:      Ptr(0),
+		EventTypeID: Ptr(eventTypeID),
+	}
+	subs := []Subscription{}
+	for {
+		page, err := r.List(ctx, applicationID, params, opts...)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, page.Data...)
+		if !page.HasMore || len(page.Data) == 0 {
+			return subs, nil
+		}
+		*params.Offset += len(page.Data)
+	}
+}
+
 // Get returns a subscription by ID.
 func (r *SubscriptionsResource) Get(ctx context.Context, applicationID, subscriptionID string, opts ...RequestOption) (*Subscription, error) {
 	var resp struct {
@@ -158,3 +218,194 @@ func (r *SubscriptionsResource) BulkSubscribe(ctx context.Context, endpointID st
 	}
 	return &resp, nil
 }
+
+// BulkUnsubscribe unsubscribes an endpoint from multiple event types. Event types the
+// endpoint was not subscribed to are reported with status UnsubscribeNotFound rather
+// than failing the request.
+func (r *SubscriptionsResource) BulkUnsubscribe(ctx context.Context, endpointID string, eventTypeIDs []string, opts ...RequestOption) (*BulkUnsubscribeResult, error) {
+	if len(eventTypeIDs) == 0 {
+		return nil, newClientValidationError("eventTypeIds", "must contain at least one event type ID")
+	}
+	var resp BulkUnsubscribeResult
+	body := map[string]interface{}{
+		"endpointId":   endpointID,
+		"eventTypeIds": eventTypeIDs,
+	}
+	if err := r.t.do(ctx, "POST", "/api/webhook-subscriptions/bulk-unsubscribe", nil, body, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Find returns the subscription linking an endpoint to an event type. If there is
+// none, a *NotFoundError is returned.
+func (r *SubscriptionsResource) Find(ctx context.Context, applicationID, endpointID, eventTypeID string, opts ...RequestOption) (*Subscription, error) {
+	params := &ListSubscriptionsParams{
+		Limit:       Ptr(100),
+		Offset:      Ptr(0),
+		EndpointID:  Ptr(endpointID),
+		EventTypeID: Ptr(eventTypeID),
+	}
+	for {
+		page, err := r.List(ctx, applicationID, params, opts...)
+		if err != nil {
+			return nil, err
+		}
+		for i := range page.Data {
+			if page.Data[i].EndpointID == endpointID && page.Data[i].EventTypeID == eventTypeID {
+				return &page.Data[i], nil
+			}
+		}
+		if !page.HasMore || len(page.Data) == 0 {
+			break
+		}
+		*params.Offset += len(page.Data)
+	}
+	return nil, &NotFoundError{APIError: APIError{
+		Message: fmt.Sprintf("no subscription for endpoint %q and event type %q", endpointID, eventTypeID),
+		Status:  404,
+		Code:    CodeNotFound,
+	}}
+}
+
+// BulkCreate creates subscriptions for arbitrary endpoint/event type pairs in a single
+// request scoped to applicationID, so either every pair is processed or, on error,
+// none is. Pairs that already exist are counted as skipped.
+func (r *SubscriptionsResource) BulkCreate(ctx context.Context, applicationID string, params []CreateSubscriptionParams, opts ...RequestOption) (*BulkSubscribeResult, error) {
+	if applicationID == "" {
+		return nil, newClientValidationError("applicationId", "is required")
+	}
+	if len(params) == 0 {
+		return nil, newClientValidationError("subscriptions", "must contain at least one subscription")
+	}
+	var resp BulkSubscribeResult
+	body := map[string]interface{}{
+		"applicationId": applicationID,
+		"subscriptions": params,
+	}
+	if err := r.t.do(ctx, "POST", "/api/webhook-subscriptions/bulk", nil, body, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// BulkDelete deletes multiple subscriptions of applicationID. The request carries
+// applicationID, and the API deletes only the listed subscriptions that belong to it.
+// If fewer than len(ids) are deleted, the result is returned along with a
+// *ValidationError reporting that the others do not belong to the application; they
+// are left untouched.
+func (r *SubscriptionsResource) BulkDelete(ctx context.Context, applicationID string, ids []string, opts ...RequestOption) (*BulkDeleteResult, error) {
+	if applicationID == "" {
+		return nil, newClientValidationError("applicationId", "is required")
+	}
+	if len(ids) == 0 {
+		return nil, newClientValidationError("ids", "must contain at least one subscription ID")
+	}
+	result, err := r.bulkDelete(ctx, applicationID, ids, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if result.Deleted < len(ids) {
+		return result, newClientValidationError("ids", fmt.Sprintf("%d of %d subscriptions do not belong to application %s and were not deleted", len(ids)-result.Deleted, len(ids), applicationID))
+	}
+	return result, nil
+}
+
+// bulkDelete sends a bulk delete scoped to applicationID.
+func (r *SubscriptionsResource) bulkDelete(ctx context.Context, applicationID string, ids []string, opts ...RequestOption) (*BulkDeleteResult, error) {
+	var resp BulkDeleteResult
+	body := map[string]interface{}{
+		"applicationId": applicationID,
+		"ids":           ids,
+	}
+	if err := r.t.do(ctx, "DELETE", "/api/webhook-subscriptions/bulk", nil, body, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Sync sets an endpoint's subscriptions to exactly eventTypeIDs: missing event types
+// are subscribed in one bulk request, disabled subscriptions in the list are enabled,
+// and subscriptions to other event types are deleted. opts may be nil.
+//
+// If a change fails, Sync stops and returns the counts so far with the error.
+func (r *SubscriptionsResource) Sync(ctx context.Context, applicationID, endpointID string, eventTypeIDs []string, opts *SubscriptionSyncOptions, reqOpts ...RequestOption) (*SubscriptionSyncResult, error) {
+	if opts == nil {
+		opts = &SubscriptionSyncOptions{}
+	}
+	desired := map[string]bool{}
+	for _, id := range eventTypeIDs {
+		desired[id] = true
+	}
+
+	params := &ListSubscriptionsParams{Limit: Ptr(100), Offset: Ptr(0), EndpointID: Ptr(endpointID)}
+	var current []Subscription
+	for {
+		page, err := r.List(ctx, applicationID, params, reqOpts...)
+		if err != nil {
+			return nil, err
+		}
+		current = append(current, page.Data...)
+		if !page.HasMore || len(page.Data) == 0 {
+			break
+		}
+		*params.Offset += len(page.Data)
+	}
+
+	result := &SubscriptionSyncResult{Subscriptions: []Subscription{}}
+	kept := map[string]bool{}
+	var toEnable []Subscription
+	var toDelete []string
+	for _, sub := range current {
+		switch {
+		case !desired[sub.EventTypeID] || kept[sub.EventTypeID]:
+			toDelete = append(toDelete, sub.ID)
+		case !sub.IsEnabled:
+			kept[sub.EventTypeID] = true
+			toEnable = append(toEnable, sub)
+		default:
+			kept[sub.EventTypeID] = true
+			result.Unchanged++
+			result.Subscriptions = append(result.Subscriptions, sub)
+		}
+	}
+	var missing []string
+	for _, id := range dedupeStrings(eventTypeIDs) {
+		if !kept[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	if opts.DryRun {
+		result.Created = len(missing)
+		result.Enabled = len(toEnable)
+		result.Deleted = len(toDelete)
+		result.Subscriptions = append(result.Subscriptions, toEnable...)
+		return result, nil
+	}
+
+	if len(missing) > 0 {
+		res, err := r.BulkSubscribe(ctx, endpointID, missing, reqOpts...)
+		if err != nil {
+			return result, err
+		}
+		result.Created = res.Created
+		result.Subscriptions = append(result.Subscriptions, res.Subscriptions...)
+	}
+	for _, sub := range toEnable {
+		updated, err := r.Enable(ctx, applicationID, sub.ID, reqOpts...)
+		if err != nil {
+			return result, err
+		}
+		result.Enabled++
+		result.Subscriptions = append(result.Subscriptions, *updated)
+	}
+	if len(toDelete) > 0 {
+		res, err := r.bulkDelete(ctx, applicationID, toDelete, reqOpts...)
+		if err != nil {
+			return result, err
+		}
+		result.Deleted = res.Deleted
+	}
+	return result, nil
+}
@@ -0,0 +1,47 @@
+package hookbase
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Tracer traces API calls for WithTracing. The hookbaseotel module implements it with
+// OpenTelemetry; implement it directly to use another tracing system.
+type Tracer interface {
+	// StartCall is called once per API call, before its first attempt. The returned
+	// context is used for the call's requests, and the returned CallSpan receives the
+	// call's outcome.
+	StartCall(ctx context.Context, call TracedCall) (context.Context, CallSpan)
+}
+
+// TracedCall describes an API call passed to Tracer.StartCall.
+type TracedCall struct {
+	Method string
+	Path   string
+	URL    string
+	// Resource is the first segment of Path after /api/, such as "webhook-endpoints"
+	// for /api/webhook-endpoints/ep_123.
+	Resource string
+}
+
+// CallSpan is the span of one traced API call. Retried calls have a single span.
+type CallSpan interface {
+	// InjectHeaders adds the trace context to the headers of each attempt's request.
+	InjectHeaders(header http.Header)
+	// RecordResponse records the status code and request ID of each attempt that got a
+	// response.
+	RecordResponse(status int, requestID string)
+	// End ends the span. err is the error the call returned, or nil.
+	End(err error)
+}
+
+// resourceFromPath returns the resource segment of an API path, such as
+// "webhook-endpoints" for /api/webhook-endpoints/ep_123.
+func resourceFromPath(path string) string {
+	p := strings.TrimPrefix(path, "/api/")
+	if i := strings.IndexByte(p, '/'); i >= 0 {
+		p = p[:i]
+	}
+	return p
+}
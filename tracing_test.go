@@ -0,0 +1,93 @@
+package hookbase
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type fakeTracer struct {
+	calls []TracedCall
+	spans []*fakeSpan
+}
+
+func (tr *fakeTracer) StartCall(ctx context.Context, call TracedCall) (context.Context, CallSpan) {
+	span := &fakeSpan{}
+	tr.calls = append(tr.calls, call)
+	tr.spans = append(tr.spans, span)
+	return ctx, span
+}
+
+type fakeSpan struct {
+	injected  int
+	responses [][2]interface{}
+	ended     int
+	err       error
+}
+
+func (s *fakeSpan) InjectHeaders(header http.Header) {
+	s.injected++
+	header.Set("Traceparent", "00-trace-span-01")
+}
+
+func (s *fakeSpan) RecordResponse(status int, requestID string) {
+	s.responses = append(s.responses, [2]interface{}{status, requestID})
+}
+
+func (s *fakeSpan) End(err error) {
+	s.ended++
+	s.err = err
+}
+
+func TestWithTracing(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Traceparent") != "00-trace-span-01" {
+			t.Errorf("expected injected trace header, got %q", r.Header.Get("Traceparent"))
+		}
+		attempts++
+		w.Header().Set("X-Request-Id", "req_"+itoa(attempts))
+		if r.URL.Path == "/api/webhook-endpoints/ep_missing" {
+			w.WriteHeader(404)
+			w.Write([]byte(`{"error":{"message":"not found"}}`))
+			return
+		}
+		if attempts == 1 {
+			w.WriteHeader(500)
+			w.Write([]byte(`{"error":{"message":"boom"}}`))
+			return
+		}
+		w.Write([]byte(`{"data":{"id":"app_1"}}`))
+	}))
+	defer server.Close()
+
+	tracer := &fakeTracer{}
+	client := New("test_key", WithBaseURL(server.URL), WithTracing(tracer), WithRetryPolicy(FixedInterval(1, time.Millisecond)))
+	if _, err := client.Applications.Get(context.Background(), "app_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Endpoints.Get(context.Background(), "app_1", "ep_missing"); err == nil {
+		t.Fatal("expected error")
+	}
+
+	want := []TracedCall{
+		{Method: "GET", Path: "/api/webhook-applications/app_1", URL: server.URL + "/api/webhook-applications/app_1", Resource: "webhook-applications"},
+		{Method: "GET", Path: "/api/webhook-endpoints/ep_missing", URL: server.URL + "/api/webhook-endpoints/ep_missing", Resource: "webhook-endpoints"},
+	}
+	if !reflect.DeepEqual(tracer.calls, want) {
+		t.Fatalf("expected calls %+v, got %+v", want, tracer.calls)
+	}
+
+	// The retried call has one span covering both attempts.
+	ok := tracer.spans[0]
+	if ok.injected != 2 || !reflect.DeepEqual(ok.responses, [][2]interface{}{{500, "req_1"}, {200, "req_2"}}) || ok.ended != 1 || ok.err != nil {
+		t.Errorf("unexpected span for the retried call: %+v", ok)
+	}
+	failed := tracer.spans[1]
+	if _, notFound := failed.err.(*NotFoundError); !notFound || failed.ended != 1 || !reflect.DeepEqual(failed.responses, [][2]interface{}{{404, "req_3"}}) {
+		t.Errorf("expected span ended with NotFoundError, got %+v", failed)
+	}
+}
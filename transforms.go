@@ -2,6 +2,8 @@ package hookbase
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/url"
 )
 
@@ -91,11 +93,55 @@ type TransformTestParams struct {
 }
 
 // TransformTestResult is the result of testing a transform.
+//
+// Output holds the raw JSON returned by the API. For JSON output it can be
+// decoded with DecodeTransformOutput; for xml, form, and text output it is a
+// JSON string and should be read with OutputText.
 type TransformTestResult struct {
-	Success        bool        `json:"success"`
-	Output         interface{} `json:"output,omitempty"`
-	Error          *string     `json:"error,omitempty"`
-	ExecutionTimeMs *int       `json:"executionTimeMs,omitempty"`
+	Success         bool            `json:"success"`
+	Output          json.RawMessage `json:"output,omitempty"`
+	OutputFormat    ContentFormat   `json:"outputFormat,omitempty"`
+	Error           *string         `json:"error,omitempty"`
+	ExecutionTimeMs *int            `json:"executionTimeMs,omitempty"`
+}
+
+// IsJSON reports whether the output is a JSON document.
+func (r *TransformTestResult) IsJSON() bool {
+	return r.OutputFormat == "" || r.OutputFormat == ContentJSON
+}
+
+// OutputText returns the output of a transform that produces xml, form, or text content.
+func (r *TransformTestResult) OutputText() (string, error) {
+	if len(r.Output) == 0 {
+		return "", nil
+	}
+	var s string
+	if err := json.Unmarshal(r.Output, &s); err != nil {
+		if r.IsJSON() {
+			return string(r.Output), nil
+		}
+		return "", &Error{Message: fmt.Sprintf("hookbase: %s transform output is not a string: %v", r.OutputFormat, err)}
+	}
+	return s, nil
+}
+
+// DecodeTransformOutput decodes the output of a JSON transform test into T.
+// It returns an error if the transform produced xml, form, or text output.
+func DecodeTransformOutput[T any](r *TransformTestResult) (T, error) {
+	var out T
+	if r == nil {
+		return out, &Error{Message: "hookbase: transform test result is nil"}
+	}
+	if !r.IsJSON() {
+		return out, &Error{Message: fmt.Sprintf("hookbase: cannot decode %s transform output as JSON; use OutputText", r.OutputFormat)}
+	}
+	if len(r.Output) == 0 {
+		return out, &Error{Message: "hookbase: transform test result has no output"}
+	}
+	if err := json.Unmarshal(r.Output, &out); err != nil {
+		return out, &Error{Message: fmt.Sprintf("hookbase: failed to decode transform output: %v", err)}
+	}
+	return out, nil
 }
 
 // TransformsResource provides access to transform-related API endpoints.
@@ -162,11 +208,72 @@ func (r *TransformsResource) Delete(ctx context.Context, id string, opts ...Requ
 	return r.t.do(ctx, "DELETE", "/api/transforms/"+url.PathEscape(id), nil, nil, nil, opts...)
 }
 
+// ListUsage returns the routes that reference a transform.
+func (r *TransformsResource) ListUsage(ctx context.Context, id string, opts ...RequestOption) ([]RouteRef, error) {
+	routes := &RoutesResource{t: r.t}
+	return routes.findReferences(ctx, func(route *Route) bool {
+		return route.TransformID != nil && *route.TransformID == id
+	}, opts...)
+}
+
+// DeleteIfUnused deletes a transform only if no routes reference it. If the transform
+// is still in use, an *InUseError listing the dependent routes is returned.
+func (r *TransformsResource) DeleteIfUnused(ctx context.Context, id string, opts ...RequestOption) error {
+	refs, err := r.ListUsage(ctx, id, opts...)
+	if err != nil {
+		return err
+	}
+	if len(refs) > 0 {
+		return &InUseError{Resource: "transform", ID: id, Routes: refs}
+	}
+	return r.Delete(ctx, id, opts...)
+}
+
 // Test tests a transform against a sample payload.
 func (r *TransformsResource) Test(ctx context.Context, params *TransformTestParams, opts ...RequestOption) (*TransformTestResult, error) {
 	var resp TransformTestResult
 	if err := r.t.do(ctx, "POST", "/api/transforms/test", nil, params, &resp, opts...); err != nil {
 		return nil, err
 	}
+	if resp.OutputFormat == "" && params != nil && params.OutputFormat != nil {
+		resp.OutputFormat = *params.OutputFormat
+	}
+	return &resp, nil
+}
+
+// TransformsExport is the document returned by Transforms.Export. The transforms it
+// contains can be passed directly to Transforms.Import.
+type TransformsExport struct {
+	Version    string                   `json:"version,omitempty"`
+	ExportedAt string                   `json:"exportedAt,omitempty"`
+	Transforms []map[string]interface{} `json:"transforms"`
+}
+
+// Export exports transforms as JSON.
+func (r *TransformsResource) Export(ctx context.Context, ids []string, opts ...RequestOption) (*TransformsExport, error) {
+	var q url.Values
+	if len(ids) > 0 {
+		q = url.Values{"ids": {joinIDs(ids)}}
+	}
+	var resp TransformsExport
+	if err := r.t.do(ctx, "GET", "/api/transforms/export", q, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ImportTransformsParams are the parameters for importing transforms.
+type ImportTransformsParams struct {
+	Transforms       []map[string]interface{} `json:"transforms"`
+	ConflictStrategy *string                  `json:"conflictStrategy,omitempty"`
+	ValidateOnly     *bool                    `json:"validateOnly,omitempty"`
+}
+
+// Import imports transforms from JSON.
+func (r *TransformsResource) Import(ctx context.Context, params *ImportTransformsParams, opts ...RequestOption) (*ImportResult, error) {
+	var resp ImportResult
+	if err := r.t.do(ctx, "POST", "/api/transforms/import", nil, params, &resp, opts...); err != nil {
+		return nil, err
+	}
 	return &resp, nil
 }
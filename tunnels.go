@@ -27,6 +27,72 @@ type CreateTunnelParams struct {
 	Subdomain *string `json:"subdomain,omitempty"`
 }
 
+// UpdateTunnelParams are the parameters for updating a tunnel.
+type UpdateTunnelParams struct {
+	Name      *string `json:"name,omitempty"`
+	LocalPort *int    `json:"localPort,omitempty"`
+	Subdomain *string `json:"subdomain,omitempty"`
+}
+
+// TunnelStatus describes a tunnel's live connection.
+type TunnelStatus struct {
+	Connected        bool    `json:"connected"`
+	ConnectedAt      *string `json:"connectedAt"`
+	RequestsProxied  int     `json:"requestsProxied"`
+	BytesTransferred int64   `json:"bytesTransferred"`
+	LastActivityAt   *string `json:"lastActivityAt"`
+	Error            *string `json:"error"`
+}
+
+// TunnelRequest is a request captured as it passed through a tunnel.
+type TunnelRequest struct {
+	ID             string            `json:"id"`
+	TunnelID       string            `json:"tunnelId"`
+	Method         string            `json:"method"`
+	Path           string            `json:"path"`
+	Headers        map[string]string `json:"headers"`
+	BodyExcerpt    *string           `json:"bodyExcerpt"`
+	ResponseStatus *int              `json:"responseStatus"`
+	DurationMs     *int              `json:"durationMs"`
+	ReceivedAt     string            `json:"receivedAt"`
+}
+
+// TunnelRequestDetail is a captured tunnel request with full request and response bodies.
+type TunnelRequestDetail struct {
+	TunnelRequest
+	Body            *string           `json:"body"`
+	ResponseHeaders map[string]string `json:"responseHeaders"`
+	ResponseBody    *string           `json:"responseBody"`
+}
+
+// ListTunnelRequestsParams are the parameters for listing captured tunnel requests.
+type ListTunnelRequestsParams struct {
+	Limit  *int    `json:"limit,omitempty"`
+	Cursor *string `json:"cursor,omitempty"`
+	Method *string `json:"method,omitempty"`
+	Status *int    `json:"status,omitempty"`
+}
+
+func (p *ListTunnelRequestsParams) toQuery() url.Values {
+	if p == nil {
+		return nil
+	}
+	q := url.Values{}
+	if p.Limit != nil {
+		q.Set("limit", itoa(*p.Limit))
+	}
+	if p.Cursor != nil {
+		q.Set("cursor", *p.Cursor)
+	}
+	if p.Method != nil {
+		q.Set("method", *p.Method)
+	}
+	if p.Status != nil {
+		q.Set("status", itoa(*p.Status))
+	}
+	return q
+}
+
 // TunnelsResource provides access to tunnel-related API endpoints.
 type TunnelsResource struct {
 	t *transport
@@ -54,6 +120,18 @@ func (r *TunnelsResource) Get(ctx context.Context, id string, opts ...RequestOpt
 	return &resp.Tunnel, nil
 }
 
+// GetStatus returns connection details for a tunnel, such as when it connected and
+// how much traffic it has proxied.
+func (r *TunnelsResource) GetStatus(ctx context.Context, id string, opts ...RequestOption) (*TunnelStatus, error) {
+	var resp struct {
+		Status TunnelStatus `json:"status"`
+	}
+	if err := r.t.do(ctx, "GET", "/api/tunnels/"+url.PathEscape(id)+"/status", nil, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Status, nil
+}
+
 // Create creates a new tunnel.
 func (r *TunnelsResource) Create(ctx context.Context, params *CreateTunnelParams, opts ...RequestOption) (*Tunnel, error) {
 	var resp struct {
@@ -65,7 +143,124 @@ func (r *TunnelsResource) Create(ctx context.Context, params *CreateTunnelParams
 	return &resp.Tunnel, nil
 }
 
+// Update updates a tunnel. If the requested subdomain is already taken, a
+// *ValidationError is returned with the subdomain under Details["subdomain"].
+func (r *TunnelsResource) Update(ctx context.Context, id string, params *UpdateTunnelParams, opts ...RequestOption) (*Tunnel, error) {
+	var resp struct {
+		Tunnel Tunnel `json:"tunnel"`
+	}
+	if err := r.t.do(ctx, "PATCH", "/api/tunnels/"+url.PathEscape(id), nil, params, &resp, opts...); err != nil {
+		if params != nil && params.Subdomain != nil {
+			return nil, subdomainConflictError(err, *params.Subdomain)
+		}
+		return nil, err
+	}
+	return &resp.Tunnel, nil
+}
+
+// RotateToken generates a new auth token for a tunnel and returns it. The previous
+// token stops working immediately, so connected tunnel clients must reconnect.
+func (r *TunnelsResource) RotateToken(ctx context.Context, id string, opts ...RequestOption) (string, error) {
+	var resp struct {
+		AuthToken string `json:"authToken"`
+	}
+	if err := r.t.do(ctx, "POST", "/api/tunnels/"+url.PathEscape(id)+"/regenerate-token", nil, nil, &resp, opts...); err != nil {
+		return "", err
+	}
+	return resp.AuthToken, nil
+}
+
+// subdomainConflictError converts a subdomain conflict into a *ValidationError that
+// records the conflicting subdomain. Other errors are returned unchanged.
+func subdomainConflictError(err error, subdomain string) error {
+	var verr *ValidationError
+	switch e := err.(type) {
+	case *ConflictError:
+		verr = &ValidationError{
+			APIError:         e.APIError,
+			ValidationErrors: map[string][]string{"subdomain": {e.Message}},
+		}
+	case *ValidationError:
+		if _, ok := e.ValidationErrors["subdomain"]; !ok {
+			return err
+		}
+		verr = e
+	default:
+		return err
+	}
+	if verr.Details == nil {
+		verr.Details = map[string]interface{}{}
+	}
+	verr.Details["subdomain"] = subdomain
+	return verr
+}
+
 // Delete deletes a tunnel.
 func (r *TunnelsResource) Delete(ctx context.Context, id string, opts ...RequestOption) error {
 	return r.t.do(ctx, "DELETE", "/api/tunnels/"+url.PathEscape(id), nil, nil, nil, opts...)
 }
+
+// BulkDelete deletes multiple tunnels.
+func (r *TunnelsResource) BulkDelete(ctx context.Context, ids []string, opts ...RequestOption) (*BulkDeleteResult, error) {
+	if len(ids) == 0 {
+		return nil, newClientValidationError("ids", "must contain at least one tunnel ID")
+	}
+	var resp BulkDeleteResult
+	body := map[string]interface{}{"ids": ids}
+	if err := r.t.do(ctx, "DELETE", "/api/tunnels/bulk", nil, body, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DeleteAll deletes every tunnel in the organization, disconnecting any connected
+// clients. It is intended for cleanup at the end of a development session or CI run.
+func (r *TunnelsResource) DeleteAll(ctx context.Context, opts ...RequestOption) (*BulkDeleteResult, error) {
+	var resp BulkDeleteResult
+	if err := r.t.do(ctx, "DELETE", "/api/tunnels", nil, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListRequests returns a cursor-paginated list of requests captured by a tunnel, newest first.
+func (r *TunnelsResource) ListRequests(ctx context.Context, id string, params *ListTunnelRequestsParams, opts ...RequestOption) (*CursorResponse[TunnelRequest], error) {
+	var resp struct {
+		Data       []TunnelRequest `json:"data"`
+		Pagination struct {
+			HasMore    bool    `json:"hasMore"`
+			NextCursor *string `json:"nextCursor"`
+		} `json:"pagination"`
+	}
+	if err := r.t.do(ctx, "GET", "/api/tunnels/"+url.PathEscape(id)+"/requests", params.toQuery(), nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &CursorResponse[TunnelRequest]{
+		Data:       resp.Data,
+		HasMore:    resp.Pagination.HasMore,
+		NextCursor: resp.Pagination.NextCursor,
+	}, nil
+}
+
+// GetRequest returns a captured tunnel request with full bodies.
+func (r *TunnelsResource) GetRequest(ctx context.Context, id, requestID string, opts ...RequestOption) (*TunnelRequestDetail, error) {
+	var resp struct {
+		Request TunnelRequestDetail `json:"request"`
+	}
+	if err := r.t.do(ctx, "GET", "/api/tunnels/"+url.PathEscape(id)+"/requests/"+url.PathEscape(requestID), nil, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Request, nil
+}
+
+// ReplayRequest re-sends a captured request to the tunnel's local port and returns the
+// newly captured request. The tunnel must be connected.
+func (r *TunnelsResource) ReplayRequest(ctx context.Context, id, requestID string, opts ...RequestOption) (*TunnelRequestDetail, error) {
+	var resp struct {
+		Request TunnelRequestDetail `json:"request"`
+	}
+	if err := r.t.do(ctx, "POST", "/api/tunnels/"+url.PathEscape(id)+"/requests/"+url.PathEscape(requestID)+"/replay", nil, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Request, nil
+}
@@ -0,0 +1,131 @@
+package hookbase
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Usage is the organization's consumption of each metered plan dimension.
+type Usage struct {
+	Plan        string `json:"plan"`
+	PeriodStart string `json:"periodStart"`
+	PeriodEnd   string `json:"periodEnd"`
+	// Events counts inbound events received this billing period.
+	Events UsageQuota `json:"events"`
+	// OutboundMessages counts webhook messages sent this billing period.
+	OutboundMessages UsageQuota `json:"outboundMessages"`
+	// DLQMessages counts messages currently in the dead letter queue.
+	DLQMessages  UsageQuota `json:"dlqMessages"`
+	Sources      UsageQuota `json:"sources"`
+	Destinations UsageQuota `json:"destinations"`
+	Applications UsageQuota `json:"applications"`
+	Endpoints    UsageQuota `json:"endpoints"`
+	CronJobs     UsageQuota `json:"cronJobs"`
+	Tunnels      UsageQuota `json:"tunnels"`
+}
+
+// QuotaWarning reports a usage dimension that is close to its limit.
+type QuotaWarning struct {
+	// Dimension is the JSON name of the Usage field, such as "events".
+	Dimension string
+	Used      int
+	Limit     int
+	// Fraction is Used divided by Limit.
+	Fraction float64
+	ResetsAt *string
+}
+
+// NearingLimit returns a warning for each limited dimension whose usage is at least
+// threshold of its limit, such as 0.8 for 80%. Unlimited dimensions are skipped.
+func (u *Usage) NearingLimit(threshold float64) []QuotaWarning {
+	dimensions := []struct {
+		name  string
+		quota UsageQuota
+	}{
+		{"events", u.Events},
+		{"outboundMessages", u.OutboundMessages},
+		{"dlqMessages", u.DLQMessages},
+		{"sources", u.Sources},
+		{"destinations", u.Destinations},
+		{"applications", u.Applications},
+		{"endpoints", u.Endpoints},
+		{"cronJobs", u.CronJobs},
+		{"tunnels", u.Tunnels},
+	}
+	var warnings []QuotaWarning
+	for _, d := range dimensions {
+		if d.quota.Limit == nil || *d.quota.Limit <= 0 {
+			continue
+		}
+		if f := d.quota.Fraction(); f >= threshold {
+			warnings = append(warnings, QuotaWarning{
+				Dimension: d.name,
+				Used:      d.quota.Used,
+				Limit:     *d.quota.Limit,
+				Fraction:  f,
+				ResetsAt:  d.quota.ResetsAt,
+			})
+		}
+	}
+	return warnings
+}
+
+// UsageResource provides access to plan usage and quotas.
+type UsageResource struct {
+	t *transport
+}
+
+// Get returns the organization's current usage of each metered dimension.
+func (r *UsageResource) Get(ctx context.Context, opts ...RequestOption) (*Usage, error) {
+	var resp struct {
+		Data Usage `json:"data"`
+	}
+	if err := r.t.do(ctx, "GET", "/api/usage", nil, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// parseQuotaHeaders collects X-Quota-{Dimension}-Used, -Limit, and -Reset headers by
+// lowercased dimension. Headers with non-numeric counts are ignored.
+func parseQuotaHeaders(h http.Header) map[string]UsageQuota {
+	var quotas map[string]UsageQuota
+	for key, values := range h {
+		rest, ok := strings.CutPrefix(key, "X-Quota-")
+		if !ok || len(values) == 0 {
+			continue
+		}
+		i := strings.LastIndexByte(rest, '-')
+		if i <= 0 {
+			continue
+		}
+		dimension, field := strings.ToLower(rest[:i]), rest[i+1:]
+		q := quotas[dimension]
+		switch field {
+		case "Used":
+			n, err := strconv.Atoi(values[0])
+			if err != nil {
+				continue
+			}
+			q.Used = n
+		case "Limit":
+			n, err := strconv.Atoi(values[0])
+			if err != nil {
+				continue
+			}
+			q.Limit = &n
+		case "Reset":
+			reset := values[0]
+			q.ResetsAt = &reset
+		default:
+			continue
+		}
+		if quotas == nil {
+			quotas = make(map[string]UsageQuota)
+		}
+		quotas[dimension] = q
+	}
+	return quotas
+}
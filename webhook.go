@@ -1,32 +1,144 @@
 package hookbase
 
 import (
+	"bytes"
+	"context"
 	"crypto/hmac"
+	"crypto/rand"
 	"crypto/sha256"
 	"crypto/subtle"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"math"
+	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 const defaultTolerance = 300 // 5 minutes in seconds
 
-// Webhook handles webhook signature verification.
+// DefaultMaxWebhookBodyBytes is the largest request body VerifyFromRequest and
+// NewWebhookHandler read unless changed with SetMaxBodyBytes or
+// WithWebhookMaxBodyBytes.
+const DefaultMaxWebhookBodyBytes = 1 << 20 // 1 MiB
+
+// WebhookVerifier verifies incoming webhook signatures. *Webhook implements it; accept
+// a WebhookVerifier instead of a *Webhook to substitute a fake in tests, such as
+// those in the hookbasetest package.
+type WebhookVerifier interface {
+	Verify(payload []byte, headers map[string]string) error
+	VerifyFromRequest(r *http.Request) error
+}
+
+var _ WebhookVerifier = (*Webhook)(nil)
+
+// Webhook handles webhook signature verification. It is safe for concurrent use,
+// including while secrets are added or removed.
 type Webhook struct {
-	secret []byte
+	mu           sync.RWMutex
+	secrets      [][]byte
+	clock        func() time.Time
+	maxBodyBytes int64
 }
 
 // NewWebhook creates a new Webhook verifier with the given signing secret.
 // The secret may be prefixed with "whsec_" and is expected to be base64-encoded.
 func NewWebhook(secret string) *Webhook {
-	if secret == "" {
+	return NewWebhookMulti(secret)
+}
+
+// NewWebhookMulti creates a Webhook verifier that accepts signatures from any of the
+// given secrets. Use it during a secret rotation grace period, passing both the new and
+// the old secret. The first secret is used by GenerateTestHeaders.
+func NewWebhookMulti(secrets ...string) *Webhook {
+	if len(secrets) == 0 {
 		panic("hookbase: webhook secret is required")
 	}
+	w := &Webhook{secrets: make([][]byte, len(secrets)), clock: time.Now}
+	for i, secret := range secrets {
+		if secret == "" {
+			panic("hookbase: webhook secret is required")
+		}
+		w.secrets[i] = decodeSecret(secret)
+	}
+	return w
+}
+
+// NewWebhookWithClock is like NewWebhook but reads the current time from clock, which
+// is used for timestamp tolerance checks and by Sign. It lets tests use fixed times.
+func NewWebhookWithClock(secret string, clock func() time.Time) *Webhook {
+	w := NewWebhook(secret)
+	if clock != nil {
+		w.clock = clock
+	}
+	return w
+}
+
+// NewWebhookWithSecrets is like NewWebhookMulti but takes the secrets as a slice.
+func NewWebhookWithSecrets(secrets []string) *Webhook {
+	return NewWebhookMulti(secrets...)
+}
+
+// AddSecret adds a secret that Verify will accept, for example the new secret at the
+// start of a rotation.
+func (w *Webhook) AddSecret(secret string) error {
+	if secret == "" {
+		return &Error{Message: "hookbase: webhook secret is required"}
+	}
+	decoded := decodeSecret(secret)
+	w.mu.Lock()
+	w.secrets = append(w.secrets, decoded)
+	w.mu.Unlock()
+	return nil
+}
+
+// RemoveSecret removes the secret at index, in the order the secrets were given and
+// added, for example once an old secret's grace period has ended. An out-of-range
+// index, or removing the only remaining secret, is a no-op.
+func (w *Webhook) RemoveSecret(index int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if index < 0 || index >= len(w.secrets) || len(w.secrets) == 1 {
+		return
+	}
+	secrets := make([][]byte, 0, len(w.secrets)-1)
+	secrets = append(secrets, w.secrets[:index]...)
+	w.secrets = append(secrets, w.secrets[index+1:]...)
+}
+
+// SetMaxBodyBytes sets the largest request body VerifyFromRequest reads, rejecting
+// larger ones with *WebhookBodyTooLargeError before checking the signature. n <= 0
+// restores DefaultMaxWebhookBodyBytes.
+func (w *Webhook) SetMaxBodyBytes(n int64) {
+	w.mu.Lock()
+	w.maxBodyBytes = n
+	w.mu.Unlock()
+}
+
+func (w *Webhook) bodyLimit() int64 {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.maxBodyBytes <= 0 {
+		return DefaultMaxWebhookBodyBytes
+	}
+	return w.maxBodyBytes
+}
 
+// currentSecrets returns the secrets in use. The returned slice is never modified, so
+// it can be read without holding the lock.
+func (w *Webhook) currentSecrets() [][]byte {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.secrets
+}
+
+func decodeSecret(secret string) []byte {
 	s := secret
 	if strings.HasPrefix(s, "whsec_") {
 		s = s[6:]
@@ -37,8 +149,7 @@ func NewWebhook(secret string) *Webhook {
 		// Try raw bytes if not valid base64
 		decoded = []byte(s)
 	}
-
-	return &Webhook{secret: decoded}
+	return decoded
 }
 
 // Verify verifies the webhook signature and returns an error if verification fails.
@@ -72,7 +183,7 @@ func (w *Webhook) VerifyWithTolerance(payload []byte, headers map[string]string,
 		return &WebhookVerificationError{Message: "invalid timestamp format"}
 	}
 
-	now := time.Now().Unix()
+	now := w.clock().Unix()
 	diff := math.Abs(float64(now - ts))
 	if diff > float64(toleranceSec) {
 		return &WebhookVerificationError{
@@ -83,20 +194,20 @@ func (w *Webhook) VerifyWithTolerance(payload []byte, headers map[string]string,
 	// Build signed content
 	signedContent := fmt.Sprintf("%s.%s.%s", webhookID, webhookTimestamp, string(payload))
 
-	// Compute expected signature
-	expected := w.sign(signedContent)
-
 	// Parse and check signatures
 	signatures := parseSignatures(webhookSignature)
 	if len(signatures) == 0 {
 		return &WebhookVerificationError{Message: "no valid signatures found"}
 	}
 
-	for _, sig := range signatures {
-		if sig.version == "v1" {
-			expectedBytes, err1 := base64.StdEncoding.DecodeString(expected)
-			actualBytes, err2 := base64.StdEncoding.DecodeString(sig.signature)
-			if err1 != nil || err2 != nil {
+	for _, secret := range w.currentSecrets() {
+		expectedBytes := computeSignature(secret, signedContent)
+		for _, sig := range signatures {
+			if sig.version != "v1" {
+				continue
+			}
+			actualBytes, err := base64.StdEncoding.DecodeString(sig.signature)
+			if err != nil {
 				continue
 			}
 			if len(expectedBytes) == len(actualBytes) &&
@@ -109,6 +220,108 @@ func (w *Webhook) VerifyWithTolerance(payload []byte, headers map[string]string,
 	return &WebhookVerificationError{Message: "signature verification failed"}
 }
 
+// VerifyFromRequest verifies the signature of an incoming webhook request using the
+// default tolerance. It reads r.Body and then restores it, so the handler can still
+// read the payload afterwards. A body larger than the limit set with SetMaxBodyBytes,
+// DefaultMaxWebhookBodyBytes by default, returns *WebhookBodyTooLargeError.
+func (w *Webhook) VerifyFromRequest(r *http.Request) error {
+	var payload []byte
+	if r.Body != nil {
+		limit := w.bodyLimit()
+		var err error
+		payload, err = io.ReadAll(io.LimitReader(r.Body, limit+1))
+		r.Body.Close()
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) || int64(len(payload)) > limit {
+			return &WebhookBodyTooLargeError{Limit: limit}
+		}
+		if err != nil {
+			return &WebhookVerificationError{Message: fmt.Sprintf("failed to read request body: %v", err)}
+		}
+	}
+	r.Body = io.NopCloser(bytes.NewReader(payload))
+	return w.VerifyWithHTTPHeader(payload, r.Header)
+}
+
+// VerifyWithHTTPHeader verifies the webhook signature using headers in net/http form.
+// Header names are matched case-insensitively and the first value of each is used.
+func (w *Webhook) VerifyWithHTTPHeader(payload []byte, header http.Header) error {
+	headers := make(map[string]string, 3)
+	for _, name := range []string{"webhook-id", "webhook-timestamp", "webhook-signature"} {
+		if v := header.Values(name); len(v) > 0 {
+			headers[name] = v[0]
+			continue
+		}
+		// Keys set directly on the map may not be in canonical form.
+		for k, v := range header {
+			if strings.EqualFold(k, name) && len(v) > 0 {
+				headers[name] = v[0]
+				break
+			}
+		}
+	}
+	return w.VerifyWithTolerance(payload, headers, defaultTolerance)
+}
+
+type webhookContextKey struct{}
+
+// WebhookBodyKey is the context key under which NewWebhookHandler stores the verified
+// request body. Use WebhookBody to read it.
+var WebhookBodyKey = webhookContextKey{}
+
+// WebhookBody returns the verified request body stored by NewWebhookHandler, or nil if
+// ctx does not carry one.
+func WebhookBody(ctx context.Context) []byte {
+	body, _ := ctx.Value(WebhookBodyKey).([]byte)
+	return body
+}
+
+// WebhookHandlerOption configures NewWebhookHandler.
+type WebhookHandlerOption func(*Webhook)
+
+// WithWebhookMaxBodyBytes sets the largest request body NewWebhookHandler accepts,
+// instead of DefaultMaxWebhookBodyBytes. See Webhook.SetMaxBodyBytes.
+func WithWebhookMaxBodyBytes(n int64) WebhookHandlerOption {
+	return func(w *Webhook) {
+		w.SetMaxBodyBytes(n)
+	}
+}
+
+// NewWebhookHandler returns middleware that verifies the signature of every request
+// before calling next. Requests that fail verification get a 401 response with a JSON
+// error body, and requests with a body over DefaultMaxWebhookBodyBytes (or the limit
+// set with WithWebhookMaxBodyBytes) get a 413; neither reaches next. For verified
+// requests, the raw body is available from WebhookBody(r.Context()) and r.Body can
+// still be read. It works with any router that accepts an http.Handler.
+func NewWebhookHandler(secret string, next http.Handler, opts ...WebhookHandlerOption) http.Handler {
+	wh := NewWebhook(secret)
+	for _, opt := range opts {
+		opt(wh)
+	}
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Body != nil {
+			r.Body = http.MaxBytesReader(rw, r.Body, wh.bodyLimit())
+		}
+		err := wh.VerifyFromRequest(r)
+		if err != nil {
+			status, code := http.StatusUnauthorized, "invalid_signature"
+			if _, ok := err.(*WebhookBodyTooLargeError); ok {
+				status, code = http.StatusRequestEntityTooLarge, "payload_too_large"
+			}
+			rw.Header().Set("Content-Type", "application/json")
+			rw.WriteHeader(status)
+			json.NewEncoder(rw).Encode(map[string]interface{}{
+				"error": map[string]string{"message": err.Error(), "code": code},
+			})
+			return
+		}
+		// VerifyFromRequest left an in-memory copy of the body in r.Body.
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(rw, r.WithContext(context.WithValue(r.Context(), WebhookBodyKey, body)))
+	})
+}
+
 // VerifyAndParse verifies the webhook and unmarshals the payload into v.
 func (w *Webhook) VerifyAndParse(payload []byte, headers map[string]string, v interface{}) error {
 	if err := w.Verify(payload, headers); err != nil {
@@ -117,12 +330,14 @@ func (w *Webhook) VerifyAndParse(payload []byte, headers map[string]string, v in
 	return json.Unmarshal(payload, v)
 }
 
-// GenerateTestHeaders generates valid webhook headers for testing.
-func (w *Webhook) GenerateTestHeaders(payload []byte, webhookID string) map[string]string {
+// Sign generates the webhook-id, webhook-timestamp, and webhook-signature headers for
+// sending payload, signed with the first secret at the current time. If webhookID is
+// empty, a random "msg_" ID is generated. Receivers can check the headers with Verify.
+func (w *Webhook) Sign(payload []byte, webhookID string) map[string]string {
 	if webhookID == "" {
-		webhookID = "msg_test"
+		webhookID = newWebhookID()
 	}
-	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	timestamp := strconv.FormatInt(w.clock().Unix(), 10)
 	signedContent := fmt.Sprintf("%s.%s.%s", webhookID, timestamp, string(payload))
 	signature := w.sign(signedContent)
 
@@ -133,10 +348,45 @@ func (w *Webhook) GenerateTestHeaders(payload []byte, webhookID string) map[stri
 	}
 }
 
+// GenerateTestHeaders generates valid webhook headers for testing.
+func (w *Webhook) GenerateTestHeaders(payload []byte, webhookID string) map[string]string {
+	if webhookID == "" {
+		webhookID = "msg_test"
+	}
+	return w.Sign(payload, webhookID)
+}
+
+// GenerateTestRequest builds an *http.Request carrying payload and valid webhook
+// headers, for testing handlers such as those wrapped by NewWebhookHandler.
+func (w *Webhook) GenerateTestRequest(method string, url string, payload []byte, webhookID string) (*http.Request, error) {
+	req, err := http.NewRequest(method, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range w.GenerateTestHeaders(payload, webhookID) {
+		req.Header.Set(k, v)
+	}
+	return req, nil
+}
+
+// newWebhookID returns a random message ID.
+func newWebhookID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("msg_%d", time.Now().UnixNano())
+	}
+	return "msg_" + hex.EncodeToString(b)
+}
+
 func (w *Webhook) sign(content string) string {
-	mac := hmac.New(sha256.New, w.secret)
+	return base64.StdEncoding.EncodeToString(computeSignature(w.currentSecrets()[0], content))
+}
+
+func computeSignature(secret []byte, content string) []byte {
+	mac := hmac.New(sha256.New, secret)
 	mac.Write([]byte(content))
-	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	return mac.Sum(nil)
 }
 
 type parsedSignature struct {
@@ -2,8 +2,13 @@ package hookbase
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
@@ -120,6 +125,36 @@ func TestWebhookVerifyExpiredTimestamp(t *testing.T) {
 	}
 }
 
+func TestWebhookWithClock(t *testing.T) {
+	secret := base64.StdEncoding.EncodeToString([]byte("clock-secret"))
+	signedAt := time.Unix(1700000000, 0)
+	payload := []byte(`{}`)
+	headers := NewWebhookWithClock(secret, func() time.Time { return signedAt }).Sign(payload, "msg_clock")
+	if headers["webhook-timestamp"] != "1700000000" {
+		t.Fatalf("expected Sign to use the injected clock, got %s", headers["webhook-timestamp"])
+	}
+
+	cases := []struct {
+		name    string
+		now     time.Time
+		wantErr bool
+	}{
+		{"same time", signedAt, false},
+		{"at tolerance", signedAt.Add(defaultTolerance * time.Second), false},
+		{"expired", signedAt.Add((defaultTolerance + 1) * time.Second), true},
+		{"future", signedAt.Add(-(defaultTolerance + 1) * time.Second), true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			wh := NewWebhookWithClock(secret, func() time.Time { return tc.now })
+			err := wh.Verify(payload, headers)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("expected error=%v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
 func TestWebhookVerifyInvalidSignature(t *testing.T) {
 	wh := NewWebhook(base64.StdEncoding.EncodeToString([]byte("secret")))
 
@@ -158,6 +193,213 @@ func TestWebhookVerifyCaseInsensitiveHeaders(t *testing.T) {
 	}
 }
 
+func TestWebhookVerifyFromRequest(t *testing.T) {
+	secret := base64.StdEncoding.EncodeToString([]byte("request-secret"))
+	wh := NewWebhook(secret)
+
+	payload := `{"event":"test"}`
+	req := httptest.NewRequest("POST", "/webhooks", strings.NewReader(payload))
+	for k, v := range wh.GenerateTestHeaders([]byte(payload), "msg_req") {
+		req.Header.Set(k, v)
+	}
+
+	if err := wh.VerifyFromRequest(req); err != nil {
+		t.Fatalf("expected successful verification, got: %v", err)
+	}
+	body, _ := io.ReadAll(req.Body)
+	if string(body) != payload {
+		t.Errorf("expected body to be restored, got %q", body)
+	}
+
+	req = httptest.NewRequest("POST", "/webhooks", strings.NewReader(`{"event":"tampered"}`))
+	for k, v := range wh.GenerateTestHeaders([]byte(payload), "msg_req") {
+		req.Header.Set(k, v)
+	}
+	if err := wh.VerifyFromRequest(req); err == nil {
+		t.Fatal("expected verification to fail for a tampered body")
+	}
+}
+
+func TestWebhookVerifyWithHTTPHeader(t *testing.T) {
+	secret := base64.StdEncoding.EncodeToString([]byte("header-secret"))
+	wh := NewWebhook(secret)
+
+	payload := []byte(`{"event":"test"}`)
+	header := http.Header{}
+	for k, v := range wh.GenerateTestHeaders(payload, "msg_hdr") {
+		header.Set(k, v)
+	}
+	// A non-canonical key must still be found.
+	header["webhook-id"] = header["Webhook-Id"]
+	delete(header, "Webhook-Id")
+
+	if err := wh.VerifyWithHTTPHeader(payload, header); err != nil {
+		t.Fatalf("expected successful verification, got: %v", err)
+	}
+	header.Del("Webhook-Signature")
+	if err := wh.VerifyWithHTTPHeader(payload, header); err == nil {
+		t.Fatal("expected error for missing signature header")
+	}
+}
+
+func TestWebhookSecretRotation(t *testing.T) {
+	oldSecret := base64.StdEncoding.EncodeToString([]byte("old-secret"))
+	newSecret := base64.StdEncoding.EncodeToString([]byte("new-secret"))
+	payload := []byte(`{"event":"test"}`)
+	headers := NewWebhook(newSecret).GenerateTestHeaders(payload, "msg_rot")
+
+	if err := NewWebhook(oldSecret).Verify(payload, headers); err == nil {
+		t.Fatal("expected old secret alone to reject the payload")
+	}
+	wh := NewWebhookWithSecrets([]string{oldSecret, newSecret})
+	if err := wh.Verify(payload, headers); err != nil {
+		t.Fatalf("expected payload signed with the second secret to verify, got: %v", err)
+	}
+
+	wh.RemoveSecret(1)
+	if err := wh.Verify(payload, headers); err == nil {
+		t.Fatal("expected verification to fail after removing the new secret")
+	}
+	if err := wh.AddSecret(newSecret); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wh.RemoveSecret(0)
+	if err := wh.Verify(payload, headers); err != nil {
+		t.Fatalf("expected verification with the added secret, got: %v", err)
+	}
+	wh.RemoveSecret(0)
+	if err := wh.Verify(payload, headers); err != nil {
+		t.Fatalf("removing the only secret should be a no-op, got: %v", err)
+	}
+	if err := wh.AddSecret(""); err == nil {
+		t.Error("expected error for empty secret")
+	}
+}
+
+func TestNewWebhookHandler(t *testing.T) {
+	secret := base64.StdEncoding.EncodeToString([]byte("handler-secret"))
+	payload := `{"event":"test"}`
+	var got []byte
+	calls := 0
+	handler := NewWebhookHandler(secret, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		got = WebhookBody(r.Context())
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != payload {
+			t.Errorf("expected r.Body to be readable, got %q", body)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	req := httptest.NewRequest("POST", "/webhooks", strings.NewReader(payload))
+	for k, v := range NewWebhook(secret).GenerateTestHeaders([]byte(payload), "msg_mw") {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent || string(got) != payload {
+		t.Errorf("expected verified request to reach handler, got status %d body %q", rec.Code, got)
+	}
+
+	req = httptest.NewRequest("POST", "/webhooks", strings.NewReader(payload))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized || calls != 1 {
+		t.Errorf("expected 401 without calling next, got status %d after %d calls", rec.Code, calls)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" || !strings.Contains(rec.Body.String(), "invalid_signature") {
+		t.Errorf("expected JSON error body, got %s %q", ct, rec.Body.String())
+	}
+	if WebhookBody(req.Context()) != nil {
+		t.Error("expected no body in an unrelated context")
+	}
+}
+
+func TestWebhookBodyLimit(t *testing.T) {
+	secret := base64.StdEncoding.EncodeToString([]byte("limit-secret"))
+	signed := func(payload string) *http.Request {
+		req := httptest.NewRequest("POST", "/webhooks", strings.NewReader(payload))
+		for k, v := range NewWebhook(secret).GenerateTestHeaders([]byte(payload), "msg_big") {
+			req.Header.Set(k, v)
+		}
+		return req
+	}
+
+	wh := NewWebhook(secret)
+	big := strings.Repeat("x", DefaultMaxWebhookBodyBytes+1)
+	var tooLarge *WebhookBodyTooLargeError
+	if err := wh.VerifyFromRequest(signed(big)); !errors.As(err, &tooLarge) || tooLarge.Limit != DefaultMaxWebhookBodyBytes {
+		t.Errorf("expected WebhookBodyTooLargeError over the default limit, got %v", err)
+	}
+	wh.SetMaxBodyBytes(int64(len(big)))
+	if err := wh.VerifyFromRequest(signed(big)); err != nil {
+		t.Errorf("expected a raised limit to accept the body, got %v", err)
+	}
+
+	calls := 0
+	handler := NewWebhookHandler(secret, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}), WithWebhookMaxBodyBytes(16))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, signed(`{"event":"test","padding":"xxxxxxxx"}`))
+	if rec.Code != http.StatusRequestEntityTooLarge || calls != 0 || !strings.Contains(rec.Body.String(), "payload_too_large") {
+		t.Errorf("expected 413 without calling next, got %d %q after %d calls", rec.Code, rec.Body.String(), calls)
+	}
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, signed(`{"a":1}`))
+	if rec.Code != http.StatusOK || calls != 1 {
+		t.Errorf("expected a small body to pass, got %d after %d calls", rec.Code, calls)
+	}
+}
+
+func TestWebhookSign(t *testing.T) {
+	secret := base64.StdEncoding.EncodeToString([]byte("sign-secret"))
+	wh := NewWebhook(secret)
+	payload := []byte(`{"event":"forwarded"}`)
+
+	headers := wh.Sign(payload, "msg_fwd")
+	if headers["webhook-id"] != "msg_fwd" {
+		t.Errorf("expected webhook-id msg_fwd, got %s", headers["webhook-id"])
+	}
+	if err := wh.Verify(payload, headers); err != nil {
+		t.Fatalf("expected signed headers to verify, got: %v", err)
+	}
+
+	a, b := wh.Sign(payload, ""), wh.Sign(payload, "")
+	if !strings.HasPrefix(a["webhook-id"], "msg_") || a["webhook-id"] == b["webhook-id"] {
+		t.Errorf("expected unique generated IDs, got %s and %s", a["webhook-id"], b["webhook-id"])
+	}
+	if err := wh.Verify(payload, a); err != nil {
+		t.Fatalf("expected headers with generated ID to verify, got: %v", err)
+	}
+}
+
+func TestWebhookGenerateTestRequest(t *testing.T) {
+	secret := base64.StdEncoding.EncodeToString([]byte("request-secret"))
+	wh := NewWebhook(secret)
+	payload := []byte(`{"event":"test"}`)
+
+	req, err := wh.GenerateTestRequest("POST", "http://example.com/webhooks", payload, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.Header.Get("Webhook-Id") != "msg_test" || req.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("unexpected headers: %v", req.Header)
+	}
+
+	rec := httptest.NewRecorder()
+	NewWebhookHandler(secret, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})).ServeHTTP(rec, req)
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("expected generated request to pass verification, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	if _, err := wh.GenerateTestRequest("BAD METHOD", "http://example.com", payload, ""); err == nil {
+		t.Error("expected error for invalid method")
+	}
+}
+
 func TestWebhookPanicsWithoutSecret(t *testing.T) {
 	defer func() {
 		if r := recover(); r == nil {
@@ -251,3 +493,23 @@ func TestErrorMessages(t *testing.T) {
 		}
 	}
 }
+
+func TestWebhookVerifyMultipleSecrets(t *testing.T) {
+	oldSecret := base64.StdEncoding.EncodeToString([]byte("old-secret"))
+	newSecret := "whsec_" + base64.StdEncoding.EncodeToString([]byte("new-secret"))
+	payload := []byte(`{"event":"rotation"}`)
+
+	oldHeaders := NewWebhook(oldSecret).GenerateTestHeaders(payload, "msg_old")
+	newHeaders := NewWebhook(newSecret).GenerateTestHeaders(payload, "msg_new")
+
+	wh := NewWebhookMulti(newSecret, oldSecret)
+	if err := wh.Verify(payload, oldHeaders); err != nil {
+		t.Errorf("expected old secret to verify, got: %v", err)
+	}
+	if err := wh.Verify(payload, newHeaders); err != nil {
+		t.Errorf("expected new secret to verify, got: %v", err)
+	}
+	if err := NewWebhook(newSecret).Verify(payload, oldHeaders); err == nil {
+		t.Error("expected old signature to fail against the new secret alone")
+	}
+}